@@ -0,0 +1,55 @@
+//------------------------------------------------------------------------------
+//    Copyright 2019 Jeff Sharpe (zeropointx.io)
+//
+//    Licensed under the Apache License, Version 2.0 (the "License");
+//    you may not use this file except in compliance with the License.
+//    You may obtain a copy of the License at
+//
+//        http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS,
+//    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//    See the License for the specific language governing permissions and
+//    limitations under the License.
+//------------------------------------------------------------------------------
+
+package data
+
+import (
+	"github.com/stretchr/testify/suite"
+	"github.com/zpxio/heromanager/internal/game/data/content"
+	"github.com/zpxio/heromanager/internal/game/util"
+	"testing"
+)
+
+type EmbedTestSuite struct {
+	suite.Suite
+}
+
+func TestEmbedSuite(t *testing.T) {
+	suite.Run(t, new(EmbedTestSuite))
+}
+
+func (s *EmbedTestSuite) TestLoad() {
+	util.SetEmbeddedData(Files)
+	defer util.SetEmbeddedData(nil)
+
+	set, err := content.Load(util.EmbeddedDir)
+	s.Require().Nil(err)
+
+	s.NotEmpty(set.Classifiers.AllRaces())
+	s.NotEmpty(set.Derived.All())
+	s.NotEmpty(set.Rolling.Default)
+	s.Nil(set.Progression.Validate())
+	s.NotEmpty(set.Skills.All())
+	s.NotEmpty(set.Quests.All())
+	s.NotEmpty(set.Enemies.All())
+	s.NotEmpty(set.Encounters.All())
+	s.NotEmpty(set.Items.All())
+	s.NotEmpty(set.Loot.All())
+	s.False(set.Economy.Empty())
+	s.False(set.Recruitment.Empty())
+	s.NotEmpty(set.Classifiers.AllCastes())
+	s.NotEmpty(set.Classifiers.AllProfessions())
+}
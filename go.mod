@@ -1,6 +1,6 @@
 module github.com/zpxio/heromanager
 
-go 1.12
+go 1.16
 
 require (
 	github.com/ghodss/yaml v1.0.0
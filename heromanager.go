@@ -18,18 +18,50 @@ package main
 
 import (
 	"flag"
+	"fmt"
+	"github.com/zpxio/heromanager/data"
 	"github.com/zpxio/heromanager/internal/api"
 	"github.com/zpxio/heromanager/internal/game"
+	"github.com/zpxio/heromanager/internal/game/data/content"
+	"github.com/zpxio/heromanager/internal/game/data/loot"
+	"github.com/zpxio/heromanager/internal/game/util"
 	"log"
+	"math/rand"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
 )
 
 func main() {
+	util.SetEmbeddedData(data.Files)
 
-	log.Printf("Starting up...")
-
-	dataDirectory := flag.String("data", "/usr/local/share/heromanager", "The directory to load game data from.")
+	flag.Usage = func() {
+		fmt.Fprintf(flag.CommandLine.Output(), "Usage: %s [flags]\n       %s export-data [-force] <dir>\n       %s schema [-data dir] <dir>\n       %s loot [-data dir] [-runs n] [-tier t] [-professions p,...] [-seed s] <table>\n\nFlags:\n", os.Args[0], os.Args[0], os.Args[0], os.Args[0])
+		flag.PrintDefaults()
+	}
+	dataDirectory := flag.String("data", "", "The directory to load game data from. Defaults to the data built into the binary.")
+	watchInterval := flag.Duration("watch", 0, "Reload game data when it changes, checking at this interval (0 disables).")
 	flag.Parse()
 
+	if flag.Arg(0) == "export-data" {
+		exportData(flag.Args()[1:])
+		return
+	}
+	if flag.Arg(0) == "schema" {
+		writeSchemas(flag.Args()[1:])
+		return
+	}
+	if flag.Arg(0) == "loot" {
+		simulateLoot(flag.Args()[1:])
+		return
+	}
+
+	log.Printf("Starting up...")
+
+	if *dataDirectory == "" {
+		*dataDirectory = util.EmbeddedDir
+	}
 	log.Printf("Data directory: %s", *dataDirectory)
 
 	world := game.CreateWorld()
@@ -40,7 +72,108 @@ func main() {
 	apiServer := api.CreateServer(world)
 
 	world.Start()
+	if *watchInterval > 0 {
+		if util.IsEmbedded(*dataDirectory) {
+			log.Printf("Not watching the built-in game data")
+		} else {
+			log.Printf("Watching game data every %s", *watchInterval)
+			world.Watch(*watchInterval)
+		}
+	}
 	apiServer.Start()
 
 	world.AwaitShutdown()
 }
+
+// exportData writes the built-in game data to a directory, as a starting
+// point for editing.
+func exportData(args []string) {
+	command := flag.NewFlagSet("export-data", flag.ExitOnError)
+	force := command.Bool("force", false, "Overwrite existing files.")
+	command.Parse(args)
+
+	if command.NArg() != 1 {
+		fmt.Fprintf(os.Stderr, "Usage: %s export-data [-force] <dir>\n", os.Args[0])
+		os.Exit(2)
+	}
+
+	written, err := util.ExportData(data.Files, command.Arg(0), *force)
+	for _, file := range written {
+		fmt.Println(file)
+	}
+	if err != nil {
+		log.Fatalf("ERROR: Could not export game data: %s", err)
+	}
+}
+
+// writeSchemas writes a JSON Schema document for every kind of game data file
+// to a directory. The game data is loaded first, so that dimensions it
+// declares are described too.
+func writeSchemas(args []string) {
+	command := flag.NewFlagSet("schema", flag.ExitOnError)
+	dataDirectory := command.String("data", util.EmbeddedDir, "The game data declaring the dimensions to describe.")
+	command.Parse(args)
+
+	if command.NArg() != 1 {
+		fmt.Fprintf(os.Stderr, "Usage: %s schema [-data dir] <dir>\n", os.Args[0])
+		os.Exit(2)
+	}
+
+	data, err := content.Load(*dataDirectory)
+	if err != nil {
+		log.Fatalf("ERROR: Invalid game data: %s", err)
+	}
+
+	written, err := content.WriteSchemas(command.Arg(0), data.Classifiers)
+	for _, file := range written {
+		fmt.Println(file)
+	}
+	if err != nil {
+		log.Fatalf("ERROR: Could not write schemas: %s", err)
+	}
+}
+
+// simulateLoot rolls a loot table many times, printing how often each item
+// drops and in what quantities, for balancing the tables.
+func simulateLoot(args []string) {
+	command := flag.NewFlagSet("loot", flag.ExitOnError)
+	dataDirectory := command.String("data", util.EmbeddedDir, "The game data to load the loot tables from.")
+	runs := command.Int("runs", 10000, "The number of times to roll the table.")
+	tier := command.Int("tier", 0, "The quest tier to roll for.")
+	professions := command.String("professions", "", "A comma-separated list of party professions to roll for.")
+	seed := command.Int64("seed", 0, "The random seed to roll with. Defaults to the current time.")
+	command.Parse(args)
+
+	if command.NArg() != 1 || *runs < 1 {
+		fmt.Fprintf(os.Stderr, "Usage: %s loot [-data dir] [-runs n] [-tier t] [-professions p,...] [-seed s] <table>\n", os.Args[0])
+		os.Exit(2)
+	}
+
+	set, err := content.Load(*dataDirectory)
+	if err != nil {
+		log.Fatalf("ERROR: Invalid game data: %s", err)
+	}
+
+	id := command.Arg(0)
+	if _, found := set.Loot.Resolve(id); !found {
+		log.Fatalf("ERROR: Unknown loot table: %s", id)
+	}
+
+	ctx := loot.Context{Tier: *tier}
+	if *professions != "" {
+		ctx.Professions = strings.Split(*professions, ",")
+	}
+	if *seed == 0 {
+		*seed = time.Now().UnixNano()
+	}
+
+	rates := set.Loot.Simulate(id, ctx, *runs, rand.New(rand.NewSource(*seed)))
+
+	fmt.Printf("%s: %d runs, tier %d, seed %d\n", id, *runs, ctx.Tier, *seed)
+	out := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(out, "ITEM\tCHANCE\tMEAN\tMAX")
+	for _, r := range rates {
+		fmt.Fprintf(out, "%s\t%.2f%%\t%.3f\t%d\n", r.Item, r.Chance*100, r.Mean, r.Max)
+	}
+	out.Flush()
+}
@@ -0,0 +1,39 @@
+//------------------------------------------------------------------------------
+//    Copyright 2019 Jeff Sharpe (zeropointx.io)
+//
+//    Licensed under the Apache License, Version 2.0 (the "License");
+//    you may not use this file except in compliance with the License.
+//    You may obtain a copy of the License at
+//
+//        http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS,
+//    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//    See the License for the specific language governing permissions and
+//    limitations under the License.
+//------------------------------------------------------------------------------
+
+package api
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/zpxio/heromanager/internal/game"
+	"net/http"
+)
+
+// ReloadData reloads the game data directory and reports what changed.
+func (server *Server) ReloadData(c *gin.Context) {
+	diff, err := server.world.Reload()
+	if err != nil {
+		if orphans, ok := err.(*game.OrphanError); ok {
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error(), "orphans": orphans.Orphans})
+			return
+		}
+
+		c.JSON(http.StatusUnprocessableEntity, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"diff": diff})
+}
@@ -41,6 +41,40 @@ func CreateServer(world *game.World) *Server {
 	// Standard system API
 	server.router.GET("/sys/ping", Ping)
 
+	// Heroes
+	server.router.GET("/heroes/:id/breakdown", server.HeroBreakdown)
+	server.router.POST("/heroes/:id/experience", server.HeroExperience)
+	server.router.POST("/heroes/:id/items/:action", server.HeroItems)
+
+	// Items
+	server.router.GET("/items", server.ListItems)
+	server.router.GET("/stash", server.GetStash)
+
+	// Parties
+	server.router.GET("/parties", server.ListParties)
+	server.router.POST("/parties", server.CreateParty)
+	server.router.GET("/parties/:id", server.GetParty)
+	server.router.DELETE("/parties/:id", server.DisbandParty)
+
+	// Recruitment
+	server.router.GET("/recruits", server.ListCandidates)
+	server.router.POST("/recruits/:id/hire", server.HireCandidate)
+
+	// Economy
+	server.router.GET("/treasury", server.GetTreasury)
+	server.router.GET("/ledger", server.GetLedger)
+
+	// Quests
+	server.router.GET("/quests", server.ListQuests)
+	server.router.POST("/quests/:id/assign", server.AssignQuest)
+	server.router.GET("/expeditions", server.ListExpeditions)
+
+	// Game data
+	server.router.GET("/data/manifest", server.DataManifest)
+
+	// Administration
+	server.router.POST("/admin/reload", server.ReloadData)
+
 	return &server
 }
 
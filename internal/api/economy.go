@@ -0,0 +1,68 @@
+//------------------------------------------------------------------------------
+//    Copyright 2019 Jeff Sharpe (zeropointx.io)
+//
+//    Licensed under the Apache License, Version 2.0 (the "License");
+//    you may not use this file except in compliance with the License.
+//    You may obtain a copy of the License at
+//
+//        http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS,
+//    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//    See the License for the specific language governing permissions and
+//    limitations under the License.
+//------------------------------------------------------------------------------
+
+package api
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/zpxio/heromanager/internal/game/state"
+	"net/http"
+	"strconv"
+)
+
+// GetTreasury reports the guild's money along with what each payday costs.
+func (server *Server) GetTreasury(c *gin.Context) {
+	config := server.world.Economy()
+	wages := server.world.Wages()
+
+	payroll := config.Upkeep
+	for _, wage := range wages {
+		payroll += wage
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"currency":   config.Currency,
+		"balance":    server.world.Treasury(),
+		"upkeep":     config.Upkeep,
+		"wages":      wages,
+		"payroll":    payroll,
+		"nextPayday": config.NextPayday(server.world.Tick()),
+	})
+}
+
+// GetLedger lists treasury transactions, filtered by the kind, hero, since
+// and limit query parameters.
+func (server *Server) GetLedger(c *gin.Context) {
+	filter := state.LedgerFilter{Kind: c.Query("kind"), Hero: c.Query("hero")}
+
+	var err error
+	if since := c.Query("since"); since != "" {
+		filter.Since, err = strconv.ParseUint(since, 10, 64)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid since: " + since})
+			return
+		}
+	}
+	if limit := c.Query("limit"); limit != "" {
+		filter.Limit, err = strconv.Atoi(limit)
+		if err != nil || filter.Limit < 0 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid limit: " + limit})
+			return
+		}
+	}
+
+	c.JSON(http.StatusOK, gin.H{"transactions": server.world.Ledger(filter)})
+}
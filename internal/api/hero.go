@@ -0,0 +1,72 @@
+//------------------------------------------------------------------------------
+//    Copyright 2019 Jeff Sharpe (zeropointx.io)
+//
+//    Licensed under the Apache License, Version 2.0 (the "License");
+//    you may not use this file except in compliance with the License.
+//    You may obtain a copy of the License at
+//
+//        http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS,
+//    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//    See the License for the specific language governing permissions and
+//    limitations under the License.
+//------------------------------------------------------------------------------
+
+package api
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/zpxio/heromanager/internal/game/data/derived"
+	"net/http"
+)
+
+func (server *Server) HeroBreakdown(c *gin.Context) {
+	id := c.Param("id")
+
+	h, found := server.world.CopyHero(id)
+	if !found {
+		c.JSON(http.StatusNotFound, gin.H{"error": "hero not found", "id": id})
+		return
+	}
+
+	values, breakdown := h.Breakdown(server.world.Manifest(), h.EquipmentModifiers(server.world.Items())...)
+
+	stats := derived.NewStats(server.world.DerivedStats(), &values)
+
+	c.JSON(http.StatusOK, gin.H{"id": h.Id, "attributes": values, "breakdown": breakdown, "derived": stats.All(), "skills": h.Skills,
+		"equipment": h.Equipment, "inventory": h.Inventory})
+}
+
+type experienceRequest struct {
+	Amount int `json:"amount"`
+}
+
+func (server *Server) HeroExperience(c *gin.Context) {
+	id := c.Param("id")
+
+	if _, found := server.world.CopyHero(id); !found {
+		c.JSON(http.StatusNotFound, gin.H{"error": "hero not found", "id": id})
+		return
+	}
+
+	request := experienceRequest{}
+	err := c.BindJSON(&request)
+	if err != nil {
+		return
+	}
+
+	advancement, err := server.world.AwardExperience(id, request.Amount)
+	if err != nil {
+		c.JSON(http.StatusUnprocessableEntity, gin.H{"error": err.Error(), "id": id})
+		return
+	}
+
+	h, found := server.world.CopyHero(id)
+	if !found {
+		c.JSON(http.StatusNotFound, gin.H{"error": "hero not found", "id": id})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"id": h.Id, "level": h.Level, "xp": h.Experience, "advancement": advancement})
+}
@@ -0,0 +1,93 @@
+//------------------------------------------------------------------------------
+//    Copyright 2019 Jeff Sharpe (zeropointx.io)
+//
+//    Licensed under the Apache License, Version 2.0 (the "License");
+//    you may not use this file except in compliance with the License.
+//    You may obtain a copy of the License at
+//
+//        http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS,
+//    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//    See the License for the specific language governing permissions and
+//    limitations under the License.
+//------------------------------------------------------------------------------
+
+package api
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/zpxio/heromanager/internal/game/data/item"
+	"net/http"
+)
+
+type itemListing struct {
+	item.Item
+	Id string `json:"id"`
+}
+
+// ListItems describes every item heroes can carry, along with the equipment
+// slots.
+func (server *Server) ListItems(c *gin.Context) {
+	items := server.world.Items()
+
+	listing := make([]itemListing, 0, len(items.All()))
+	for _, id := range items.All() {
+		i, _ := items.Resolve(id)
+		listing = append(listing, itemListing{Item: *i, Id: id})
+	}
+
+	c.JSON(http.StatusOK, gin.H{"items": listing, "slots": item.Slots()})
+}
+
+func (server *Server) GetStash(c *gin.Context) {
+	c.JSON(http.StatusOK, gin.H{"stash": server.world.Stash()})
+}
+
+type itemRequest struct {
+	Item string `json:"item"`
+	Slot string `json:"slot"`
+}
+
+// HeroItems moves items between a hero and the stash, or in and out of the
+// hero's equipment, as named by the action.
+func (server *Server) HeroItems(c *gin.Context) {
+	id := c.Param("id")
+
+	if _, found := server.world.CopyHero(id); !found {
+		c.JSON(http.StatusNotFound, gin.H{"error": "hero not found", "id": id})
+		return
+	}
+
+	request := itemRequest{}
+	err := c.BindJSON(&request)
+	if err != nil {
+		return
+	}
+
+	switch c.Param("action") {
+	case "give":
+		err = server.world.GiveItem(id, request.Item)
+	case "store":
+		err = server.world.StoreItem(id, request.Item)
+	case "equip":
+		_, err = server.world.EquipItem(id, request.Item)
+	case "unequip":
+		_, err = server.world.UnequipItem(id, request.Slot)
+	default:
+		c.JSON(http.StatusNotFound, gin.H{"error": "unknown action", "action": c.Param("action")})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusUnprocessableEntity, gin.H{"error": err.Error(), "id": id})
+		return
+	}
+
+	h, found := server.world.CopyHero(id)
+	if !found {
+		c.JSON(http.StatusNotFound, gin.H{"error": "hero not found", "id": id})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"id": h.Id, "inventory": h.Inventory, "equipment": h.Equipment})
+}
@@ -0,0 +1,82 @@
+//------------------------------------------------------------------------------
+//    Copyright 2019 Jeff Sharpe (zeropointx.io)
+//
+//    Licensed under the Apache License, Version 2.0 (the "License");
+//    you may not use this file except in compliance with the License.
+//    You may obtain a copy of the License at
+//
+//        http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS,
+//    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//    See the License for the specific language governing permissions and
+//    limitations under the License.
+//------------------------------------------------------------------------------
+
+package api
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/zpxio/heromanager/internal/game"
+	"github.com/zpxio/heromanager/internal/game/state"
+	"net/http"
+)
+
+// ListParties describes every party and the formation roles.
+func (server *Server) ListParties(c *gin.Context) {
+	c.JSON(http.StatusOK, gin.H{"parties": server.world.Parties(), "roles": state.Roles()})
+}
+
+func (server *Server) GetParty(c *gin.Context) {
+	id := c.Param("id")
+
+	p, found := server.world.FindParty(id)
+	if !found {
+		c.JSON(http.StatusNotFound, gin.H{"error": "party not found", "id": id})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"party": p})
+}
+
+type partyRequest struct {
+	Name    string         `json:"name"`
+	Leader  string         `json:"leader"`
+	Members []state.Member `json:"members"`
+}
+
+// CreateParty forms a party, rejecting it when it breaks the composition
+// rules.
+func (server *Server) CreateParty(c *gin.Context) {
+	request := partyRequest{}
+	err := c.BindJSON(&request)
+	if err != nil {
+		return
+	}
+
+	p, err := server.world.CreateParty(request.Name, request.Leader, request.Members)
+	if err != nil {
+		if rejected, ok := err.(*game.CompositionError); ok {
+			c.JSON(http.StatusUnprocessableEntity, gin.H{"error": err.Error(), "reasons": rejected.Reasons})
+			return
+		}
+
+		c.JSON(http.StatusUnprocessableEntity, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{"party": p})
+}
+
+func (server *Server) DisbandParty(c *gin.Context) {
+	id := c.Param("id")
+
+	err := server.world.DisbandParty(id)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "party not found", "id": id})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"id": id})
+}
@@ -0,0 +1,88 @@
+//------------------------------------------------------------------------------
+//    Copyright 2019 Jeff Sharpe (zeropointx.io)
+//
+//    Licensed under the Apache License, Version 2.0 (the "License");
+//    you may not use this file except in compliance with the License.
+//    You may obtain a copy of the License at
+//
+//        http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS,
+//    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//    See the License for the specific language governing permissions and
+//    limitations under the License.
+//------------------------------------------------------------------------------
+
+package api
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/zpxio/heromanager/internal/game"
+	"github.com/zpxio/heromanager/internal/game/data/quest"
+	"github.com/zpxio/heromanager/internal/game/state"
+	"net/http"
+)
+
+type questListing struct {
+	quest.Quest
+	Id string `json:"id"`
+}
+
+// ListQuests describes every quest heroes can be sent on.
+func (server *Server) ListQuests(c *gin.Context) {
+	quests := server.world.Quests()
+
+	listing := make([]questListing, 0, len(quests.All()))
+	for _, id := range quests.All() {
+		q, _ := quests.Resolve(id)
+		listing = append(listing, questListing{Quest: *q, Id: id})
+	}
+
+	c.JSON(http.StatusOK, gin.H{"quests": listing})
+}
+
+// assignRequest names either the heroes to send, or a party to send whole.
+type assignRequest struct {
+	Heroes []string `json:"heroes"`
+	Party  string   `json:"party"`
+}
+
+// AssignQuest sends a party of heroes on a quest.
+func (server *Server) AssignQuest(c *gin.Context) {
+	id := c.Param("id")
+
+	if _, found := server.world.Quests().Resolve(id); !found {
+		c.JSON(http.StatusNotFound, gin.H{"error": "quest not found", "id": id})
+		return
+	}
+
+	request := assignRequest{}
+	err := c.BindJSON(&request)
+	if err != nil {
+		return
+	}
+
+	var expedition *state.Expedition
+	if request.Party != "" {
+		expedition, err = server.world.AssignParty(id, request.Party)
+	} else {
+		expedition, err = server.world.AssignQuest(id, request.Heroes)
+	}
+	if err != nil {
+		if rejected, ok := err.(*game.AssignmentError); ok {
+			c.JSON(http.StatusUnprocessableEntity, gin.H{"error": err.Error(), "reasons": rejected.Reasons})
+			return
+		}
+
+		c.JSON(http.StatusUnprocessableEntity, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{"expedition": expedition})
+}
+
+// ListExpeditions describes the quests in progress and those resolved.
+func (server *Server) ListExpeditions(c *gin.Context) {
+	c.JSON(http.StatusOK, gin.H{"expeditions": server.world.Expeditions(), "results": server.world.Results()})
+}
@@ -0,0 +1,44 @@
+//------------------------------------------------------------------------------
+//    Copyright 2019 Jeff Sharpe (zeropointx.io)
+//
+//    Licensed under the Apache License, Version 2.0 (the "License");
+//    you may not use this file except in compliance with the License.
+//    You may obtain a copy of the License at
+//
+//        http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS,
+//    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//    See the License for the specific language governing permissions and
+//    limitations under the License.
+//------------------------------------------------------------------------------
+
+package api
+
+import (
+	"github.com/gin-gonic/gin"
+	"net/http"
+)
+
+// ListCandidates describes the heroes available for hire, and when the pool
+// next refreshes.
+func (server *Server) ListCandidates(c *gin.Context) {
+	c.JSON(http.StatusOK, gin.H{
+		"candidates":  server.world.Candidates(),
+		"nextRefresh": server.world.NextRefresh(),
+	})
+}
+
+// HireCandidate hires a candidate, paying its fee out of the treasury.
+func (server *Server) HireCandidate(c *gin.Context) {
+	id := c.Param("id")
+
+	h, err := server.world.Hire(id)
+	if err != nil {
+		c.JSON(http.StatusUnprocessableEntity, gin.H{"error": err.Error(), "id": id})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"hero": h, "treasury": server.world.Treasury()})
+}
@@ -0,0 +1,232 @@
+//------------------------------------------------------------------------------
+//    Copyright 2019 Jeff Sharpe (zeropointx.io)
+//
+//    Licensed under the Apache License, Version 2.0 (the "License");
+//    you may not use this file except in compliance with the License.
+//    You may obtain a copy of the License at
+//
+//        http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS,
+//    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//    See the License for the specific language governing permissions and
+//    limitations under the License.
+//------------------------------------------------------------------------------
+
+package combat
+
+import (
+	"github.com/zpxio/heromanager/internal/game/data/damage"
+	"github.com/zpxio/heromanager/internal/game/data/table"
+	"math/rand"
+	"sort"
+)
+
+const (
+	// MaxRounds ends a fight as a draw when neither side has fallen.
+	MaxRounds = 50
+
+	// HitChance is the chance for an attack to land.
+	HitChance = 0.8
+
+	// DamageSpread varies the damage of each hit by up to this share either
+	// way.
+	DamageSpread = 0.25
+)
+
+const (
+	SideHeroes  = "heroes"
+	SideEnemies = "enemies"
+)
+
+// Combatant is one fighter in a combat. Lower ranks stand closer to the
+// front, and are attacked before anyone behind them.
+type Combatant struct {
+	Id          string          `json:"id"`
+	Name        string          `json:"name"`
+	Side        string          `json:"side"`
+	Rank        int             `json:"rank"`
+	Health      float64         `json:"health"`
+	MaxHealth   float64         `json:"maxHealth"`
+	Initiative  float64         `json:"initiative"`
+	Attacks     []damage.Attack `json:"attacks"`
+	Resistances table.Values    `json:"resistances"`
+}
+
+func (c *Combatant) Alive() bool {
+	return c.Health > 0
+}
+
+// Event records a single attack.
+type Event struct {
+	Round    int     `json:"round"`
+	Actor    string  `json:"actor"`
+	Target   string  `json:"target"`
+	Attack   string  `json:"attack"`
+	Type     string  `json:"type"`
+	Hit      bool    `json:"hit"`
+	Rolled   float64 `json:"rolled,omitempty"`
+	Dealt    float64 `json:"dealt,omitempty"`
+	Defeated bool    `json:"defeated,omitempty"`
+}
+
+// Result summarizes a finished combat. The winner is empty on a draw.
+type Result struct {
+	Winner string  `json:"winner"`
+	Rounds int     `json:"rounds"`
+	Log    []Event `json:"log"`
+
+	// Damage is the total damage dealt to each combatant, keyed by ID.
+	Damage map[string]float64 `json:"damage"`
+}
+
+// Combat resolves a fight one round at a time. All randomness comes from
+// the given source, so a seeded source always gives the same fight.
+type Combat struct {
+	combatants []*Combatant
+	rng        *rand.Rand
+	round      int
+	log        []Event
+	damage     map[string]float64
+}
+
+func New(rng *rand.Rand, combatants ...*Combatant) *Combat {
+	return &Combat{
+		combatants: combatants,
+		rng:        rng,
+		log:        make([]Event, 0),
+		damage:     make(map[string]float64, len(combatants)),
+	}
+}
+
+// sides lists the sides with a combatant still standing.
+func (c *Combat) sides() []string {
+	standing := make([]string, 0, 2)
+	for _, f := range c.combatants {
+		if !f.Alive() {
+			continue
+		}
+		known := false
+		for _, side := range standing {
+			known = known || side == f.Side
+		}
+		if !known {
+			standing = append(standing, f.Side)
+		}
+	}
+
+	return standing
+}
+
+// Over reports whether at most one side is left standing, or the round
+// limit is reached.
+func (c *Combat) Over() bool {
+	return len(c.sides()) < 2 || c.round >= MaxRounds
+}
+
+// order lists the living combatants by initiative, highest first, and then
+// by ID.
+func (c *Combat) order() []*Combatant {
+	order := make([]*Combatant, 0, len(c.combatants))
+	for _, f := range c.combatants {
+		if f.Alive() {
+			order = append(order, f)
+		}
+	}
+	sort.SliceStable(order, func(i, j int) bool {
+		if order[i].Initiative != order[j].Initiative {
+			return order[i].Initiative > order[j].Initiative
+		}
+		return order[i].Id < order[j].Id
+	})
+
+	return order
+}
+
+// targets lists the living opponents of a combatant in the foremost rank
+// still standing.
+func (c *Combat) targets(actor *Combatant) []*Combatant {
+	targets := make([]*Combatant, 0)
+	for _, f := range c.order() {
+		if f.Side == actor.Side {
+			continue
+		}
+		if len(targets) > 0 && f.Rank > targets[0].Rank {
+			continue
+		}
+		if len(targets) > 0 && f.Rank < targets[0].Rank {
+			targets = targets[:0]
+		}
+		targets = append(targets, f)
+	}
+
+	return targets
+}
+
+// Step fights one round, in which every living combatant attacks once in
+// order of initiative. It returns true once the combat is over.
+func (c *Combat) Step() bool {
+	if c.Over() {
+		return true
+	}
+
+	c.round++
+	for _, actor := range c.order() {
+		if !actor.Alive() || len(actor.Attacks) == 0 {
+			continue
+		}
+		targets := c.targets(actor)
+		if len(targets) == 0 {
+			break
+		}
+
+		target := targets[c.rng.Intn(len(targets))]
+		attack := actor.Attacks[c.rng.Intn(len(actor.Attacks))]
+		c.log = append(c.log, c.strike(actor, target, attack))
+	}
+
+	return c.Over()
+}
+
+func (c *Combat) strike(actor *Combatant, target *Combatant, attack damage.Attack) Event {
+	e := Event{Round: c.round, Actor: actor.Id, Target: target.Id, Attack: attack.Name, Type: attack.Type}
+
+	e.Hit = c.rng.Float64() < HitChance
+	if !e.Hit {
+		return e
+	}
+
+	e.Rolled = attack.Damage * (1.0 - DamageSpread + c.rng.Float64()*2*DamageSpread)
+	e.Dealt = damage.Apply(e.Rolled, attack.Type, target.Resistances)
+	if e.Dealt > target.Health {
+		e.Dealt = target.Health
+	}
+
+	target.Health -= e.Dealt
+	c.damage[target.Id] += e.Dealt
+	e.Defeated = !target.Alive()
+
+	return e
+}
+
+// Run fights rounds until the combat is over.
+func (c *Combat) Run() Result {
+	for !c.Step() {
+	}
+
+	return c.Result()
+}
+
+func (c *Combat) Result() Result {
+	r := Result{Rounds: c.round, Log: append([]Event{}, c.log...), Damage: make(map[string]float64, len(c.damage))}
+
+	if sides := c.sides(); len(sides) == 1 {
+		r.Winner = sides[0]
+	}
+	for id, dealt := range c.damage {
+		r.Damage[id] = dealt
+	}
+
+	return r
+}
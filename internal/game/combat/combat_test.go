@@ -0,0 +1,127 @@
+//------------------------------------------------------------------------------
+//    Copyright 2019 Jeff Sharpe (zeropointx.io)
+//
+//    Licensed under the Apache License, Version 2.0 (the "License");
+//    you may not use this file except in compliance with the License.
+//    You may obtain a copy of the License at
+//
+//        http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS,
+//    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//    See the License for the specific language governing permissions and
+//    limitations under the License.
+//------------------------------------------------------------------------------
+
+package combat
+
+import (
+	"github.com/stretchr/testify/suite"
+	"github.com/zpxio/heromanager/internal/game/data/damage"
+	"math/rand"
+	"testing"
+)
+
+type CombatTestSuite struct {
+	suite.Suite
+}
+
+func TestCombatSuite(t *testing.T) {
+	suite.Run(t, new(CombatTestSuite))
+}
+
+func fighter(id string, side string, health float64, initiative float64, hit float64) *Combatant {
+	return &Combatant{
+		Id:          id,
+		Name:        id,
+		Side:        side,
+		Health:      health,
+		MaxHealth:   health,
+		Initiative:  initiative,
+		Attacks:     []damage.Attack{{Name: "Claw", Type: damage.Physical, Damage: hit}},
+		Resistances: damage.NewResistances(),
+	}
+}
+
+func (s *CombatTestSuite) TestRun_Deterministic() {
+	fight := func() Result {
+		return New(rand.New(rand.NewSource(7)),
+			fighter("H1", SideHeroes, 30, 5, 6),
+			fighter("H2", SideHeroes, 20, 9, 4),
+			fighter("R1", SideEnemies, 25, 7, 5),
+			fighter("R2", SideEnemies, 25, 3, 5),
+		).Run()
+	}
+
+	first := fight()
+	s.Equal(first, fight())
+	s.NotEmpty(first.Log)
+	s.NotEmpty(first.Winner)
+}
+
+func (s *CombatTestSuite) TestRun_Winner() {
+	hero := fighter("H1", SideHeroes, 100, 1, 20)
+	rat := fighter("R1", SideEnemies, 10, 5, 1)
+
+	r := New(rand.New(rand.NewSource(1)), hero, rat).Run()
+
+	s.Equal(SideHeroes, r.Winner)
+	s.False(rat.Alive())
+	s.Equal(10.0, r.Damage["R1"])
+	s.InDelta(100.0-hero.Health, r.Damage["H1"], 0.0001)
+
+	last := r.Log[len(r.Log)-1]
+	s.Equal("H1", last.Actor)
+	s.True(last.Defeated)
+	s.Equal(r.Rounds, last.Round)
+}
+
+func (s *CombatTestSuite) TestStep_Order() {
+	c := New(rand.New(rand.NewSource(1)),
+		fighter("B", SideHeroes, 100, 5, 1),
+		fighter("A", SideHeroes, 100, 5, 1),
+		fighter("Z", SideEnemies, 100, 9, 1),
+	)
+
+	s.False(c.Step())
+	r := c.Result()
+	s.Require().Len(r.Log, 3)
+	s.Equal("Z", r.Log[0].Actor)
+	s.Equal("A", r.Log[1].Actor)
+	s.Equal("B", r.Log[2].Actor)
+	s.Equal(1, r.Rounds)
+}
+
+func (s *CombatTestSuite) TestStep_FrontRankFirst() {
+	front := fighter("F", SideEnemies, 1000, 1, 0)
+	rear := fighter("R", SideEnemies, 1000, 1, 0)
+	rear.Rank = 2
+
+	c := New(rand.New(rand.NewSource(1)), fighter("H1", SideHeroes, 10, 5, 5), front, rear)
+	for i := 0; i < 5; i++ {
+		c.Step()
+	}
+
+	for _, e := range c.Result().Log {
+		if e.Actor == "H1" {
+			s.Equal("F", e.Target)
+		}
+	}
+}
+
+func (s *CombatTestSuite) TestRun_Resistances() {
+	hero := fighter("H1", SideHeroes, 10, 5, 5)
+	hero.Attacks[0].Type = damage.Fire
+	golem := fighter("G1", SideEnemies, 10, 1, 0)
+	golem.Resistances.Set(damage.Fire, damage.MaxResistance)
+
+	r := New(rand.New(rand.NewSource(1)), hero, golem).Run()
+
+	s.Equal("", r.Winner)
+	s.Equal(MaxRounds, r.Rounds)
+	s.Equal(10.0, golem.Health)
+	for _, e := range r.Log {
+		s.Equal(0.0, e.Dealt)
+	}
+}
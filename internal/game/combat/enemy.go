@@ -0,0 +1,44 @@
+//------------------------------------------------------------------------------
+//    Copyright 2019 Jeff Sharpe (zeropointx.io)
+//
+//    Licensed under the Apache License, Version 2.0 (the "License");
+//    you may not use this file except in compliance with the License.
+//    You may obtain a copy of the License at
+//
+//        http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS,
+//    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//    See the License for the specific language governing permissions and
+//    limitations under the License.
+//------------------------------------------------------------------------------
+
+package combat
+
+import (
+	"fmt"
+	"github.com/zpxio/heromanager/internal/game/data/damage"
+	"github.com/zpxio/heromanager/internal/game/data/derived"
+	"github.com/zpxio/heromanager/internal/game/data/enemy"
+	"math"
+)
+
+// FromEnemy builds a combatant on the enemies side for one of the enemies
+// rolled for an encounter. The index tells apart enemies of the same kind.
+func FromEnemy(id string, index int, e *enemy.Enemy, stats *derived.Definitions) *Combatant {
+	health, initiative := vitals(e.Values(), stats)
+	health = math.Max(health, MinHealth)
+
+	return &Combatant{
+		Id:          fmt.Sprintf("%s#%d", id, index),
+		Name:        e.Name,
+		Side:        SideEnemies,
+		Rank:        e.Rank,
+		Health:      health,
+		MaxHealth:   health,
+		Initiative:  initiative,
+		Attacks:     append([]damage.Attack{}, e.Attacks...),
+		Resistances: e.ResistanceValues(),
+	}
+}
@@ -0,0 +1,49 @@
+//------------------------------------------------------------------------------
+//    Copyright 2019 Jeff Sharpe (zeropointx.io)
+//
+//    Licensed under the Apache License, Version 2.0 (the "License");
+//    you may not use this file except in compliance with the License.
+//    You may obtain a copy of the License at
+//
+//        http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS,
+//    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//    See the License for the specific language governing permissions and
+//    limitations under the License.
+//------------------------------------------------------------------------------
+
+package combat
+
+import (
+	"github.com/zpxio/heromanager/internal/game/data/attributes"
+	"github.com/zpxio/heromanager/internal/game/data/damage"
+	"github.com/zpxio/heromanager/internal/game/data/derived"
+	"github.com/zpxio/heromanager/internal/game/data/enemy"
+	"github.com/zpxio/heromanager/internal/game/data/formula"
+)
+
+func (s *CombatTestSuite) TestFromEnemy() {
+	stats := derived.NewDefinitions()
+	stats.Register(HealthStat, derived.Stat{Name: "Health", Formula: *formula.MustParse("Vigor * 5")})
+
+	e := enemy.Enemy{
+		Name:        "Rat",
+		Rank:        2,
+		Attributes:  map[string]float64{attributes.Vigor: 4, attributes.Finesse: 20},
+		Resistances: map[string]float64{damage.Toxin: 0.5},
+		Attacks:     []damage.Attack{{Name: "Bite", Type: damage.Toxin, Damage: 2}},
+	}
+
+	c := FromEnemy("Rat", 3, &e, stats)
+	s.Equal("Rat#3", c.Id)
+	s.Equal(SideEnemies, c.Side)
+	s.Equal(2, c.Rank)
+	s.Equal(20.0, c.Health)
+	s.Equal(20.0, c.Initiative)
+	s.Equal(0.5, c.Resistances.Get(damage.Toxin))
+	s.Equal(e.Attacks, c.Attacks)
+
+	s.Equal(MinHealth, FromEnemy("Wisp", 1, &enemy.Enemy{}, stats).Health)
+}
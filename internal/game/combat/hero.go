@@ -0,0 +1,99 @@
+//------------------------------------------------------------------------------
+//    Copyright 2019 Jeff Sharpe (zeropointx.io)
+//
+//    Licensed under the Apache License, Version 2.0 (the "License");
+//    you may not use this file except in compliance with the License.
+//    You may obtain a copy of the License at
+//
+//        http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS,
+//    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//    See the License for the specific language governing permissions and
+//    limitations under the License.
+//------------------------------------------------------------------------------
+
+package combat
+
+import (
+	"github.com/zpxio/heromanager/internal/game/data/attributes"
+	"github.com/zpxio/heromanager/internal/game/data/classifier"
+	"github.com/zpxio/heromanager/internal/game/data/damage"
+	"github.com/zpxio/heromanager/internal/game/data/derived"
+	"github.com/zpxio/heromanager/internal/game/data/item"
+	"github.com/zpxio/heromanager/internal/game/data/table"
+	"github.com/zpxio/heromanager/internal/game/state/hero"
+)
+
+// Combatants take their health and initiative from these derived stats when
+// they are defined, and otherwise from Vigor and Finesse.
+const (
+	HealthStat     = "Health"
+	InitiativeStat = "Initiative"
+)
+
+const (
+	// MinHealth is the least health a combatant starts with, however
+	// wounded.
+	MinHealth = 1.0
+
+	// UnarmedDamage is the base damage of a hero's unarmed strike, which
+	// rises by BrawnDamageFactor for each point of Brawn.
+	UnarmedDamage     = 2.0
+	BrawnDamageFactor = 0.1
+)
+
+// Unarmed is the physical strike every hero can make.
+func Unarmed(brawn float64) damage.Attack {
+	return damage.Attack{Name: "Strike", Type: damage.Physical, Damage: UnarmedDamage + brawn*BrawnDamageFactor}
+}
+
+// FromHero builds a combatant for a hero on the heroes side, using its
+// effective attributes and resistances with its equipment. Wounds reduce its
+// starting health, and an equipped weapon replaces its unarmed strike.
+func FromHero(h *hero.Hero, rank int, manifest *classifier.ClassifierManifest, items *item.Definitions, stats *derived.Definitions) *Combatant {
+	values := h.EffectiveAttributes(manifest, h.EquipmentModifiers(items)...)
+	health, initiative := vitals(values, stats)
+
+	attack := Unarmed(values.Get(attributes.Brawn))
+	for _, i := range h.Equipped(items) {
+		if i.Attack != nil {
+			attack = *i.Attack
+		}
+	}
+
+	current := health - h.Wounds
+	if current < MinHealth {
+		current = MinHealth
+	}
+
+	return &Combatant{
+		Id:          h.Id,
+		Name:        h.Name,
+		Side:        SideHeroes,
+		Rank:        rank,
+		Health:      current,
+		MaxHealth:   health,
+		Initiative:  initiative,
+		Attacks:     []damage.Attack{attack},
+		Resistances: h.Resistances(manifest, items),
+	}
+}
+
+// vitals returns the health and initiative of a combatant with the given
+// attributes.
+func vitals(values table.Values, stats *derived.Definitions) (float64, float64) {
+	derivedStats := derived.NewStats(stats, &values)
+
+	health := values.Get(attributes.Vigor)
+	if _, found := stats.Resolve(HealthStat); found {
+		health = derivedStats.Get(HealthStat)
+	}
+	initiative := values.Get(attributes.Finesse)
+	if _, found := stats.Resolve(InitiativeStat); found {
+		initiative = derivedStats.Get(InitiativeStat)
+	}
+
+	return health, initiative
+}
@@ -0,0 +1,82 @@
+//------------------------------------------------------------------------------
+//    Copyright 2019 Jeff Sharpe (zeropointx.io)
+//
+//    Licensed under the Apache License, Version 2.0 (the "License");
+//    you may not use this file except in compliance with the License.
+//    You may obtain a copy of the License at
+//
+//        http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS,
+//    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//    See the License for the specific language governing permissions and
+//    limitations under the License.
+//------------------------------------------------------------------------------
+
+package combat
+
+import (
+	"github.com/zpxio/heromanager/internal/game/data/attributes"
+	"github.com/zpxio/heromanager/internal/game/data/classifier"
+	"github.com/zpxio/heromanager/internal/game/data/damage"
+	"github.com/zpxio/heromanager/internal/game/data/derived"
+	"github.com/zpxio/heromanager/internal/game/data/formula"
+	"github.com/zpxio/heromanager/internal/game/data/item"
+	"github.com/zpxio/heromanager/internal/game/state/hero"
+)
+
+func (s *CombatTestSuite) TestFromHero() {
+	m := classifier.NewManifest()
+	r := classifier.BlankRace()
+	r.Resistances[damage.Cold] = 0.5
+	m.RegisterRace("Snowfolk", r)
+
+	stats := derived.NewDefinitions()
+	stats.Register(HealthStat, derived.Stat{Name: "Health", Formula: *formula.MustParse("Vigor * 5")})
+
+	h := hero.Hero{Id: "H1", Race: "Snowfolk", Attributes: attributes.NewAttributeValues(), Wounds: 15}
+	h.Attributes.Set(attributes.Vigor, 10.0)
+	h.Attributes.Set(attributes.Finesse, 12.0)
+	h.Attributes.Set(attributes.Brawn, 30.0)
+
+	c := FromHero(&h, 0, m, item.NewDefinitions(), stats)
+	s.Equal(SideHeroes, c.Side)
+	s.Equal(0, c.Rank)
+	s.Equal(50.0, c.MaxHealth)
+	s.Equal(35.0, c.Health)
+	s.Equal(12.0, c.Initiative)
+	s.Equal(0.5, c.Resistances.Get(damage.Cold))
+	s.Require().Len(c.Attacks, 1)
+	s.InDelta(5.0, c.Attacks[0].Damage, 0.0001)
+
+	h.Wounds = 100
+	s.Equal(MinHealth, FromHero(&h, 1, m, nil, stats).Health)
+}
+
+func (s *CombatTestSuite) TestFromHero_Equipment() {
+	m := classifier.NewManifest()
+	m.RegisterRace("Human", classifier.BlankRace())
+
+	items := item.NewDefinitions()
+	axe := item.Initialize()
+	axe.Name = "Axe"
+	axe.Slot = item.SlotWeapon
+	axe.Attack = &damage.Attack{Name: "Chop", Type: damage.Physical, Damage: 9}
+	items.Register("Axe", axe)
+	cloak := item.Initialize()
+	cloak.Name = "Cloak"
+	cloak.Slot = item.SlotBody
+	cloak.Attributes.Load(map[string]float64{attributes.Vigor: 0.5})
+	cloak.Resistances[damage.Cold] = 0.25
+	items.Register("Cloak", cloak)
+
+	h := hero.Hero{Id: "H1", Race: "Human", Attributes: attributes.NewAttributeValues()}
+	h.Attributes.Set(attributes.Vigor, 10.0)
+	h.Equipment = map[string]string{item.SlotWeapon: "Axe", item.SlotBody: "Cloak"}
+
+	c := FromHero(&h, 0, m, items, derived.NewDefinitions())
+	s.Equal(15.0, c.MaxHealth)
+	s.Equal(0.25, c.Resistances.Get(damage.Cold))
+	s.Equal([]damage.Attack{*axe.Attack}, c.Attacks)
+}
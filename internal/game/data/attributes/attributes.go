@@ -40,6 +40,10 @@ var policy = table.NewPolicy(MinAttributeValue, MaxAttributeValue, DefaultValue,
 	Allure,
 })
 
+func Policy() *table.Policy {
+	return policy
+}
+
 func NewAttributeValues() table.Values {
 	return table.NewValues(policy)
 }
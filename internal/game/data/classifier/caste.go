@@ -16,12 +16,6 @@
 
 package classifier
 
-import (
-	log "github.com/sirupsen/logrus"
-	"github.com/zpxio/heromanager/internal/game/util"
-	"gopkg.in/yaml.v2"
-)
-
 type Caste struct {
 	Classifier
 }
@@ -34,21 +28,6 @@ func BlankCaste() Caste {
 	return r
 }
 
-func LoadCastes(gameDir string, casteFile string, manifest *ClassifierManifest) {
-
-	casteYaml, err := util.GameFileData(gameDir, casteFile)
-	if err != nil {
-		log.Errorf("yamlFile.Get err %v ", err)
-	}
-
-	castes := make(map[string]Caste)
-	err = yaml.Unmarshal(casteYaml, &castes)
-	if err != nil {
-		log.Errorf("failed to parse caste data: %s", err)
-	}
-
-	// Register the castes
-	for id, caste := range castes {
-		manifest.RegisterCaste(id, caste)
-	}
+func LoadCastes(gameDir string, casteFile string, manifest *ClassifierManifest) error {
+	return LoadClassifiers(ConflictCastes, gameDir, casteFile, manifest)
 }
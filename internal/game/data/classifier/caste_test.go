@@ -54,3 +54,30 @@ func (t *CasteTestSuite) TestYamlLoadAll() {
 	t.Contains(manifest.AllCastes(), "Noble")
 	t.Contains(manifest.AllCastes(), "Peasant")
 }
+
+func (t *CasteTestSuite) TestYamlLoadAll_Contents() {
+	manifest := NewManifest()
+	LoadCastes("testdata/game/data/caste", "test_caste_all_simple.yml", manifest)
+
+	c, found := manifest.ResolveCaste("Noble")
+	t.Require().True(found)
+	t.Equal("Noble", c.Name)
+	t.Equal(1.12, c.Attributes.Factor(attributes.Brawn))
+	t.Equal(DefaultWeight, c.Rarity())
+}
+
+func (t *CasteTestSuite) TestYamlLoadAll_Weighted() {
+	manifest := NewManifest()
+	LoadCastes("testdata/game/data/caste", "test_caste_weighted.yml", manifest)
+
+	noble, found := manifest.ResolveCaste("Noble")
+	t.Require().True(found)
+	t.Equal(float32(0.5), noble.Rarity())
+	t.Equal(float32(2.0), noble.WeightGiven(map[string]string{ConflictRaces: "Elf"}))
+	t.Equal(float32(0.5), noble.WeightGiven(map[string]string{ConflictRaces: "Dwarf"}))
+
+	peasant, found := manifest.ResolveCaste("Peasant")
+	t.Require().True(found)
+	t.Equal(DefaultWeight, peasant.Rarity())
+	t.Equal(1.1, peasant.Attributes.Factor(attributes.Brawn))
+}
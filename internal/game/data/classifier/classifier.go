@@ -17,24 +17,263 @@
 package classifier
 
 import (
+	"encoding/json"
 	"github.com/zpxio/heromanager/internal/game/data/attributes"
 	"github.com/zpxio/heromanager/internal/game/data/table"
 )
 
+const DefaultWeight float32 = 1.0
+
 type Classifier struct {
-	Name       string         `yaml:"name"`
-	Attributes table.Modifier `yaml:"attributes"`
+	Id      string  `yaml:"-" json:"-"`
+	Name    string  `yaml:"name" json:"name"`
+	Extends string  `yaml:"extends" json:"extends,omitempty"`
+	Weight  float32 `yaml:"weight" json:"weight"`
+	// Order places the classifier in listings, lowest first. Classifiers
+	// with the same order are listed by ID.
+	Order      int            `yaml:"order" json:"order,omitempty"`
+	Tags       []string       `yaml:"tags" json:"tags"`
+	Attributes table.Modifier `yaml:"attributes" json:"attributes"`
+
+	// Skills are granted to heroes on creation, keyed by skill ID. Grants
+	// from each of a hero's classifiers add up.
+	Skills map[string]float64 `yaml:"skills" json:"skills,omitempty"`
+
+	// Resistances reduce damage taken by heroes, keyed by damage type.
+	// Resistances from each of a hero's classifiers add up.
+	Resistances map[string]float64 `yaml:"resistances" json:"resistances,omitempty"`
+
+	// Wage is paid each payday to heroes of this classifier. Wages from each
+	// of a hero's classifiers add up, and may be negative.
+	Wage int `yaml:"wage" json:"wage,omitempty"`
+
+	// Weights multiply the base weight when the named classifiers have
+	// already been chosen, keyed by conflict target and then by ID.
+	Weights map[string]map[string]float32 `yaml:"weights" json:"weights"`
 
-	Conflicts ConflictGroup `yaml:"conflicts"`
+	Conflicts ConflictGroup `yaml:"conflicts" json:"conflicts"`
+	Requires  Requirements  `yaml:"requires" json:"requires"`
+
+	// Rivals lists the classifiers whose heroes refuse to share a party with
+	// heroes of this one. Rivalries apply in both directions.
+	Rivals ConflictGroup `yaml:"rivals" json:"rivals"`
+
+	// declared records the fields explicitly present in loaded data.
+	declared map[string]bool
 }
 
+// classifierData has the layout of Classifier without its unmarshal methods.
+type classifierData Classifier
+
 func Initialize() Classifier {
 	c := Classifier{
-		Name:       "",
-		Attributes: attributes.NewAttributeModifier(),
+		Name:        "",
+		Weight:      DefaultWeight,
+		Tags:        make([]string, 0),
+		Attributes:  attributes.NewAttributeModifier(),
+		Skills:      make(map[string]float64),
+		Resistances: make(map[string]float64),
+		Weights:     make(map[string]map[string]float32),
 
 		Conflicts: EmptyConflicts(),
+		Requires:  EmptyRequirements(),
+		Rivals:    EmptyConflicts(),
 	}
 
 	return c
 }
+
+func (c Classifier) HasTag(tag string) bool {
+	for _, t := range c.Tags {
+		if t == tag {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Rarity implements util.Weighted using the base weight.
+func (c Classifier) Rarity() float32 {
+	return c.Weight
+}
+
+// WeightGiven returns the weight of the classifier once the given classifiers
+// have been chosen. Each selection is keyed by conflict target.
+func (c Classifier) WeightGiven(selected map[string]string) float32 {
+	weight := c.Weight
+
+	for target, id := range selected {
+		if factor, ok := c.Weights[target][id]; ok {
+			weight *= factor
+		}
+	}
+
+	return weight
+}
+
+// Inherit merges a parent classifier into this one. Fields set by the child,
+// including the wage, override the parent, attribute modifiers, skill grants, resistances and
+// conditional weights are merged key by key, and conflicts, requirements, rivals and tags
+// are combined. The order is not inherited, since it places the child among its
+// siblings.
+func (c *Classifier) Inherit(parent *Classifier) {
+	if c.Name == "" {
+		c.Name = parent.Name
+	}
+	if !c.declared["weight"] && c.Weight == DefaultWeight {
+		c.Weight = parent.Weight
+	}
+	if !c.declared["wage"] && c.Wage == 0 {
+		c.Wage = parent.Wage
+	}
+
+	c.Attributes.Inherit(parent.Attributes)
+
+	if c.Skills == nil {
+		c.Skills = make(map[string]float64)
+	}
+	for id, value := range parent.Skills {
+		if _, ok := c.Skills[id]; !ok {
+			c.Skills[id] = value
+		}
+	}
+
+	if c.Resistances == nil {
+		c.Resistances = make(map[string]float64)
+	}
+	for t, value := range parent.Resistances {
+		if _, ok := c.Resistances[t]; !ok {
+			c.Resistances[t] = value
+		}
+	}
+
+	if c.Weights == nil {
+		c.Weights = make(map[string]map[string]float32)
+	}
+	for target, weights := range parent.Weights {
+		if c.Weights[target] == nil {
+			c.Weights[target] = make(map[string]float32)
+		}
+		for id, w := range weights {
+			if _, ok := c.Weights[target][id]; !ok {
+				c.Weights[target][id] = w
+			}
+		}
+	}
+
+	tags := append([]string{}, parent.Tags...)
+	for _, tag := range c.Tags {
+		if !parent.HasTag(tag) {
+			tags = append(tags, tag)
+		}
+	}
+	c.Tags = tags
+
+	c.Conflicts.Merge(parent.Conflicts)
+	c.Requires.Merge(parent.Requires)
+	c.Rivals.Merge(parent.Rivals)
+}
+
+// RivalOf checks whether either classifier names the other as a rival, by
+// ID or by tag.
+func (c *Classifier) RivalOf(target string, other *Classifier, otherTarget string) bool {
+	return !c.Rivals.AllowClassifier(otherTarget, other) || !other.Rivals.AllowClassifier(target, c)
+}
+
+// Patch applies a partial classifier over this one. Fields declared by the
+// patch replace the current values, attribute modifiers, skill grants,
+// resistances and conditional weights are overridden key by key, and conflicts, requirements,
+// rivals and tags are added to.
+func (c *Classifier) Patch(patch Classifier) {
+	patched := patch
+	patched.Id = c.Id
+	if !patch.declared["name"] {
+		patched.Name = ""
+	}
+	if !patch.declared["extends"] {
+		patched.Extends = c.Extends
+	}
+	if !patch.declared["order"] {
+		patched.Order = c.Order
+	}
+	patched.Inherit(c)
+
+	patched.declared = make(map[string]bool, len(c.declared)+len(patch.declared))
+	for k := range c.declared {
+		patched.declared[k] = true
+	}
+	for k := range patch.declared {
+		patched.declared[k] = true
+	}
+
+	*c = patched
+}
+
+// restoreDefaults replaces fields loaded as null with their initial values,
+// so that inheritance and patching can merge into them.
+func (c *Classifier) restoreDefaults() {
+	if c.Attributes.Policy() == nil {
+		c.Attributes = attributes.NewAttributeModifier()
+	}
+	if c.Tags == nil {
+		c.Tags = make([]string, 0)
+	}
+	if c.Skills == nil {
+		c.Skills = make(map[string]float64)
+	}
+	if c.Resistances == nil {
+		c.Resistances = make(map[string]float64)
+	}
+	if c.Weights == nil {
+		c.Weights = make(map[string]map[string]float32)
+	}
+}
+
+func (c *Classifier) UnmarshalJSON(data []byte) error {
+	loaded := classifierData(Initialize())
+	err := json.Unmarshal(data, &loaded)
+	if err != nil {
+		return err
+	}
+
+	fields := map[string]json.RawMessage{}
+	err = json.Unmarshal(data, &fields)
+	if err != nil {
+		return err
+	}
+
+	loaded.declared = make(map[string]bool, len(fields))
+	for k := range fields {
+		loaded.declared[k] = true
+	}
+
+	*c = Classifier(loaded)
+	c.restoreDefaults()
+
+	return nil
+}
+
+func (c *Classifier) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	loaded := classifierData(Initialize())
+	err := unmarshal(&loaded)
+	if err != nil {
+		return err
+	}
+
+	fields := map[string]interface{}{}
+	err = unmarshal(&fields)
+	if err != nil {
+		return err
+	}
+
+	loaded.declared = make(map[string]bool, len(fields))
+	for k := range fields {
+		loaded.declared[k] = true
+	}
+
+	*c = Classifier(loaded)
+	c.restoreDefaults()
+
+	return nil
+}
@@ -17,6 +17,7 @@
 package classifier
 
 import (
+	"encoding/json"
 	"github.com/stretchr/testify/suite"
 	"github.com/zpxio/heromanager/internal/game/data/attributes"
 	"github.com/zpxio/heromanager/internal/game/util"
@@ -36,6 +37,31 @@ func (s *ClassifierTestSuite) TestInitialize() {
 	c := Initialize()
 
 	s.Equal("", c.Name)
+	s.Equal(DefaultWeight, c.Rarity())
+}
+
+func (s *ClassifierTestSuite) TestWeightGiven() {
+	c := Initialize()
+	c.Weight = 2.0
+	c.Weights[ConflictRaces] = map[string]float32{"Dwarf": 3.0}
+	c.Weights[ConflictCastes] = map[string]float32{"Noble": 0.5}
+
+	s.Equal(float32(2.0), c.WeightGiven(map[string]string{}))
+	s.Equal(float32(6.0), c.WeightGiven(map[string]string{ConflictRaces: "Dwarf"}))
+	s.Equal(float32(3.0), c.WeightGiven(map[string]string{ConflictRaces: "Dwarf", ConflictCastes: "Noble"}))
+	s.Equal(float32(2.0), c.WeightGiven(map[string]string{ConflictRaces: "Elf"}))
+}
+
+func (s *ClassifierTestSuite) TestUnmarshallJSON() {
+	c := Initialize()
+
+	err := json.Unmarshal([]byte(`{"name": "Tester", "weight": 0.25, "attributes": {"Brawn": 0.5}, "conflicts": {"races": ["Elf"]}}`), &c)
+	s.Require().Nil(err)
+
+	s.Equal("Tester", c.Name)
+	s.Equal(float32(0.25), c.Rarity())
+	s.Equal(1.5, c.Attributes.Factor(attributes.Brawn))
+	s.False(c.Conflicts.AllowRace("Elf"))
 }
 
 func (s *ClassifierTestSuite) TestUnmarshallYAML() {
@@ -58,3 +84,65 @@ func (s *ClassifierTestSuite) TestUnmarshallYAML() {
 	s.Equal(1.2, c.Attributes.Factor(attributes.Allure))
 	s.Equal(0.88, c.Attributes.Factor(attributes.Brawn))
 }
+
+func (s *ClassifierTestSuite) TestPatch_Order() {
+	c := Initialize()
+	s.Require().Nil(yaml.Unmarshal([]byte("name: Base\norder: 3\n"), &c))
+
+	patch := Initialize()
+	s.Require().Nil(yaml.Unmarshal([]byte("weight: 0.5\n"), &patch))
+	c.Patch(patch)
+	s.Equal(3, c.Order)
+
+	patch = Initialize()
+	s.Require().Nil(yaml.Unmarshal([]byte("order: 1\n"), &patch))
+	c.Patch(patch)
+	s.Equal(1, c.Order)
+	s.Equal(float32(0.5), c.Weight)
+}
+
+func (s *ClassifierTestSuite) TestInherit_Skills() {
+	parent := Initialize()
+	s.Require().Nil(yaml.Unmarshal([]byte("skills:\n  Smithing: 10\n  Lore: 5\n"), &parent))
+
+	child := Initialize()
+	s.Require().Nil(yaml.Unmarshal([]byte("skills:\n  Smithing: 20\n"), &child))
+	child.Inherit(&parent)
+
+	s.Equal(map[string]float64{"Smithing": 20.0, "Lore": 5.0}, child.Skills)
+}
+
+func (s *ClassifierTestSuite) TestInherit_Wage() {
+	parent := Initialize()
+	s.Require().Nil(yaml.Unmarshal([]byte("wage: 12\n"), &parent))
+
+	child := Initialize()
+	child.Inherit(&parent)
+	s.Equal(12, child.Wage)
+
+	unpaid := Initialize()
+	s.Require().Nil(yaml.Unmarshal([]byte("wage: 0\n"), &unpaid))
+	unpaid.Inherit(&parent)
+	s.Equal(0, unpaid.Wage)
+}
+
+func (s *ClassifierTestSuite) TestRivalOf() {
+	noble := Initialize()
+	noble.Id = "Noble"
+	s.Require().Nil(yaml.Unmarshal([]byte("rivals:\n  castes: [Serf]\n"), &noble))
+
+	serf := Initialize()
+	serf.Id = "Serf"
+	elder := Initialize()
+	elder.Id = "Elder"
+	elder.Tags = []string{"wise"}
+	dwarf := Initialize()
+	dwarf.Id = "Dwarf"
+	dwarf.Rivals.Add(ConflictTags, "wise")
+
+	s.True(noble.RivalOf(ConflictCastes, &serf, ConflictCastes))
+	s.True(serf.RivalOf(ConflictCastes, &noble, ConflictCastes))
+	s.False(noble.RivalOf(ConflictCastes, &elder, ConflictCastes))
+	s.True(elder.RivalOf(ConflictCastes, &dwarf, ConflictRaces))
+	s.False(serf.RivalOf(ConflictCastes, &dwarf, ConflictRaces))
+}
@@ -18,7 +18,8 @@ package classifier
 
 import (
 	"encoding/json"
-	log "github.com/sirupsen/logrus"
+	"github.com/zpxio/heromanager/internal/game/data/schema"
+	"sort"
 	"strings"
 )
 
@@ -26,68 +27,167 @@ const (
 	ConflictRaces       = "races"
 	ConflictCastes      = "castes"
 	ConflictProfessions = "professions"
+	ConflictTags        = "tags"
 )
 
 type ConflictGroup struct {
-	races       map[string]bool
-	castes      map[string]bool
-	professions map[string]bool
+	entries map[string]map[string]bool
 }
 
 func EmptyConflicts() ConflictGroup {
 	return ConflictGroup{
-		races:       make(map[string]bool),
-		castes:      make(map[string]bool),
-		professions: make(map[string]bool),
+		entries: make(map[string]map[string]bool),
 	}
 }
 
 func (c *ConflictGroup) Load(conflictData map[string][]string) {
-	// Load races
-	for _, id := range conflictData[ConflictRaces] {
-		c.Add(ConflictRaces, id)
+	for target, ids := range conflictData {
+		for _, id := range ids {
+			c.Add(target, id)
+		}
 	}
+}
 
-	// Load castes
-	for _, id := range conflictData[ConflictCastes] {
-		c.Add(ConflictCastes, id)
+func (c *ConflictGroup) Add(target string, id string) {
+	target = strings.ToLower(target)
+	if c.entries == nil {
+		c.entries = make(map[string]map[string]bool)
+	}
+	if c.entries[target] == nil {
+		c.entries[target] = make(map[string]bool)
 	}
+	c.entries[target][id] = true
+}
 
-	// Load professions
-	for _, id := range conflictData[ConflictProfessions] {
-		c.Add(ConflictProfessions, id)
+// Merge adds every entry of another group to this one.
+func (c *ConflictGroup) Merge(other ConflictGroup) {
+	for target, ids := range other.entries {
+		for id := range ids {
+			c.Add(target, id)
+		}
 	}
 }
 
-func (c *ConflictGroup) Add(target string, id string) {
-	switch strings.ToLower(target) {
-	case ConflictRaces:
-		c.races[id] = true
-	case ConflictCastes:
-		c.castes[id] = true
-	case ConflictProfessions:
-		c.professions[id] = true
-	default:
-		log.Warnf("abnormal conflict target: %s:%s", target, id)
+// Ids returns the sorted IDs listed for a target.
+func (c *ConflictGroup) Ids(target string) []string {
+	set := c.entries[strings.ToLower(target)]
+	ids := make([]string, 0, len(set))
+	for id := range set {
+		ids = append(ids, id)
 	}
+	sort.Strings(ids)
+
+	return ids
 }
 
-func (c *ConflictGroup) AllowRace(id string) bool {
-	_, ok := c.races[id]
+func (c *ConflictGroup) Contains(target string, id string) bool {
+	return c.entries[strings.ToLower(target)][id]
+}
 
-	return !ok
+func (c *ConflictGroup) Empty() bool {
+	for _, ids := range c.entries {
+		if len(ids) > 0 {
+			return false
+		}
+	}
+
+	return true
 }
 
-func (c *ConflictGroup) AllowCaste(id string) bool {
-	_, ok := c.castes[id]
+func (c *ConflictGroup) Allow(target string, id string) bool {
+	if strings.ToLower(target) == ConflictTags {
+		return true
+	}
+
+	return !c.Contains(target, id)
+}
+
+// AllowTags checks that none of the given tags are excluded.
+func (c *ConflictGroup) AllowTags(tags []string) bool {
+	for _, tag := range tags {
+		if c.Contains(ConflictTags, tag) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// AllowClassifier checks a classifier against the group by both its ID and
+// its tags.
+func (c *ConflictGroup) AllowClassifier(target string, other *Classifier) bool {
+	return c.Allow(target, other.Id) && c.AllowTags(other.Tags)
+}
+
+func (c *ConflictGroup) AllowRace(id string) bool {
+	return c.Allow(ConflictRaces, id)
+}
 
-	return !ok
+func (c *ConflictGroup) AllowCaste(id string) bool {
+	return c.Allow(ConflictCastes, id)
 }
 
 func (c *ConflictGroup) AllowProfession(id string) bool {
-	_, ok := c.professions[id]
+	return c.Allow(ConflictProfessions, id)
+}
+
+// Targets returns every target with listed IDs: the built-in dimensions
+// first, then any others by name, with tags last.
+func (c *ConflictGroup) Targets() []string {
+	targets := make([]string, 0, len(c.entries))
+	for _, d := range BuiltinDimensions() {
+		if len(c.entries[d.Target]) > 0 {
+			targets = append(targets, d.Target)
+		}
+	}
+
+	others := make([]string, 0)
+	for target, ids := range c.entries {
+		if len(ids) > 0 && !builtin(target) && target != ConflictTags {
+			others = append(others, target)
+		}
+	}
+	sort.Strings(others)
+	targets = append(targets, others...)
+
+	if len(c.entries[ConflictTags]) > 0 {
+		targets = append(targets, ConflictTags)
+	}
+
+	return targets
+}
+
+// listing returns the sorted IDs of every non-empty target.
+func (c ConflictGroup) listing() map[string][]string {
+	listing := make(map[string][]string)
+	for target := range c.entries {
+		if ids := c.Ids(target); len(ids) > 0 {
+			listing[target] = ids
+		}
+	}
+
+	return listing
+}
+
+func (c ConflictGroup) MarshalJSON() ([]byte, error) {
+	return json.Marshal(c.listing())
+}
+
+func (c ConflictGroup) MarshalYAML() (interface{}, error) {
+	return c.listing(), nil
+}
+
+// JSONSchema describes a conflict group as lists of IDs keyed by the
+// built-in targets and tags.
+func (c ConflictGroup) JSONSchema() *schema.Schema {
+	return conflictSchema(NewManifest().ReferenceTargets())
+}
+
+func conflictSchema(targets []string) *schema.Schema {
+	s := schema.MapOf(schema.ArrayOf(schema.String()))
+	s.PropertyNames = schema.Enum(targets...)
 
-	return !ok
+	return s
 }
 
 func (c *ConflictGroup) UnmarshalJSON(data []byte) error {
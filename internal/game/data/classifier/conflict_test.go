@@ -35,43 +35,43 @@ func TestConflictSuite(t *testing.T) {
 func (s *ConflictTestSuite) TestEmptyConflicts() {
 	c := EmptyConflicts()
 
-	s.Empty(c.professions)
-	s.Empty(c.castes)
-	s.Empty(c.races)
+	s.Empty(c.entries[ConflictProfessions])
+	s.Empty(c.entries[ConflictCastes])
+	s.Empty(c.entries[ConflictRaces])
 }
 
 func (s *ConflictTestSuite) TestAdd_Simple() {
 	c := EmptyConflicts()
 
 	c.Add("races", "TEST1")
-	s.Len(c.races, 1)
-	s.Contains(c.races, "TEST1")
+	s.Len(c.entries[ConflictRaces], 1)
+	s.Contains(c.entries[ConflictRaces], "TEST1")
 }
 
 func (s *ConflictTestSuite) TestAdd_Invalid() {
 	c := EmptyConflicts()
 
-	s.Empty(c.professions)
-	s.Empty(c.castes)
-	s.Empty(c.races)
+	s.Empty(c.entries[ConflictProfessions])
+	s.Empty(c.entries[ConflictCastes])
+	s.Empty(c.entries[ConflictRaces])
 
 	c.Add("Invalid", "TEST4")
 
-	s.Empty(c.professions)
-	s.Empty(c.castes)
-	s.Empty(c.races)
+	s.Empty(c.entries[ConflictProfessions])
+	s.Empty(c.entries[ConflictCastes])
+	s.Empty(c.entries[ConflictRaces])
 }
 
 func (s *ConflictTestSuite) TestAdd_Idempotence() {
 	c := EmptyConflicts()
 
 	c.Add("races", "TEST1")
-	s.Len(c.races, 1)
-	s.Contains(c.races, "TEST1")
+	s.Len(c.entries[ConflictRaces], 1)
+	s.Contains(c.entries[ConflictRaces], "TEST1")
 
 	c.Add("races", "TEST1")
-	s.Len(c.races, 1)
-	s.Contains(c.races, "TEST1")
+	s.Len(c.entries[ConflictRaces], 1)
+	s.Contains(c.entries[ConflictRaces], "TEST1")
 }
 
 func (s *ConflictTestSuite) TestAdd_Multiple() {
@@ -81,14 +81,14 @@ func (s *ConflictTestSuite) TestAdd_Multiple() {
 	c.Add("castes", "TEST2")
 	c.Add("professions", "TEST3")
 
-	s.Len(c.races, 1)
-	s.Contains(c.races, "TEST1")
+	s.Len(c.entries[ConflictRaces], 1)
+	s.Contains(c.entries[ConflictRaces], "TEST1")
 
-	s.Len(c.castes, 1)
-	s.Contains(c.castes, "TEST2")
+	s.Len(c.entries[ConflictCastes], 1)
+	s.Contains(c.entries[ConflictCastes], "TEST2")
 
-	s.Len(c.professions, 1)
-	s.Contains(c.professions, "TEST3")
+	s.Len(c.entries[ConflictProfessions], 1)
+	s.Contains(c.entries[ConflictProfessions], "TEST3")
 }
 
 func (s *ConflictTestSuite) TestAllowRace() {
@@ -121,6 +121,47 @@ func (s *ConflictTestSuite) TestAllowProfession() {
 	s.False(c.AllowProfession(testKey))
 }
 
+func (s *ConflictTestSuite) TestAllow() {
+	c := EmptyConflicts()
+
+	c.Add(ConflictRaces, "TEST1")
+	c.Add(ConflictProfessions, "TEST2")
+
+	s.False(c.Allow(ConflictRaces, "TEST1"))
+	s.True(c.Allow(ConflictCastes, "TEST1"))
+	s.False(c.Allow(ConflictProfessions, "TEST2"))
+	s.True(c.Allow("Invalid", "TEST1"))
+}
+
+func (s *ConflictTestSuite) TestAllowTags() {
+	c := EmptyConflicts()
+	c.Add(ConflictTags, "undead")
+
+	s.True(c.AllowTags([]string{}))
+	s.True(c.AllowTags([]string{"martial"}))
+	s.False(c.AllowTags([]string{"martial", "undead"}))
+}
+
+func (s *ConflictTestSuite) TestAllowClassifier() {
+	c := EmptyConflicts()
+	c.Add(ConflictTags, "undead")
+	c.Add(ConflictRaces, "Orc")
+
+	ghoul := Initialize()
+	ghoul.Id = "Ghoul"
+	ghoul.Tags = []string{"undead"}
+
+	orc := Initialize()
+	orc.Id = "Orc"
+
+	elf := Initialize()
+	elf.Id = "Elf"
+
+	s.False(c.AllowClassifier(ConflictRaces, &ghoul))
+	s.False(c.AllowClassifier(ConflictRaces, &orc))
+	s.True(c.AllowClassifier(ConflictRaces, &elf))
+}
+
 func (s *ConflictTestSuite) TestUnmarshallYAML() {
 	c := EmptyConflicts()
 
@@ -130,9 +171,9 @@ func (s *ConflictTestSuite) TestUnmarshallYAML() {
 	err := yaml.Unmarshal(data, &c)
 	s.Require().Nil(err)
 
-	s.Len(c.races, 2)
-	s.Len(c.castes, 3)
-	s.Len(c.professions, 4)
+	s.Len(c.entries[ConflictRaces], 2)
+	s.Len(c.entries[ConflictCastes], 3)
+	s.Len(c.entries[ConflictProfessions], 4)
 }
 
 func (s *ConflictTestSuite) TestUnmarshallJSON() {
@@ -144,9 +185,9 @@ func (s *ConflictTestSuite) TestUnmarshallJSON() {
 	err := json.Unmarshal(data, &c)
 	s.Require().Nil(err)
 
-	s.Len(c.races, 2)
-	s.Len(c.castes, 3)
-	s.Len(c.professions, 4)
+	s.Len(c.entries[ConflictRaces], 2)
+	s.Len(c.entries[ConflictCastes], 3)
+	s.Len(c.entries[ConflictProfessions], 4)
 }
 
 func (s *ConflictTestSuite) TestUnmarshallYAML_Malformed() {
@@ -0,0 +1,109 @@
+//------------------------------------------------------------------------------
+//    Copyright 2019 Jeff Sharpe (zeropointx.io)
+//
+//    Licensed under the Apache License, Version 2.0 (the "License");
+//    you may not use this file except in compliance with the License.
+//    You may obtain a copy of the License at
+//
+//        http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS,
+//    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//    See the License for the specific language governing permissions and
+//    limitations under the License.
+//------------------------------------------------------------------------------
+
+package classifier
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Dimension is one axis along which heroes are classified, such as race or
+// faith. Target is the plural key used in data files, conflicts and
+// requirements; Kind is the singular name used in messages and sources.
+type Dimension struct {
+	Target string `yaml:"target" json:"target"`
+	Kind   string `yaml:"kind" json:"kind"`
+}
+
+var (
+	DimensionRaces       = Dimension{Target: ConflictRaces, Kind: "race"}
+	DimensionCastes      = Dimension{Target: ConflictCastes, Kind: "caste"}
+	DimensionProfessions = Dimension{Target: ConflictProfessions, Kind: "profession"}
+)
+
+// BuiltinDimensions returns the dimensions every manifest starts with.
+func BuiltinDimensions() []Dimension {
+	return []Dimension{DimensionRaces, DimensionCastes, DimensionProfessions}
+}
+
+func builtin(target string) bool {
+	switch target {
+	case ConflictRaces, ConflictCastes, ConflictProfessions:
+		return true
+	}
+
+	return false
+}
+
+// Declare makes a new classifier dimension available to the manifest, so its
+// classifiers may be registered and referenced. Declaring an existing
+// dimension is a no-op.
+func (m *ClassifierManifest) Declare(d Dimension) error {
+	d.Target = strings.ToLower(d.Target)
+	if d.Target == "" || d.Kind == "" {
+		return fmt.Errorf("dimension needs both a target and a kind: %+v", d)
+	}
+	if d.Target == ConflictTags {
+		return fmt.Errorf("dimension target is reserved: %s", d.Target)
+	}
+
+	for _, existing := range m.declared {
+		if existing.Target == d.Target {
+			if existing.Kind != d.Kind {
+				return fmt.Errorf("dimension %s already declared with kind %s", d.Target, existing.Kind)
+			}
+			return nil
+		}
+	}
+
+	m.declared = append(m.declared, d)
+
+	return nil
+}
+
+// Declared returns every dimension declared in the manifest, built-ins first,
+// whether or not it has any classifiers.
+func (m *ClassifierManifest) Declared() []Dimension {
+	return append([]Dimension{}, m.declared...)
+}
+
+// Lookup finds a declared dimension by its target.
+func (m *ClassifierManifest) Lookup(target string) (Dimension, bool) {
+	target = strings.ToLower(target)
+	for _, d := range m.declared {
+		if d.Target == target {
+			return d, true
+		}
+	}
+
+	return Dimension{}, false
+}
+
+// Targets returns the targets of every declared dimension.
+func (m *ClassifierManifest) Targets() []string {
+	targets := make([]string, len(m.declared))
+	for i, d := range m.declared {
+		targets[i] = d.Target
+	}
+
+	return targets
+}
+
+// ReferenceTargets are every target a group may list, including tags.
+func (m *ClassifierManifest) ReferenceTargets() []string {
+	return append(m.Targets(), ConflictTags)
+}
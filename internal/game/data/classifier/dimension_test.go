@@ -0,0 +1,147 @@
+//------------------------------------------------------------------------------
+//    Copyright 2019 Jeff Sharpe (zeropointx.io)
+//
+//    Licensed under the Apache License, Version 2.0 (the "License");
+//    you may not use this file except in compliance with the License.
+//    You may obtain a copy of the License at
+//
+//        http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS,
+//    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//    See the License for the specific language governing permissions and
+//    limitations under the License.
+//------------------------------------------------------------------------------
+
+package classifier
+
+import (
+	"github.com/stretchr/testify/suite"
+	"github.com/zpxio/heromanager/internal/game/data/attributes"
+	"testing"
+)
+
+type DimensionTestSuite struct {
+	suite.Suite
+}
+
+func TestDimensionSuite(t *testing.T) {
+	suite.Run(t, new(DimensionTestSuite))
+}
+
+func (s *DimensionTestSuite) TestBuiltins() {
+	m := NewManifest()
+	targets := m.Targets()
+
+	s.Equal([]string{ConflictRaces, ConflictCastes, ConflictProfessions}, targets)
+	s.NotContains(targets, ConflictTags)
+	s.Contains(m.ReferenceTargets(), ConflictTags)
+
+	d, found := m.Lookup("Races")
+	s.True(found)
+	s.Equal(DimensionRaces, d)
+}
+
+func (s *DimensionTestSuite) TestDeclare() {
+	m := NewManifest()
+	s.Require().Nil(m.Declare(Dimension{Target: "Backgrounds", Kind: "background"}))
+	s.Nil(m.Declare(Dimension{Target: "backgrounds", Kind: "background"}))
+
+	d, found := m.Lookup("backgrounds")
+	s.True(found)
+	s.Equal("background", d.Kind)
+	s.Contains(m.ReferenceTargets(), "backgrounds")
+	s.Len(m.Declared(), 4)
+
+	// Declarations belong to the manifest they were made in
+	_, found = NewManifest().Lookup("backgrounds")
+	s.False(found)
+}
+
+func (s *DimensionTestSuite) TestDeclare_Invalid() {
+	m := NewManifest()
+	s.NotNil(m.Declare(Dimension{Target: "", Kind: "nothing"}))
+	s.NotNil(m.Declare(Dimension{Target: "nothings", Kind: ""}))
+	s.NotNil(m.Declare(Dimension{Target: ConflictTags, Kind: "tag"}))
+	s.NotNil(m.Declare(Dimension{Target: ConflictRaces, Kind: "species"}))
+}
+
+func (s *DimensionTestSuite) TestManifestDimensions() {
+	m := NewManifest()
+	s.Require().Nil(m.Declare(Dimension{Target: "homelands", Kind: "homeland"}))
+	s.Equal([]Dimension{DimensionRaces, DimensionCastes, DimensionProfessions}, m.Dimensions())
+
+	m.Register("homelands", "Coast", Initialize())
+	s.Contains(m.Dimensions(), Dimension{Target: "homelands", Kind: "homeland"})
+	s.Equal([]string{"Coast"}, m.All("homelands"))
+
+	c, found := m.Resolve("homelands", "Coast")
+	s.Require().True(found)
+	s.Equal("Coast", c.Id)
+}
+
+func (s *DimensionTestSuite) TestRegister_Undeclared() {
+	m := NewManifest()
+	m.Register("moons", "Red", Initialize())
+
+	_, found := m.Resolve("moons", "Red")
+	s.False(found)
+	s.Empty(m.All("moons"))
+}
+
+func (s *DimensionTestSuite) TestConflicts() {
+	c := EmptyConflicts()
+	c.Add("faiths", "Sun")
+	c.Add("Tags", "holy")
+	c.Add("races", "Elf")
+
+	s.False(c.Allow("faiths", "Sun"))
+	s.True(c.Allow("faiths", "Stone"))
+	s.Equal([]string{"Sun"}, c.Ids("faiths"))
+	s.Equal([]string{ConflictRaces, "faiths", ConflictTags}, c.Targets())
+}
+
+func (s *DimensionTestSuite) TestValidate_Undeclared() {
+	m := NewManifest()
+	r := BlankRace()
+	r.Conflicts.Add("moons", "Red")
+	m.RegisterRace("Dwarf", r)
+
+	err := m.Validate()
+	s.Require().NotNil(err)
+	s.Contains(err.Error(), "undeclared dimension: moons")
+}
+
+func (s *DimensionTestSuite) TestLoadDimensions() {
+	m := NewManifest()
+	err := LoadDimensions("testdata/game/data/dimension", "test_dimension_simple.yml", m)
+	s.Require().Nil(err)
+
+	_, found := m.Lookup("homelands")
+	s.True(found)
+
+	s.Len(m.All("faiths"), 2)
+	sun, found := m.Resolve("faiths", "Sun")
+	s.Require().True(found)
+	s.Equal("Sun Cult", sun.Name)
+	s.Equal(1.2, sun.Attributes.Factor(attributes.Allure))
+	s.False(sun.Conflicts.AllowRace("Dwarf"))
+
+	stone, found := m.Resolve("faiths", "Stone")
+	s.Require().True(found)
+	s.Equal(float32(3.0), stone.Weight)
+}
+
+func (s *DimensionTestSuite) TestLoadDimensions_Reserved() {
+	err := LoadDimensions("testdata/game/data/dimension", "test_dimension_reserved.yml", NewManifest())
+	s.NotNil(err)
+}
+
+func (s *DimensionTestSuite) TestLoadClassifiers_Unknown() {
+	err := LoadClassifiers("moons", "testdata/game/data/dimension", "test_faiths.yml", NewManifest())
+
+	s.Require().NotNil(err)
+	_, ok := err.(*UnknownDimensionError)
+	s.True(ok)
+}
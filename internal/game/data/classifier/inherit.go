@@ -0,0 +1,90 @@
+//------------------------------------------------------------------------------
+//    Copyright 2019 Jeff Sharpe (zeropointx.io)
+//
+//    Licensed under the Apache License, Version 2.0 (the "License");
+//    you may not use this file except in compliance with the License.
+//    You may obtain a copy of the License at
+//
+//        http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS,
+//    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//    See the License for the specific language governing permissions and
+//    limitations under the License.
+//------------------------------------------------------------------------------
+
+package classifier
+
+import "fmt"
+
+// resolveInheritance applies `extends` for every classifier of one kind,
+// resolving parents before their children and rejecting unknown parents and
+// cycles.
+func resolveInheritance(kind string, ids []string, get func(id string) (*Classifier, bool), set func(id string, c *Classifier)) error {
+	const (
+		visiting = 1
+		done     = 2
+	)
+	state := make(map[string]int, len(ids))
+
+	var resolve func(id string, chain []string) error
+	resolve = func(id string, chain []string) error {
+		switch state[id] {
+		case done:
+			return nil
+		case visiting:
+			return fmt.Errorf("%s inheritance cycle: %v", kind, append(chain, id))
+		}
+		state[id] = visiting
+
+		c, _ := get(id)
+		if c.Extends != "" {
+			if _, found := get(c.Extends); !found {
+				return fmt.Errorf("%s %s extends unknown %s: %s", kind, id, kind, c.Extends)
+			}
+
+			err := resolve(c.Extends, append(chain, id))
+			if err != nil {
+				return err
+			}
+
+			parent, _ := get(c.Extends)
+			c.Inherit(parent)
+			set(id, c)
+		}
+
+		state[id] = done
+		return nil
+	}
+
+	for _, id := range ids {
+		err := resolve(id, []string{})
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// ResolveInheritance applies `extends` across the manifest. It should be
+// called once every data file has been loaded, since a parent may be defined
+// in a different file than its children.
+func (m *ClassifierManifest) ResolveInheritance() error {
+	for _, d := range m.Dimensions() {
+		target := d.Target
+		err := resolveInheritance(d.Kind, m.All(target),
+			func(id string) (*Classifier, bool) {
+				return m.Resolve(target, id)
+			},
+			func(id string, c *Classifier) {
+				m.classifiers[target][id] = *c
+			})
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,127 @@
+//------------------------------------------------------------------------------
+//    Copyright 2019 Jeff Sharpe (zeropointx.io)
+//
+//    Licensed under the Apache License, Version 2.0 (the "License");
+//    you may not use this file except in compliance with the License.
+//    You may obtain a copy of the License at
+//
+//        http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS,
+//    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//    See the License for the specific language governing permissions and
+//    limitations under the License.
+//------------------------------------------------------------------------------
+
+package classifier
+
+import (
+	"github.com/stretchr/testify/suite"
+	"github.com/zpxio/heromanager/internal/game/data/attributes"
+	"testing"
+)
+
+type InheritTestSuite struct {
+	suite.Suite
+}
+
+func TestInheritSuite(t *testing.T) {
+	suite.Run(t, new(InheritTestSuite))
+}
+
+func (s *InheritTestSuite) TestResolveInheritance() {
+	m := NewManifest()
+	s.Require().Nil(LoadRaces("testdata/game/data/race", "test_race_extends.yml", m))
+	s.Require().Nil(m.ResolveInheritance())
+
+	hill, _ := m.ResolveRace("HillDwarf")
+	s.Equal("Hill Dwarf", hill.Name)
+	s.Equal(float32(2.0), hill.Rarity())
+	s.Equal(1.12, hill.Attributes.Factor(attributes.Brawn))
+	s.Equal(1.2, hill.Attributes.Factor(attributes.Vigor))
+	s.Equal(0.9, hill.Attributes.Factor(attributes.Allure))
+	s.Equal([]string{"stout"}, hill.Tags)
+	s.False(hill.Conflicts.AllowCaste("Serf"))
+
+	mountain, _ := m.ResolveRace("MountainDwarf")
+	s.Equal("Hill Dwarf", mountain.Name)
+	s.Equal(float32(1.0), mountain.Rarity())
+	s.Equal(1.3, mountain.Attributes.Factor(attributes.Brawn))
+	s.Equal(1.2, mountain.Attributes.Factor(attributes.Vigor))
+	s.Equal(0.9, mountain.Attributes.Factor(attributes.Allure))
+	s.Equal([]string{"stout", "miner"}, mountain.Tags)
+	s.False(mountain.Conflicts.AllowCaste("Serf"))
+	s.False(mountain.Conflicts.AllowProfession("Sailor"))
+
+	// The parent is unchanged
+	dwarf, _ := m.ResolveRace("Dwarf")
+	s.True(dwarf.Conflicts.AllowProfession("Sailor"))
+	s.Equal([]string{"stout"}, dwarf.Tags)
+}
+
+func (s *InheritTestSuite) TestResolveInheritance_Null() {
+	m := NewManifest()
+	s.Require().Nil(LoadRaces("testdata/game/data/race", "test_race_extends_null.yml", m))
+	s.Require().Nil(m.ResolveInheritance())
+
+	r, found := m.ResolveRace("NullDwarf")
+	s.Require().True(found)
+	s.Equal("Dwarf", r.Name)
+	s.Equal(1.12, r.Attributes.Factor(attributes.Brawn))
+	s.Equal([]string{"stout"}, r.Tags)
+	s.Equal(map[string]float64{"Smithing": 10}, r.Skills)
+	s.Equal(float32(2.0), r.WeightGiven(map[string]string{ConflictCastes: "Noble"}))
+}
+
+func (s *InheritTestSuite) TestResolveInheritance_Idempotent() {
+	m := NewManifest()
+	s.Require().Nil(LoadRaces("testdata/game/data/race", "test_race_extends.yml", m))
+	s.Require().Nil(m.ResolveInheritance())
+	s.Require().Nil(m.ResolveInheritance())
+
+	mountain, _ := m.ResolveRace("MountainDwarf")
+	s.Equal([]string{"stout", "miner"}, mountain.Tags)
+	s.Equal(1.3, mountain.Attributes.Factor(attributes.Brawn))
+}
+
+func (s *InheritTestSuite) TestResolveInheritance_Cycle() {
+	m := NewManifest()
+	s.Require().Nil(LoadRaces("testdata/game/data/race", "test_race_extends_cycle.yml", m))
+
+	err := m.ResolveInheritance()
+	s.Require().NotNil(err)
+	s.Contains(err.Error(), "race inheritance cycle")
+}
+
+func (s *InheritTestSuite) TestResolveInheritance_Unknown() {
+	m := NewManifest()
+	s.Require().Nil(LoadRaces("testdata/game/data/race", "test_race_extends_unknown.yml", m))
+
+	err := m.ResolveInheritance()
+	s.Require().NotNil(err)
+	s.Equal("race HillDwarf extends unknown race: Dwarf", err.Error())
+}
+
+func (s *InheritTestSuite) TestResolveInheritance_Professions() {
+	m := NewManifest()
+
+	parent := BlankProfession()
+	parent.Name = "Soldier"
+	parent.Attributes.Load(map[string]float64{attributes.Brawn: 0.2})
+	parent.Requires.AnyOf.Add(ConflictTags, "martial")
+	m.RegisterProfession("Soldier", parent)
+
+	child := BlankProfession()
+	child.Extends = "Soldier"
+	child.Attributes.Load(map[string]float64{attributes.Finesse: 0.1})
+	m.RegisterProfession("Archer", child)
+
+	s.Require().Nil(m.ResolveInheritance())
+
+	archer, _ := m.ResolveProfession("Archer")
+	s.Equal("Soldier", archer.Name)
+	s.Equal(1.2, archer.Attributes.Factor(attributes.Brawn))
+	s.Equal(1.1, archer.Attributes.Factor(attributes.Finesse))
+	s.True(archer.Requires.AnyOf.Contains(ConflictTags, "martial"))
+}
@@ -0,0 +1,130 @@
+//------------------------------------------------------------------------------
+//    Copyright 2019 Jeff Sharpe (zeropointx.io)
+//
+//    Licensed under the Apache License, Version 2.0 (the "License");
+//    you may not use this file except in compliance with the License.
+//    You may obtain a copy of the License at
+//
+//        http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS,
+//    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//    See the License for the specific language governing permissions and
+//    limitations under the License.
+//------------------------------------------------------------------------------
+
+package classifier
+
+import (
+	"fmt"
+	log "github.com/sirupsen/logrus"
+	"github.com/zpxio/heromanager/internal/game/data/schema"
+	"github.com/zpxio/heromanager/internal/game/util"
+	"gopkg.in/yaml.v2"
+	"sort"
+)
+
+// Layer is a set of changes to one dimension, as shipped by a content pack.
+// Removals are applied first, so a layer may replace a classifier by removing
+// and adding it, followed by additions and then patches.
+type Layer struct {
+	Add    map[string]Classifier `yaml:"add" json:"add"`
+	Patch  map[string]Classifier `yaml:"patch" json:"patch"`
+	Remove []string              `yaml:"remove" json:"remove"`
+}
+
+// Apply makes the changes in a layer to a dimension. Adding an existing ID,
+// or patching or removing a missing one, is an error; the manifest is left
+// unchanged unless the whole layer applies.
+func (m *ClassifierManifest) Apply(target string, source string, layer Layer) error {
+	if _, known := m.Lookup(target); !known {
+		return &UnknownDimensionError{Target: target}
+	}
+
+	exists := make(map[string]bool)
+	for _, id := range m.All(target) {
+		exists[id] = true
+	}
+
+	for _, id := range layer.Remove {
+		if !exists[id] {
+			return fmt.Errorf("%s cannot remove unknown %s: %s", source, target, id)
+		}
+		exists[id] = false
+	}
+	for _, id := range sortedIds(layer.Add) {
+		if exists[id] {
+			return fmt.Errorf("%s cannot add existing %s: %s", source, target, id)
+		}
+		exists[id] = true
+	}
+	for _, id := range sortedIds(layer.Patch) {
+		if !exists[id] {
+			return fmt.Errorf("%s cannot patch unknown %s: %s", source, target, id)
+		}
+	}
+
+	for _, id := range layer.Remove {
+		m.Remove(target, id)
+	}
+	for _, id := range sortedIds(layer.Add) {
+		m.Register(target, id, layer.Add[id])
+		m.Trace(target, id, Origin{Source: source, Action: ActionAdd})
+	}
+	for _, id := range sortedIds(layer.Patch) {
+		c, _ := m.Resolve(target, id)
+		c.Patch(layer.Patch[id])
+		m.classifiers[target][id] = *c
+		m.Trace(target, id, Origin{Source: source, Action: ActionPatch})
+	}
+
+	return nil
+}
+
+// LoadLayers applies every layer file for a dimension in a game directory,
+// found in the same locations LoadDimension reads from.
+func LoadLayers(d Dimension, gameDir string, source string, manifest *ClassifierManifest) error {
+	files, err := util.KindFiles(gameDir, d.Target)
+	if err != nil {
+		return err
+	}
+
+	layerSchema := LayerSchema(d, manifest)
+	for _, file := range files {
+		data, err := util.GameFileData(gameDir, file)
+		if err != nil {
+			return err
+		}
+
+		err = schema.ValidateData(source+"/"+file, layerSchema, data)
+		if err != nil {
+			log.Errorf("invalid %s layer: %s", d.Kind, err)
+			return err
+		}
+
+		layer := Layer{}
+		err = yaml.UnmarshalStrict(data, &layer)
+		if err != nil {
+			log.Errorf("failed to parse %s layer in %s: %s", d.Kind, file, err)
+			return fmt.Errorf("%s/%s: %s", source, file, err)
+		}
+
+		err = manifest.Apply(d.Target, source+"/"+file, layer)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func sortedIds(classifiers map[string]Classifier) []string {
+	ids := make([]string, 0, len(classifiers))
+	for id := range classifiers {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	return ids
+}
@@ -0,0 +1,130 @@
+//------------------------------------------------------------------------------
+//    Copyright 2019 Jeff Sharpe (zeropointx.io)
+//
+//    Licensed under the Apache License, Version 2.0 (the "License");
+//    you may not use this file except in compliance with the License.
+//    You may obtain a copy of the License at
+//
+//        http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS,
+//    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//    See the License for the specific language governing permissions and
+//    limitations under the License.
+//------------------------------------------------------------------------------
+
+package classifier
+
+import (
+	"github.com/stretchr/testify/suite"
+	"github.com/zpxio/heromanager/internal/game/data/attributes"
+	"gopkg.in/yaml.v2"
+	"testing"
+)
+
+type LayerTestSuite struct {
+	suite.Suite
+}
+
+func TestLayerSuite(t *testing.T) {
+	suite.Run(t, new(LayerTestSuite))
+}
+
+func layerManifest() *ClassifierManifest {
+	m := NewManifest()
+
+	dwarf := BlankRace()
+	dwarf.Name = "Dwarf"
+	dwarf.Extends = "Stout"
+	dwarf.Attributes.Load(map[string]float64{attributes.Brawn: 0.1, attributes.Vigor: 0.2})
+	dwarf.Conflicts.Add(ConflictCastes, "Serf")
+	m.RegisterRace("Dwarf", dwarf)
+	m.RegisterRace("Stout", BlankRace())
+	m.RegisterRace("Elf", BlankRace())
+
+	return m
+}
+
+func (s *LayerTestSuite) TestPatch() {
+	patch := Classifier{}
+	s.Require().Nil(yaml.Unmarshal([]byte("weight: 3\nattributes:\n  Brawn: 0.5\nconflicts:\n  castes: [Noble]\n"), &patch))
+
+	r, _ := layerManifest().ResolveRace("Dwarf")
+	r.Patch(patch)
+
+	s.Equal("Dwarf", r.Name)
+	s.Equal("Stout", r.Extends)
+	s.Equal(float32(3.0), r.Weight)
+	s.Equal(1.5, r.Attributes.Factor(attributes.Brawn))
+	s.Equal(1.2, r.Attributes.Factor(attributes.Vigor))
+	s.False(r.Conflicts.AllowCaste("Serf"))
+	s.False(r.Conflicts.AllowCaste("Noble"))
+}
+
+func (s *LayerTestSuite) TestPatch_Null() {
+	patch := Classifier{}
+	s.Require().Nil(yaml.Unmarshal([]byte("attributes:\nweights:\nconflicts:\nrequires:\n"), &patch))
+
+	r, _ := layerManifest().ResolveRace("Dwarf")
+	r.Patch(patch)
+
+	s.Equal(1.1, r.Attributes.Factor(attributes.Brawn))
+	s.False(r.Conflicts.AllowCaste("Serf"))
+}
+
+func (s *LayerTestSuite) TestPatch_Name() {
+	patch := Classifier{}
+	s.Require().Nil(yaml.Unmarshal([]byte("name: Deep Dwarf\nextends: Elf\n"), &patch))
+
+	r, _ := layerManifest().ResolveRace("Dwarf")
+	r.Patch(patch)
+
+	s.Equal("Deep Dwarf", r.Name)
+	s.Equal("Elf", r.Extends)
+	s.Equal(float32(DefaultWeight), r.Weight)
+}
+
+func (s *LayerTestSuite) TestApply() {
+	m := layerManifest()
+
+	layer := Layer{}
+	s.Require().Nil(yaml.Unmarshal([]byte("add:\n  Orc:\n    name: Orc\npatch:\n  Orc:\n    weight: 0.5\nremove: [Elf]\n"), &layer))
+
+	s.Require().Nil(m.Apply(ConflictRaces, "pack/races.yml", layer))
+
+	s.ElementsMatch([]string{"Dwarf", "Stout", "Orc"}, m.AllRaces())
+	orc, found := m.ResolveRace("Orc")
+	s.Require().True(found)
+	s.Equal("Orc", orc.Name)
+	s.Equal(float32(0.5), orc.Weight)
+	s.Equal([]Origin{{Source: "pack/races.yml", Action: ActionAdd}, {Source: "pack/races.yml", Action: ActionPatch}}, m.Origins(ConflictRaces, "Orc"))
+	s.Empty(m.Origins(ConflictRaces, "Elf"))
+}
+
+func (s *LayerTestSuite) TestApply_Replace() {
+	m := layerManifest()
+
+	layer := Layer{Add: map[string]Classifier{"Elf": Initialize()}, Remove: []string{"Elf"}}
+	s.Nil(m.Apply(ConflictRaces, "pack", layer))
+	s.Len(m.AllRaces(), 3)
+}
+
+func (s *LayerTestSuite) TestApply_Errors() {
+	m := layerManifest()
+
+	s.NotNil(m.Apply(ConflictRaces, "pack", Layer{Add: map[string]Classifier{"Elf": Initialize()}}))
+	s.NotNil(m.Apply(ConflictRaces, "pack", Layer{Patch: map[string]Classifier{"Orc": Initialize()}}))
+	s.NotNil(m.Apply(ConflictRaces, "pack", Layer{Remove: []string{"Orc"}}))
+	s.NotNil(m.Apply("moons", "pack", Layer{}))
+
+	// A failed layer leaves the manifest untouched
+	s.NotNil(m.Apply(ConflictRaces, "pack", Layer{Remove: []string{"Elf"}, Patch: map[string]Classifier{"Elf": Initialize()}}))
+	s.Len(m.AllRaces(), 3)
+}
+
+func (s *LayerTestSuite) TestLoadLayers_Strict() {
+	err := LoadLayers(DimensionRaces, "testdata/game/data/tree", "tree", NewManifest())
+
+	s.NotNil(err)
+}
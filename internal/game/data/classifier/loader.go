@@ -0,0 +1,201 @@
+//------------------------------------------------------------------------------
+//    Copyright 2019 Jeff Sharpe (zeropointx.io)
+//
+//    Licensed under the Apache License, Version 2.0 (the "License");
+//    you may not use this file except in compliance with the License.
+//    You may obtain a copy of the License at
+//
+//        http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS,
+//    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//    See the License for the specific language governing permissions and
+//    limitations under the License.
+//------------------------------------------------------------------------------
+
+package classifier
+
+import (
+	"fmt"
+	log "github.com/sirupsen/logrus"
+	"github.com/zpxio/heromanager/internal/game/data/schema"
+	"github.com/zpxio/heromanager/internal/game/util"
+	"gopkg.in/yaml.v2"
+	"strings"
+)
+
+// LoadClassifiers reads classifiers from a data file, or from every data file
+// in a directory tree, and registers them under the given dimension. An ID
+// defined in more than one file is an error, and nothing is registered
+// unless every file loads cleanly.
+func LoadClassifiers(target string, gameDir string, dataPath string, manifest *ClassifierManifest) error {
+	d, known := manifest.Lookup(target)
+	if !known {
+		log.Errorf("unknown classifier dimension: %s", target)
+		return &UnknownDimensionError{Target: target}
+	}
+
+	files, err := util.DataFiles(gameDir, dataPath)
+	if err != nil {
+		log.Errorf("failed to find %s data: %s", d.Kind, err)
+		return err
+	}
+
+	return loadClassifierFiles(d, gameDir, files, manifest)
+}
+
+// LoadDimension loads every classifier of a dimension from its conventional
+// location in a game directory: a file named after the dimension target,
+// such as races.yml, and the directory tree of the same name, such as
+// races/. A dimension without any data files loads nothing.
+func LoadDimension(d Dimension, gameDir string, manifest *ClassifierManifest) error {
+	files, err := util.KindFiles(gameDir, d.Target)
+	if err != nil {
+		log.Errorf("failed to find %s data: %s", d.Kind, err)
+		return err
+	}
+	if len(files) == 0 {
+		log.Warnf("no %s data found in: %s", d.Kind, gameDir)
+	}
+
+	return loadClassifierFiles(d, gameDir, files, manifest)
+}
+
+func loadClassifierFiles(d Dimension, gameDir string, files []string, manifest *ClassifierManifest) error {
+	loaded := make(map[string]Classifier)
+	sources := make(map[string]string)
+	ids := make([]string, 0)
+	fileSchema := FileSchema(d, manifest)
+
+	for _, file := range files {
+		data, err := util.GameFileData(gameDir, file)
+		if err != nil {
+			log.Errorf("yamlFile.Get err %v ", err)
+			return err
+		}
+
+		err = schema.ValidateData(file, fileSchema, data)
+		if err != nil {
+			log.Errorf("invalid %s data: %s", d.Kind, err)
+			return err
+		}
+
+		classifiers := make(map[string]Classifier)
+		err = yaml.Unmarshal(data, &classifiers)
+		if err != nil {
+			log.Errorf("failed to parse %s data in %s: %s", d.Kind, file, err)
+			return err
+		}
+
+		for id, c := range classifiers {
+			if first, duplicate := sources[id]; duplicate {
+				return &DuplicateIdError{Kind: d.Kind, Id: id, Files: []string{first, file}}
+			}
+			sources[id] = file
+			loaded[id] = c
+			ids = append(ids, id)
+		}
+	}
+
+	// Register the classifiers
+	for _, id := range ids {
+		manifest.Register(d.Target, id, loaded[id])
+		manifest.Trace(d.Target, id, Origin{Source: sources[id], Action: ActionAdd})
+	}
+
+	return nil
+}
+
+// dimensionSpec declares a dimension in data, optionally naming the file or
+// directory that holds its classifiers.
+type dimensionSpec struct {
+	Dimension `yaml:",inline"`
+	File      string `yaml:"file"`
+}
+
+// LoadDimensions declares every dimension listed in a data file and loads
+// its classifiers, either from the file or directory it names or from its
+// conventional location.
+func LoadDimensions(gameDir string, dataFile string, manifest *ClassifierManifest) error {
+	data, err := util.GameFileData(gameDir, dataFile)
+	if err != nil {
+		log.Errorf("yamlFile.Get err %v ", err)
+		return err
+	}
+
+	err = schema.ValidateData(dataFile, DimensionsSchema(), data)
+	if err != nil {
+		log.Errorf("invalid dimension data: %s", err)
+		return err
+	}
+
+	specs := make([]dimensionSpec, 0)
+	err = yaml.Unmarshal(data, &specs)
+	if err != nil {
+		log.Errorf("failed to parse dimension data: %s", err)
+		return err
+	}
+
+	for _, spec := range specs {
+		err = manifest.Declare(spec.Dimension)
+		if err != nil {
+			return err
+		}
+
+		if spec.File != "" {
+			err = LoadClassifiers(spec.Target, gameDir, spec.File, manifest)
+		} else {
+			err = LoadDimension(spec.Dimension, gameDir, manifest)
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// LoadAllClassifiers loads every classifier in a game directory: the
+// built-in dimensions from their conventional locations, then any dimensions
+// declared in dimensions.yml or the dimensions/ tree.
+func LoadAllClassifiers(gameDir string, manifest *ClassifierManifest) error {
+	for _, d := range BuiltinDimensions() {
+		err := LoadDimension(d, gameDir, manifest)
+		if err != nil {
+			return err
+		}
+	}
+
+	files, err := util.KindFiles(gameDir, "dimensions")
+	if err != nil {
+		return err
+	}
+	for _, file := range files {
+		err = LoadDimensions(gameDir, file, manifest)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+type UnknownDimensionError struct {
+	Target string
+}
+
+func (e *UnknownDimensionError) Error() string {
+	return "unknown classifier dimension: " + e.Target
+}
+
+// DuplicateIdError reports a classifier ID defined in more than one file.
+type DuplicateIdError struct {
+	Kind  string
+	Id    string
+	Files []string
+}
+
+func (e *DuplicateIdError) Error() string {
+	return fmt.Sprintf("duplicate %s %s defined in: %s", e.Kind, e.Id, strings.Join(e.Files, ", "))
+}
@@ -0,0 +1,88 @@
+//------------------------------------------------------------------------------
+//    Copyright 2019 Jeff Sharpe (zeropointx.io)
+//
+//    Licensed under the Apache License, Version 2.0 (the "License");
+//    you may not use this file except in compliance with the License.
+//    You may obtain a copy of the License at
+//
+//        http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS,
+//    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//    See the License for the specific language governing permissions and
+//    limitations under the License.
+//------------------------------------------------------------------------------
+
+package classifier
+
+import (
+	"github.com/stretchr/testify/suite"
+	"github.com/zpxio/heromanager/internal/game/data/attributes"
+	"testing"
+)
+
+type LoaderTestSuite struct {
+	suite.Suite
+}
+
+func TestLoaderSuite(t *testing.T) {
+	suite.Run(t, new(LoaderTestSuite))
+}
+
+func (s *LoaderTestSuite) TestLoadClassifiers_Directory() {
+	m := NewManifest()
+	err := LoadRaces("testdata/game/data/tree", "races", m)
+
+	s.Require().Nil(err)
+	s.ElementsMatch([]string{"Dwarf", "Elf", "Gnome"}, m.AllRaces())
+
+	g, found := m.ResolveRace("Gnome")
+	s.Require().True(found)
+	s.Equal(float32(0.5), g.Weight)
+
+	d, found := m.ResolveRace("Dwarf")
+	s.Require().True(found)
+	s.Equal(1.12, d.Attributes.Factor(attributes.Brawn))
+}
+
+func (s *LoaderTestSuite) TestLoadClassifiers_Duplicate() {
+	m := NewManifest()
+	err := LoadRaces("testdata/game/data/tree_dup", "races", m)
+
+	s.Require().NotNil(err)
+	dup, ok := err.(*DuplicateIdError)
+	s.Require().True(ok)
+	s.Equal("Dwarf", dup.Id)
+	s.Equal([]string{"races/a.yml", "races/b.yml"}, dup.Files)
+	s.Empty(m.AllRaces())
+}
+
+func (s *LoaderTestSuite) TestLoadDimension() {
+	m := NewManifest()
+	err := LoadDimension(DimensionRaces, "testdata/game/data/tree", m)
+
+	s.Require().Nil(err)
+	s.ElementsMatch([]string{"Human", "Dwarf", "Elf", "Gnome"}, m.AllRaces())
+}
+
+func (s *LoaderTestSuite) TestLoadAllClassifiers() {
+	m := NewManifest()
+	err := LoadAllClassifiers("testdata/game/data/tree", m)
+	s.Require().Nil(err)
+	s.Require().Nil(m.ResolveInheritance())
+
+	s.Len(m.AllRaces(), 4)
+	s.Equal([]string{"Noble"}, m.AllCastes())
+	s.Empty(m.AllProfessions())
+
+	e, found := m.ResolveRace("Elf")
+	s.Require().True(found)
+	s.Equal("Human", e.Extends)
+}
+
+func (s *LoaderTestSuite) TestLoadAllClassifiers_Duplicate() {
+	err := LoadAllClassifiers("testdata/game/data/tree_dup", NewManifest())
+
+	s.NotNil(err)
+}
@@ -16,113 +16,214 @@
 
 package classifier
 
-import log "github.com/sirupsen/logrus"
+import (
+	log "github.com/sirupsen/logrus"
+	"sort"
+	"strings"
+)
 
 type ClassifierManifest struct {
-	races       map[string]Race
-	castes      map[string]Caste
-	professions map[string]Profession
+	declared    []Dimension
+	classifiers map[string]map[string]Classifier
+	keys        map[string][]string
+	origins     map[string]map[string][]Origin
+}
 
-	raceKeys       []string
-	casteKeys      []string
-	professionKeys []string
+// Origin records one change made to a classifier while loading data.
+type Origin struct {
+	Source string `json:"source" yaml:"source"`
+	Action string `json:"action" yaml:"action"`
 }
 
+const (
+	ActionAdd    = "add"
+	ActionPatch  = "patch"
+	ActionRemove = "remove"
+)
+
 func NewManifest() *ClassifierManifest {
 	return &ClassifierManifest{
-		races:       make(map[string]Race),
-		castes:      make(map[string]Caste),
-		professions: make(map[string]Profession),
+		declared:    BuiltinDimensions(),
+		classifiers: make(map[string]map[string]Classifier),
+		keys:        make(map[string][]string),
+		origins:     make(map[string]map[string][]Origin),
+	}
+}
+
+// Dimensions returns the dimensions that take part in selection: the
+// built-in dimensions, plus any declared dimension with registered
+// classifiers.
+func (m *ClassifierManifest) Dimensions() []Dimension {
+	active := make([]Dimension, 0)
+	for _, d := range m.declared {
+		if builtin(d.Target) || len(m.classifiers[d.Target]) > 0 {
+			active = append(active, d)
+		}
+	}
+
+	return active
+}
+
+// Register adds a classifier to a dimension, replacing any existing
+// classifier with the same ID.
+func (m *ClassifierManifest) Register(target string, id string, c Classifier) {
+	d, known := m.Lookup(target)
+	if !known {
+		log.Warnf("Ignoring classifier %s for undeclared dimension: %s", id, target)
+		return
+	}
+
+	log.Infof("Registering %s: %s", kindTitle(d.Kind), id)
+	c.Id = id
+	if m.classifiers[d.Target] == nil {
+		m.classifiers[d.Target] = make(map[string]Classifier)
+	}
+	m.classifiers[d.Target][id] = c
+	delete(m.keys, d.Target)
+}
+
+// Remove drops a classifier from a dimension, reporting whether it existed.
+func (m *ClassifierManifest) Remove(target string, id string) bool {
+	if _, found := m.classifiers[target][id]; !found {
+		return false
+	}
+
+	log.Infof("Removing %s: %s", target, id)
+	delete(m.classifiers[target], id)
+	delete(m.origins[target], id)
+	delete(m.keys, target)
+
+	return true
+}
 
-		raceKeys:       make([]string, 0),
-		casteKeys:      make([]string, 0),
-		professionKeys: make([]string, 0),
+// Trace records where a change to a classifier came from.
+func (m *ClassifierManifest) Trace(target string, id string, origin Origin) {
+	if m.origins[target] == nil {
+		m.origins[target] = make(map[string][]Origin)
 	}
+	m.origins[target][id] = append(m.origins[target][id], origin)
+}
+
+// Origins lists the recorded changes to a classifier, oldest first.
+func (m *ClassifierManifest) Origins(target string, id string) []Origin {
+	return m.origins[target][id]
+}
+
+func (m *ClassifierManifest) Resolve(target string, id string) (*Classifier, bool) {
+	c, found := m.classifiers[target][id]
+
+	if found {
+		return &c, true
+	} else {
+		return nil, false
+	}
+}
+
+// All lists the IDs in a dimension by their declared order, then by ID. The
+// list is a copy, so callers may change it freely.
+func (m *ClassifierManifest) All(target string) []string {
+	if _, cached := m.keys[target]; !cached {
+		classifiers := m.classifiers[target]
+		keys := make([]string, 0, len(classifiers))
+		for id := range classifiers {
+			keys = append(keys, id)
+		}
+		sort.Slice(keys, func(i, j int) bool {
+			a, b := classifiers[keys[i]], classifiers[keys[j]]
+			if a.Order != b.Order {
+				return a.Order < b.Order
+			}
+			return keys[i] < keys[j]
+		})
+		m.keys[target] = keys
+	}
+
+	return append([]string{}, m.keys[target]...)
 }
 
 func (m *ClassifierManifest) RegisterRace(id string, r Race) {
-	log.Infof("Registering Race: %s", id)
-	m.races[id] = r
-	m.raceKeys = nil
+	m.Register(ConflictRaces, id, r.Classifier)
 }
 
 func (m *ClassifierManifest) RegisterCaste(id string, c Caste) {
-	log.Infof("Registering Caste: %s", id)
-	m.castes[id] = c
-	m.casteKeys = nil
+	m.Register(ConflictCastes, id, c.Classifier)
 }
 
 func (m *ClassifierManifest) RegisterProfession(id string, p Profession) {
-	log.Infof("Registering Profession: %s", id)
-	m.professions[id] = p
-	m.professionKeys = nil
+	m.Register(ConflictProfessions, id, p.Classifier)
 }
 
 func (m *ClassifierManifest) ResolveRace(id string) (*Race, bool) {
-	r, found := m.races[id]
+	c, found := m.Resolve(ConflictRaces, id)
 
 	if found {
-		return &r, true
+		return &Race{Classifier: *c}, true
 	} else {
 		return nil, false
 	}
 }
 
 func (m *ClassifierManifest) ResolveCaste(id string) (*Caste, bool) {
-	c, found := m.castes[id]
+	c, found := m.Resolve(ConflictCastes, id)
 
 	if found {
-		return &c, true
+		return &Caste{Classifier: *c}, true
 	} else {
 		return nil, false
 	}
 }
 
 func (m *ClassifierManifest) ResolveProfession(id string) (*Profession, bool) {
-	p, found := m.professions[id]
+	c, found := m.Resolve(ConflictProfessions, id)
 
 	if found {
-		return &p, true
+		return &Profession{Classifier: *c}, true
 	} else {
 		return nil, false
 	}
 }
 
 func (m *ClassifierManifest) AllRaces() []string {
-	if len(m.raceKeys) < 1 {
-		m.raceKeys = make([]string, len(m.races), len(m.races))
-		i := 0
-		for id := range m.races {
-			m.raceKeys[i] = id
-			i++
-		}
-	}
-
-	return m.raceKeys
+	return m.All(ConflictRaces)
 }
 
 func (m *ClassifierManifest) AllCastes() []string {
-	if len(m.casteKeys) < 1 {
-		m.casteKeys = make([]string, len(m.castes), len(m.castes))
-		i := 0
-		for id := range m.castes {
-			m.casteKeys[i] = id
-			i++
-		}
+	return m.All(ConflictCastes)
+}
+
+func (m *ClassifierManifest) AllProfessions() []string {
+	return m.All(ConflictProfessions)
+}
+
+func kindTitle(kind string) string {
+	if kind == "" {
+		return kind
 	}
 
-	return m.casteKeys
+	return strings.ToUpper(kind[:1]) + kind[1:]
 }
 
-func (m *ClassifierManifest) AllProfessions() []string {
-	if len(m.professionKeys) < 1 {
-		m.professionKeys = make([]string, len(m.professions), len(m.professions))
-		i := 0
-		for id := range m.professions {
-			m.professionKeys[i] = id
-			i++
+// Entry is a classifier together with the changes that produced it.
+type Entry struct {
+	Classifier
+	Id      string   `json:"id"`
+	Origins []Origin `json:"origins"`
+}
+
+// Effective lists every classifier in the manifest by dimension target, in
+// listing order, describing the result of loading the base data and every content pack.
+func (m *ClassifierManifest) Effective() map[string][]Entry {
+	effective := make(map[string][]Entry)
+
+	for _, d := range m.Dimensions() {
+		ids := m.All(d.Target)
+		entries := make([]Entry, 0, len(ids))
+		for _, id := range ids {
+			c, _ := m.Resolve(d.Target, id)
+			entries = append(entries, Entry{Classifier: *c, Id: id, Origins: m.Origins(d.Target, id)})
 		}
+		effective[d.Target] = entries
 	}
 
-	return m.professionKeys
+	return effective
 }
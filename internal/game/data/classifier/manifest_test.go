@@ -32,22 +32,22 @@ func TestManifestSuite(t *testing.T) {
 func (s *ManifestTestSuite) TestNewManifest() {
 	m := NewManifest()
 
-	s.Empty(m.races)
-	s.Empty(m.castes)
-	s.Empty(m.professions)
-	s.Empty(m.raceKeys)
-	s.Empty(m.casteKeys)
-	s.Empty(m.professionKeys)
+	s.Empty(m.classifiers[ConflictRaces])
+	s.Empty(m.classifiers[ConflictCastes])
+	s.Empty(m.classifiers[ConflictProfessions])
+	s.Empty(m.keys[ConflictRaces])
+	s.Empty(m.keys[ConflictCastes])
+	s.Empty(m.keys[ConflictProfessions])
 }
 
 func (s *ManifestTestSuite) TestRaceUsage() {
 	m := NewManifest()
 
-	s.Empty(m.races)
+	s.Empty(m.classifiers[ConflictRaces])
 	testRace1 := BlankRace()
 	testRace1.Name = "TEST1"
 	m.RegisterRace(testRace1.Name, testRace1)
-	s.Len(m.races, 1)
+	s.Len(m.classifiers[ConflictRaces], 1)
 
 	// Check the keys
 	s.Len(m.AllRaces(), 1)
@@ -56,13 +56,13 @@ func (s *ManifestTestSuite) TestRaceUsage() {
 	testRace2 := BlankRace()
 	testRace2.Name = "TEST2"
 	m.RegisterRace(testRace2.Name, testRace2)
-	s.Len(m.races, 2)
+	s.Len(m.classifiers[ConflictRaces], 2)
 
 	// Add a colliding key
 	testRace3 := BlankRace()
 	testRace3.Name = "TEST3"
 	m.RegisterRace(testRace2.Name, testRace3)
-	s.Len(m.races, 2)
+	s.Len(m.classifiers[ConflictRaces], 2)
 
 	// Check the keys. Make sure they are regenerated
 	s.Len(m.AllRaces(), 2)
@@ -71,6 +71,7 @@ func (s *ManifestTestSuite) TestRaceUsage() {
 	resolve1, found1 := m.ResolveRace(testRace1.Name)
 	s.True(found1)
 	s.Equal(testRace1.Name, resolve1.Name)
+	s.Equal(testRace1.Name, resolve1.Id)
 
 	// Check that resolving the colliding key returns the second value
 	resolve2, found2 := m.ResolveRace(testRace2.Name)
@@ -86,11 +87,11 @@ func (s *ManifestTestSuite) TestRaceUsage() {
 func (s *ManifestTestSuite) TestCasteUsage() {
 	m := NewManifest()
 
-	s.Empty(m.castes)
+	s.Empty(m.classifiers[ConflictCastes])
 	testCaste1 := BlankCaste()
 	testCaste1.Name = "TEST1"
 	m.RegisterCaste(testCaste1.Name, testCaste1)
-	s.Len(m.castes, 1)
+	s.Len(m.classifiers[ConflictCastes], 1)
 
 	// Check the keys
 	s.Len(m.AllCastes(), 1)
@@ -99,13 +100,13 @@ func (s *ManifestTestSuite) TestCasteUsage() {
 	testCaste2 := BlankCaste()
 	testCaste2.Name = "TEST2"
 	m.RegisterCaste(testCaste2.Name, testCaste2)
-	s.Len(m.castes, 2)
+	s.Len(m.classifiers[ConflictCastes], 2)
 
 	// Add a colliding key
 	testCaste3 := BlankCaste()
 	testCaste3.Name = "TEST3"
 	m.RegisterCaste(testCaste2.Name, testCaste3)
-	s.Len(m.castes, 2)
+	s.Len(m.classifiers[ConflictCastes], 2)
 
 	// Check the keys. Make sure they are regenerated
 	s.Len(m.AllCastes(), 2)
@@ -129,11 +130,11 @@ func (s *ManifestTestSuite) TestCasteUsage() {
 func (s *ManifestTestSuite) TestProfessionUsage() {
 	m := NewManifest()
 
-	s.Empty(m.professions)
+	s.Empty(m.classifiers[ConflictProfessions])
 	testJob1 := BlankProfession()
 	testJob1.Name = "TEST1"
 	m.RegisterProfession(testJob1.Name, testJob1)
-	s.Len(m.professions, 1)
+	s.Len(m.classifiers[ConflictProfessions], 1)
 
 	// Check the keys
 	s.Len(m.AllProfessions(), 1)
@@ -142,13 +143,13 @@ func (s *ManifestTestSuite) TestProfessionUsage() {
 	testJob2 := BlankProfession()
 	testJob2.Name = "TEST2"
 	m.RegisterProfession(testJob2.Name, testJob2)
-	s.Len(m.professions, 2)
+	s.Len(m.classifiers[ConflictProfessions], 2)
 
 	// Add a colliding key
 	testJob3 := BlankProfession()
 	testJob3.Name = "TEST3"
 	m.RegisterProfession(testJob2.Name, testJob3)
-	s.Len(m.professions, 2)
+	s.Len(m.classifiers[ConflictProfessions], 2)
 
 	// Check the keys. Make sure they are regenerated
 	s.Len(m.AllProfessions(), 2)
@@ -168,3 +169,32 @@ func (s *ManifestTestSuite) TestProfessionUsage() {
 	s.False(found3)
 	s.Nil(resolve3)
 }
+
+func (s *ManifestTestSuite) TestAll_Order() {
+	m := NewManifest()
+	for _, id := range []string{"Mason", "Farmer", "Miner", "Pirate"} {
+		m.RegisterProfession(id, BlankProfession())
+	}
+	s.Equal([]string{"Farmer", "Mason", "Miner", "Pirate"}, m.AllProfessions())
+
+	late := BlankProfession()
+	late.Order = 10
+	m.RegisterProfession("Farmer", late)
+	early := BlankProfession()
+	early.Order = -1
+	m.RegisterProfession("Pirate", early)
+
+	s.Equal([]string{"Pirate", "Mason", "Miner", "Farmer"}, m.AllProfessions())
+}
+
+func (s *ManifestTestSuite) TestAll_Copy() {
+	m := NewManifest()
+	m.RegisterCaste("Noble", BlankCaste())
+	m.RegisterCaste("Serf", BlankCaste())
+
+	castes := m.AllCastes()
+	castes[0] = "Changed"
+	_ = append(castes[:1], "Appended")
+
+	s.Equal([]string{"Noble", "Serf"}, m.AllCastes())
+}
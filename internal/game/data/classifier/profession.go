@@ -16,12 +16,6 @@
 
 package classifier
 
-import (
-	log "github.com/sirupsen/logrus"
-	"github.com/zpxio/heromanager/internal/game/util"
-	"gopkg.in/yaml.v2"
-)
-
 type Profession struct {
 	Classifier
 }
@@ -34,20 +28,6 @@ func BlankProfession() Profession {
 	return r
 }
 
-func LoadProfessions(gameDir string, casteFile string, manifest *ClassifierManifest) {
-	jobYaml, err := util.GameFileData(gameDir, casteFile)
-	if err != nil {
-		log.Errorf("yamlFile.Get err %v ", err)
-	}
-
-	professions := make(map[string]Profession)
-	err = yaml.Unmarshal(jobYaml, &professions)
-	if err != nil {
-		log.Errorf("failed to parse profession data: %s", err)
-	}
-
-	// Register the professions
-	for id, race := range professions {
-		manifest.RegisterProfession(id, race)
-	}
+func LoadProfessions(gameDir string, professionFile string, manifest *ClassifierManifest) error {
+	return LoadClassifiers(ConflictProfessions, gameDir, professionFile, manifest)
 }
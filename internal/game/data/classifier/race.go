@@ -16,12 +16,6 @@
 
 package classifier
 
-import (
-	log "github.com/sirupsen/logrus"
-	"github.com/zpxio/heromanager/internal/game/util"
-	"gopkg.in/yaml.v2"
-)
-
 type Race struct {
 	Classifier
 }
@@ -35,23 +29,5 @@ func BlankRace() Race {
 }
 
 func LoadRaces(gameDir string, raceFile string, manifest *ClassifierManifest) error {
-	raceYaml, err := util.GameFileData(gameDir, raceFile)
-	if err != nil {
-		log.Errorf("yamlFile.Get err %v ", err)
-		return err
-	}
-
-	races := make(map[string]Race)
-	err = yaml.Unmarshal(raceYaml, &races)
-	if err != nil {
-		log.Errorf("failed to parse race data: %s", err)
-		return err
-	}
-
-	// Register the races
-	for id, race := range races {
-		manifest.RegisterRace(id, race)
-	}
-
-	return nil
+	return LoadClassifiers(ConflictRaces, gameDir, raceFile, manifest)
 }
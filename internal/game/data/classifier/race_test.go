@@ -70,3 +70,11 @@ func (t *RaceTestSuite) TestLoadRaces_BadFormat() {
 	t.NotNil(err)
 	t.Len(manifest.AllRaces(), 0)
 }
+
+func (t *RaceTestSuite) TestLoadRaces_Ordered() {
+	manifest := NewManifest()
+	err := LoadRaces("testdata/game/data/race", "test_race_ordered.yml", manifest)
+
+	t.Require().Nil(err)
+	t.Equal([]string{"Gnome", "Human", "Dwarf", "Elf"}, manifest.AllRaces())
+}
@@ -0,0 +1,199 @@
+//------------------------------------------------------------------------------
+//    Copyright 2019 Jeff Sharpe (zeropointx.io)
+//
+//    Licensed under the Apache License, Version 2.0 (the "License");
+//    you may not use this file except in compliance with the License.
+//    You may obtain a copy of the License at
+//
+//        http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS,
+//    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//    See the License for the specific language governing permissions and
+//    limitations under the License.
+//------------------------------------------------------------------------------
+
+package classifier
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/zpxio/heromanager/internal/game/data/schema"
+	"strings"
+)
+
+// Requirements are positive constraints on the other classifiers chosen
+// alongside a classifier. Each group lists IDs by conflict target, like a
+// ConflictGroup.
+type Requirements struct {
+	// AllOf IDs must all be selected.
+	AllOf ConflictGroup `yaml:"all"`
+	// AnyOf requires at least one listed ID to be selected, if any are listed.
+	AnyOf ConflictGroup `yaml:"any"`
+	// NoneOf IDs must not be selected.
+	NoneOf ConflictGroup `yaml:"none"`
+}
+
+const (
+	RequireAll  = "all"
+	RequireAny  = "any"
+	RequireNone = "none"
+)
+
+func EmptyRequirements() Requirements {
+	return Requirements{
+		AllOf:  EmptyConflicts(),
+		AnyOf:  EmptyConflicts(),
+		NoneOf: EmptyConflicts(),
+	}
+}
+
+func (r *Requirements) Empty() bool {
+	return r.AllOf.Empty() && r.AnyOf.Empty() && r.NoneOf.Empty()
+}
+
+// Satisfied checks the requirements against a selection of IDs keyed by
+// conflict target, together with the tags carried by the selection.
+func (r *Requirements) Satisfied(selected map[string]string, tags map[string]bool) bool {
+	anyMatched := r.AnyOf.Empty()
+
+	for _, tag := range r.AllOf.Ids(ConflictTags) {
+		if !tags[tag] {
+			return false
+		}
+	}
+
+	for tag := range tags {
+		if r.NoneOf.Contains(ConflictTags, tag) {
+			return false
+		}
+		if r.AnyOf.Contains(ConflictTags, tag) {
+			anyMatched = true
+		}
+	}
+
+	for _, target := range r.AllOf.Targets() {
+		if target == ConflictTags {
+			continue
+		}
+		for _, required := range r.AllOf.Ids(target) {
+			if id, chosen := selected[target]; !chosen || id != required {
+				return false
+			}
+		}
+	}
+
+	for target, id := range selected {
+		if r.NoneOf.Contains(target, id) {
+			return false
+		}
+
+		if r.AnyOf.Contains(target, id) {
+			anyMatched = true
+		}
+	}
+
+	return anyMatched
+}
+
+func (r *Requirements) Merge(other Requirements) {
+	r.AllOf.Merge(other.AllOf)
+	r.AnyOf.Merge(other.AnyOf)
+	r.NoneOf.Merge(other.NoneOf)
+}
+
+// Describe summarises the requirements for explanations and error messages.
+func (r *Requirements) Describe() string {
+	parts := make([]string, 0, 3)
+
+	describe := func(label string, group *ConflictGroup) {
+		entries := make([]string, 0)
+		for _, target := range group.Targets() {
+			for _, id := range group.Ids(target) {
+				entries = append(entries, target+":"+id)
+			}
+		}
+		if len(entries) > 0 {
+			parts = append(parts, fmt.Sprintf("%s of [%s]", label, strings.Join(entries, ", ")))
+		}
+	}
+
+	describe("all", &r.AllOf)
+	describe("any", &r.AnyOf)
+	describe("none", &r.NoneOf)
+
+	return strings.Join(parts, ", ")
+}
+
+func (r *Requirements) load(data map[string]map[string][]string) error {
+	*r = EmptyRequirements()
+
+	for kind, ids := range data {
+		switch kind {
+		case RequireAll:
+			r.AllOf.Load(ids)
+		case RequireAny:
+			r.AnyOf.Load(ids)
+		case RequireNone:
+			r.NoneOf.Load(ids)
+		default:
+			return fmt.Errorf("unknown requirement kind: %s", kind)
+		}
+	}
+
+	return nil
+}
+
+func (r Requirements) listing() map[string]map[string][]string {
+	listing := make(map[string]map[string][]string)
+	for kind, group := range map[string]ConflictGroup{RequireAll: r.AllOf, RequireAny: r.AnyOf, RequireNone: r.NoneOf} {
+		if !group.Empty() {
+			listing[kind] = group.listing()
+		}
+	}
+
+	return listing
+}
+
+func (r Requirements) MarshalJSON() ([]byte, error) {
+	return json.Marshal(r.listing())
+}
+
+func (r Requirements) MarshalYAML() (interface{}, error) {
+	return r.listing(), nil
+}
+
+func (r Requirements) JSONSchema() *schema.Schema {
+	return requirementsSchema(NewManifest().ReferenceTargets())
+}
+
+func requirementsSchema(targets []string) *schema.Schema {
+	group := conflictSchema(targets)
+
+	return schema.Object(map[string]*schema.Schema{
+		RequireAll:  group,
+		RequireAny:  group,
+		RequireNone: group,
+	})
+}
+
+func (r *Requirements) UnmarshalJSON(data []byte) error {
+	loaded := map[string]map[string][]string{}
+	err := json.Unmarshal(data, &loaded)
+	if err != nil {
+		return err
+	}
+
+	return r.load(loaded)
+}
+
+func (r *Requirements) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	loaded := map[string]map[string][]string{}
+	err := unmarshal(&loaded)
+	if err != nil {
+		return err
+	}
+
+	return r.load(loaded)
+}
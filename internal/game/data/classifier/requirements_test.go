@@ -0,0 +1,137 @@
+//------------------------------------------------------------------------------
+//    Copyright 2019 Jeff Sharpe (zeropointx.io)
+//
+//    Licensed under the Apache License, Version 2.0 (the "License");
+//    you may not use this file except in compliance with the License.
+//    You may obtain a copy of the License at
+//
+//        http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS,
+//    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//    See the License for the specific language governing permissions and
+//    limitations under the License.
+//------------------------------------------------------------------------------
+
+package classifier
+
+import (
+	"github.com/stretchr/testify/suite"
+	"github.com/zpxio/heromanager/internal/game/util"
+	"gopkg.in/yaml.v2"
+	"testing"
+)
+
+type RequirementsTestSuite struct {
+	suite.Suite
+}
+
+func TestRequirementsSuite(t *testing.T) {
+	suite.Run(t, new(RequirementsTestSuite))
+}
+
+func selection(race string, caste string, profession string) map[string]string {
+	return map[string]string{ConflictRaces: race, ConflictCastes: caste, ConflictProfessions: profession}
+}
+
+func (s *RequirementsTestSuite) TestEmptyRequirements() {
+	r := EmptyRequirements()
+
+	s.True(r.Empty())
+	s.True(r.Satisfied(selection("Dwarf", "Noble", "Miner"), nil))
+	s.Equal("", r.Describe())
+}
+
+func (s *RequirementsTestSuite) TestSatisfied_AllOf() {
+	r := EmptyRequirements()
+	r.AllOf.Add(ConflictCastes, "Noble")
+
+	s.True(r.Satisfied(selection("Dwarf", "Noble", "Miner"), nil))
+	s.False(r.Satisfied(selection("Dwarf", "Serf", "Miner"), nil))
+	s.False(r.Satisfied(map[string]string{ConflictRaces: "Dwarf"}, nil))
+}
+
+func (s *RequirementsTestSuite) TestSatisfied_AnyOf() {
+	r := EmptyRequirements()
+	r.AnyOf.Add(ConflictRaces, "Dwarf")
+	r.AnyOf.Add(ConflictRaces, "Gnome")
+
+	s.True(r.Satisfied(selection("Dwarf", "Noble", "Miner"), nil))
+	s.True(r.Satisfied(selection("Gnome", "Noble", "Miner"), nil))
+	s.False(r.Satisfied(selection("Elf", "Noble", "Miner"), nil))
+}
+
+func (s *RequirementsTestSuite) TestSatisfied_AnyOfMixedTargets() {
+	r := EmptyRequirements()
+	r.AnyOf.Add(ConflictRaces, "Dwarf")
+	r.AnyOf.Add(ConflictCastes, "Noble")
+
+	s.True(r.Satisfied(selection("Elf", "Noble", "Miner"), nil))
+	s.True(r.Satisfied(selection("Dwarf", "Serf", "Miner"), nil))
+	s.False(r.Satisfied(selection("Elf", "Serf", "Miner"), nil))
+}
+
+func (s *RequirementsTestSuite) TestSatisfied_NoneOf() {
+	r := EmptyRequirements()
+	r.NoneOf.Add(ConflictProfessions, "Pirate")
+
+	s.True(r.Satisfied(selection("Dwarf", "Noble", "Miner"), nil))
+	s.False(r.Satisfied(selection("Dwarf", "Noble", "Pirate"), nil))
+}
+
+func (s *RequirementsTestSuite) TestSatisfied_Tags() {
+	r := EmptyRequirements()
+	r.AllOf.Add(ConflictTags, "martial")
+	r.NoneOf.Add(ConflictTags, "undead")
+
+	sel := selection("Dwarf", "Noble", "Miner")
+
+	s.True(r.Satisfied(sel, map[string]bool{"martial": true}))
+	s.False(r.Satisfied(sel, map[string]bool{}))
+	s.False(r.Satisfied(sel, map[string]bool{"martial": true, "undead": true}))
+}
+
+func (s *RequirementsTestSuite) TestSatisfied_AnyOfTags() {
+	r := EmptyRequirements()
+	r.AnyOf.Add(ConflictTags, "clergy")
+	r.AnyOf.Add(ConflictRaces, "Elf")
+
+	s.True(r.Satisfied(selection("Dwarf", "Noble", "Priest"), map[string]bool{"clergy": true}))
+	s.True(r.Satisfied(selection("Elf", "Noble", "Miner"), map[string]bool{}))
+	s.False(r.Satisfied(selection("Dwarf", "Noble", "Miner"), map[string]bool{"martial": true}))
+}
+
+func (s *RequirementsTestSuite) TestDescribe() {
+	r := EmptyRequirements()
+	r.AllOf.Add(ConflictCastes, "Noble")
+	r.AnyOf.Add(ConflictRaces, "Dwarf")
+	r.AnyOf.Add(ConflictRaces, "Gnome")
+
+	s.Equal("all of [castes:Noble], any of [races:Dwarf, races:Gnome]", r.Describe())
+}
+
+func (s *RequirementsTestSuite) TestUnmarshalYAML() {
+	manifest := NewManifest()
+	LoadProfessions("testdata/game/data/profession", "test_profession_requires.yml", manifest)
+
+	p, found := manifest.ResolveProfession("Paladin")
+	s.Require().True(found)
+	s.True(p.Requires.AllOf.Contains(ConflictCastes, "Noble"))
+	s.Equal([]string{"Dwarf", "Human"}, p.Requires.AnyOf.Ids(ConflictRaces))
+	s.True(p.Requires.NoneOf.Contains(ConflictRaces, "Orc"))
+
+	t, found := manifest.ResolveProfession("Trader")
+	s.Require().True(found)
+	s.True(t.Requires.Empty())
+}
+
+func (s *RequirementsTestSuite) TestUnmarshalYAML_Malformed() {
+	r := EmptyRequirements()
+
+	data, dataErr := util.GameFileData("testdata/game/data/classifier", "test_conflict_simple.yml")
+	s.Require().Nil(dataErr)
+
+	err := yaml.Unmarshal(data, &r)
+	s.NotNil(err)
+}
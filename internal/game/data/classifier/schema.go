@@ -0,0 +1,69 @@
+//------------------------------------------------------------------------------
+//    Copyright 2019 Jeff Sharpe (zeropointx.io)
+//
+//    Licensed under the Apache License, Version 2.0 (the "License");
+//    you may not use this file except in compliance with the License.
+//    You may obtain a copy of the License at
+//
+//        http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS,
+//    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//    See the License for the specific language governing permissions and
+//    limitations under the License.
+//------------------------------------------------------------------------------
+
+package classifier
+
+import (
+	"fmt"
+	"github.com/zpxio/heromanager/internal/game/data/damage"
+	"github.com/zpxio/heromanager/internal/game/data/schema"
+)
+
+// JSONSchema describes a single classifier entry against the built-in
+// dimensions. Resistances are limited to the known damage types.
+func (c Classifier) JSONSchema() *schema.Schema {
+	return classifierSchema(NewManifest().Targets())
+}
+
+// classifierSchema describes a classifier whose conflict, requirement and
+// weight targets are limited to the given dimension targets.
+func classifierSchema(targets []string) *schema.Schema {
+	references := append(append([]string{}, targets...), ConflictTags)
+
+	s := schema.Of(classifierData(Initialize()))
+	s.Properties["weight"].Minimum = new(float64)
+	s.Properties["weights"].PropertyNames = schema.Enum(targets...)
+	s.Properties["resistances"].PropertyNames = schema.Enum(damage.Types()...)
+	s.Properties["conflicts"] = conflictSchema(references)
+	s.Properties["rivals"] = conflictSchema(references)
+	s.Properties["requires"] = requirementsSchema(references)
+
+	return s
+}
+
+// FileSchema describes a data file of classifiers for a dimension, keyed by
+// ID, allowing references to any dimension declared in the manifest.
+func FileSchema(d Dimension, m *ClassifierManifest) *schema.Schema {
+	return schema.Document(d.Target, fmt.Sprintf("Defines %s classifiers, keyed by ID.", d.Kind),
+		schema.MapOf(classifierSchema(m.Targets())))
+}
+
+// LayerSchema describes a content pack layer file for a dimension.
+func LayerSchema(d Dimension, m *ClassifierManifest) *schema.Schema {
+	s := schema.Of(Layer{})
+	s.Properties["add"] = schema.MapOf(classifierSchema(m.Targets()))
+	s.Properties["patch"] = schema.MapOf(classifierSchema(m.Targets()))
+
+	return schema.Document(d.Target+" layer", fmt.Sprintf("Adds, patches and removes %s classifiers.", d.Kind), s)
+}
+
+// DimensionsSchema describes a data file declaring classifier dimensions.
+func DimensionsSchema() *schema.Schema {
+	s := schema.Of([]dimensionSpec{})
+	s.Items.Required = []string{"target", "kind"}
+
+	return schema.Document("dimensions", "Declares classifier dimensions beyond the built-in ones.", s)
+}
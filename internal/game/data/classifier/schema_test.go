@@ -0,0 +1,93 @@
+//------------------------------------------------------------------------------
+//    Copyright 2019 Jeff Sharpe (zeropointx.io)
+//
+//    Licensed under the Apache License, Version 2.0 (the "License");
+//    you may not use this file except in compliance with the License.
+//    You may obtain a copy of the License at
+//
+//        http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS,
+//    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//    See the License for the specific language governing permissions and
+//    limitations under the License.
+//------------------------------------------------------------------------------
+
+package classifier
+
+import (
+	"github.com/stretchr/testify/suite"
+	"github.com/zpxio/heromanager/internal/game/data/schema"
+	"github.com/zpxio/heromanager/internal/game/util"
+	"testing"
+)
+
+type SchemaTestSuite struct {
+	suite.Suite
+}
+
+func TestSchemaSuite(t *testing.T) {
+	suite.Run(t, new(SchemaTestSuite))
+}
+
+func (s *SchemaTestSuite) TestFileSchema() {
+	fileSchema := FileSchema(DimensionRaces, NewManifest())
+
+	s.Equal(schema.Draft, fileSchema.Draft)
+	entry := fileSchema.AdditionalProperties
+	s.Require().NotNil(entry)
+	s.True(entry.Closed)
+	s.Contains(entry.Properties, "attributes")
+	s.NotContains(entry.Properties, "id")
+	s.Contains(entry.Properties["attributes"].PropertyNames.Enum, "Brawn")
+	s.Contains(entry.Properties["conflicts"].PropertyNames.Enum, ConflictTags)
+	s.Contains(entry.Properties["rivals"].PropertyNames.Enum, ConflictCastes)
+	s.Contains(entry.Properties["resistances"].PropertyNames.Enum, "Fire")
+	s.NotEmpty(entry.Validate(map[interface{}]interface{}{"resistances": map[interface{}]interface{}{"Sonic": 0.5}}))
+}
+
+func (s *SchemaTestSuite) TestFileSchema_Data() {
+	for _, file := range []string{"test_race_all_simple.yml", "test_race_extends.yml", "test_race_tagged.yml"} {
+		data, err := util.GameFileData("testdata/game/data/race", file)
+		s.Require().Nil(err)
+
+		s.Nil(schema.ValidateData(file, FileSchema(DimensionRaces, NewManifest()), data), file)
+	}
+}
+
+func (s *SchemaTestSuite) TestLoadRaces_Invalid() {
+	m := NewManifest()
+	err := LoadRaces("testdata/game/data/race", "test_race_bad_keys.yml", m)
+
+	s.Require().NotNil(err)
+	invalid, ok := err.(*schema.ValidationError)
+	s.Require().True(ok)
+	s.Equal("test_race_bad_keys.yml", invalid.Source)
+	s.Len(invalid.Problems, 3)
+	s.Empty(m.AllRaces())
+}
+
+func (s *SchemaTestSuite) TestLayerSchema() {
+	layerSchema := LayerSchema(DimensionCastes, NewManifest())
+
+	s.ElementsMatch([]string{"add", "patch", "remove"}, keysOf(layerSchema.Properties))
+	s.Nil(schema.ValidateData("castes.yml", layerSchema, []byte("add:\n  Noble:\n    weight: 0.5\nremove: [Peasant]\n")))
+	s.NotNil(schema.ValidateData("castes.yml", layerSchema, []byte("replace:\n  Noble: {}\n")))
+}
+
+func (s *SchemaTestSuite) TestDimensionsSchema() {
+	dimensionsSchema := DimensionsSchema()
+
+	s.Nil(schema.ValidateData("dimensions.yml", dimensionsSchema, []byte("- target: faiths\n  kind: faith\n  file: faiths.yml\n")))
+	s.NotNil(schema.ValidateData("dimensions.yml", dimensionsSchema, []byte("- kind: faith\n")))
+}
+
+func keysOf(properties map[string]*schema.Schema) []string {
+	names := make([]string, 0, len(properties))
+	for name := range properties {
+		names = append(names, name)
+	}
+
+	return names
+}
@@ -0,0 +1,76 @@
+//------------------------------------------------------------------------------
+//    Copyright 2019 Jeff Sharpe (zeropointx.io)
+//
+//    Licensed under the Apache License, Version 2.0 (the "License");
+//    you may not use this file except in compliance with the License.
+//    You may obtain a copy of the License at
+//
+//        http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS,
+//    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//    See the License for the specific language governing permissions and
+//    limitations under the License.
+//------------------------------------------------------------------------------
+
+package classifier
+
+import "sort"
+
+// Tagged resolves a tag to the IDs of every classifier carrying it, keyed by
+// conflict target.
+func (m *ClassifierManifest) Tagged(tag string) map[string][]string {
+	tagged := make(map[string][]string)
+
+	for _, d := range m.Dimensions() {
+		for _, id := range m.All(d.Target) {
+			if m.classifiers[d.Target][id].HasTag(tag) {
+				tagged[d.Target] = append(tagged[d.Target], id)
+			}
+		}
+	}
+
+	for target := range tagged {
+		sort.Strings(tagged[target])
+	}
+
+	return tagged
+}
+
+// Tags returns every tag carried by a registered classifier.
+func (m *ClassifierManifest) Tags() []string {
+	set := make(map[string]bool)
+
+	for _, d := range m.Dimensions() {
+		for _, c := range m.classifiers[d.Target] {
+			for _, tag := range c.Tags {
+				set[tag] = true
+			}
+		}
+	}
+
+	tags := make([]string, 0, len(set))
+	for tag := range set {
+		tags = append(tags, tag)
+	}
+	sort.Strings(tags)
+
+	return tags
+}
+
+// TagsOf collects the tags carried by a selection of classifier IDs keyed by
+// conflict target.
+func (m *ClassifierManifest) TagsOf(selected map[string]string) map[string]bool {
+	tags := make(map[string]bool)
+
+	for target, id := range selected {
+		if c, found := m.Resolve(target, id); found {
+			for _, tag := range c.Tags {
+				tags[tag] = true
+			}
+		}
+	}
+
+	return tags
+}
@@ -0,0 +1,81 @@
+//------------------------------------------------------------------------------
+//    Copyright 2019 Jeff Sharpe (zeropointx.io)
+//
+//    Licensed under the Apache License, Version 2.0 (the "License");
+//    you may not use this file except in compliance with the License.
+//    You may obtain a copy of the License at
+//
+//        http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS,
+//    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//    See the License for the specific language governing permissions and
+//    limitations under the License.
+//------------------------------------------------------------------------------
+
+package classifier
+
+import (
+	"github.com/stretchr/testify/suite"
+	"testing"
+)
+
+type TagsTestSuite struct {
+	suite.Suite
+	manifest *ClassifierManifest
+}
+
+func TestTagsSuite(t *testing.T) {
+	s := new(TagsTestSuite)
+	s.manifest = NewManifest()
+	LoadRaces("testdata/game/data/race", "test_race_tagged.yml", s.manifest)
+
+	priest := BlankProfession()
+	priest.Tags = []string{"clergy"}
+	priest.Conflicts.Add(ConflictTags, "undead")
+	s.manifest.RegisterProfession("Priest", priest)
+
+	suite.Run(t, s)
+}
+
+func (s *TagsTestSuite) TestLoad() {
+	r, found := s.manifest.ResolveRace("Wight")
+	s.Require().True(found)
+	s.Equal([]string{"undead", "martial"}, r.Tags)
+	s.True(r.HasTag("martial"))
+	s.False(r.HasTag("clergy"))
+
+	d, found := s.manifest.ResolveRace("Dwarf")
+	s.Require().True(found)
+	s.False(d.Conflicts.AllowTags(r.Tags))
+}
+
+func (s *TagsTestSuite) TestTagged() {
+	s.Equal(map[string][]string{ConflictRaces: {"Ghoul", "Wight"}}, s.manifest.Tagged("undead"))
+	s.Equal(map[string][]string{ConflictProfessions: {"Priest"}}, s.manifest.Tagged("clergy"))
+	s.Empty(s.manifest.Tagged("missing"))
+}
+
+func (s *TagsTestSuite) TestTags() {
+	s.Equal([]string{"clergy", "martial", "undead"}, s.manifest.Tags())
+}
+
+func (s *TagsTestSuite) TestTagsOf() {
+	tags := s.manifest.TagsOf(map[string]string{ConflictRaces: "Wight", ConflictProfessions: "Priest"})
+
+	s.Equal(map[string]bool{"undead": true, "martial": true, "clergy": true}, tags)
+}
+
+func (s *TagsTestSuite) TestValidate_UnknownTag() {
+	s.Require().Nil(s.manifest.Validate())
+
+	m := NewManifest()
+	p := BlankProfession()
+	p.Conflicts.Add(ConflictTags, "undead")
+	m.RegisterProfession("Priest", p)
+
+	err := m.Validate()
+	s.Require().NotNil(err)
+	s.Contains(err.Error(), "profession Priest conflicts references unknown tags: undead")
+}
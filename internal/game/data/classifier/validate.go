@@ -0,0 +1,129 @@
+//------------------------------------------------------------------------------
+//    Copyright 2019 Jeff Sharpe (zeropointx.io)
+//
+//    Licensed under the Apache License, Version 2.0 (the "License");
+//    you may not use this file except in compliance with the License.
+//    You may obtain a copy of the License at
+//
+//        http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS,
+//    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//    See the License for the specific language governing permissions and
+//    limitations under the License.
+//------------------------------------------------------------------------------
+
+package classifier
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// ValidationError collects every problem found in a manifest.
+type ValidationError struct {
+	Problems []string
+}
+
+func (e *ValidationError) Error() string {
+	return fmt.Sprintf("invalid classifier manifest: %s", strings.Join(e.Problems, "; "))
+}
+
+func (m *ClassifierManifest) known(target string, id string) bool {
+	if target == ConflictTags {
+		return len(m.Tagged(id)) > 0
+	}
+
+	_, found := m.Resolve(target, id)
+	return found
+}
+
+// Validate checks that conflicts, requirements and rivals only reference registered
+// classifiers, that no requirements contradict themselves, and that no weight
+// or weight factor is negative.
+func (m *ClassifierManifest) Validate() error {
+	problems := make([]string, 0)
+
+	check := func(kind string, id string, c *Classifier) {
+		name := kind + " " + id
+
+		groups := map[string]*ConflictGroup{
+			"conflicts":     &c.Conflicts,
+			"requires.all":  &c.Requires.AllOf,
+			"requires.any":  &c.Requires.AnyOf,
+			"requires.none": &c.Requires.NoneOf,
+			"rivals":        &c.Rivals,
+		}
+		for _, label := range []string{"conflicts", "requires.all", "requires.any", "requires.none", "rivals"} {
+			for _, target := range groups[label].Targets() {
+				if _, declared := m.Lookup(target); !declared && target != ConflictTags {
+					problems = append(problems, fmt.Sprintf("%s %s references undeclared dimension: %s", name, label, target))
+					continue
+				}
+				for _, ref := range groups[label].Ids(target) {
+					if !m.known(target, ref) {
+						problems = append(problems, fmt.Sprintf("%s %s references unknown %s: %s", name, label, target, ref))
+					}
+				}
+			}
+		}
+
+		if c.Weight < 0.0 {
+			problems = append(problems, fmt.Sprintf("%s has a negative weight: %v", name, c.Weight))
+		}
+		for _, target := range sortedTargets(c.Weights) {
+			for _, ref := range sortedFactors(c.Weights[target]) {
+				if factor := c.Weights[target][ref]; factor < 0.0 {
+					problems = append(problems, fmt.Sprintf("%s weights %s %s by a negative factor: %v", name, target, ref, factor))
+				}
+			}
+		}
+
+		for _, target := range c.Requires.AllOf.Targets() {
+			required := c.Requires.AllOf.Ids(target)
+			if len(required) > 1 && target != ConflictTags {
+				problems = append(problems, fmt.Sprintf("%s requires all of several %s: %s", name, target, strings.Join(required, ", ")))
+			}
+			for _, ref := range required {
+				if c.Requires.NoneOf.Contains(target, ref) || c.Conflicts.Contains(target, ref) {
+					problems = append(problems, fmt.Sprintf("%s both requires and excludes %s: %s", name, target, ref))
+				}
+			}
+		}
+	}
+
+	for _, d := range m.Dimensions() {
+		for _, id := range m.All(d.Target) {
+			c, _ := m.Resolve(d.Target, id)
+			check(d.Kind, id, c)
+		}
+	}
+
+	if len(problems) > 0 {
+		return &ValidationError{Problems: problems}
+	}
+
+	return nil
+}
+
+func sortedTargets(weights map[string]map[string]float32) []string {
+	targets := make([]string, 0, len(weights))
+	for target := range weights {
+		targets = append(targets, target)
+	}
+	sort.Strings(targets)
+
+	return targets
+}
+
+func sortedFactors(factors map[string]float32) []string {
+	ids := make([]string, 0, len(factors))
+	for id := range factors {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	return ids
+}
@@ -0,0 +1,98 @@
+//------------------------------------------------------------------------------
+//    Copyright 2019 Jeff Sharpe (zeropointx.io)
+//
+//    Licensed under the Apache License, Version 2.0 (the "License");
+//    you may not use this file except in compliance with the License.
+//    You may obtain a copy of the License at
+//
+//        http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS,
+//    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//    See the License for the specific language governing permissions and
+//    limitations under the License.
+//------------------------------------------------------------------------------
+
+package classifier
+
+import (
+	"github.com/stretchr/testify/suite"
+	"testing"
+)
+
+type ValidateTestSuite struct {
+	suite.Suite
+}
+
+func TestValidateSuite(t *testing.T) {
+	suite.Run(t, new(ValidateTestSuite))
+}
+
+func (s *ValidateTestSuite) TestValidate_Empty() {
+	s.Nil(NewManifest().Validate())
+}
+
+func (s *ValidateTestSuite) TestValidate_Valid() {
+	m := NewManifest()
+
+	r := BlankRace()
+	r.Conflicts.Add(ConflictCastes, "Serf")
+	m.RegisterRace("Dwarf", r)
+	m.RegisterCaste("Serf", BlankCaste())
+	m.RegisterCaste("Noble", BlankCaste())
+
+	p := BlankProfession()
+	p.Requires.AllOf.Add(ConflictCastes, "Noble")
+	p.Requires.AnyOf.Add(ConflictRaces, "Dwarf")
+	m.RegisterProfession("Paladin", p)
+
+	s.Nil(m.Validate())
+}
+
+func (s *ValidateTestSuite) TestValidate_Problems() {
+	m := NewManifest()
+
+	r := BlankRace()
+	r.Conflicts.Add(ConflictCastes, "Missing")
+	m.RegisterRace("Dwarf", r)
+	m.RegisterCaste("Serf", BlankCaste())
+	m.RegisterCaste("Noble", BlankCaste())
+
+	p := BlankProfession()
+	p.Requires.AllOf.Add(ConflictCastes, "Noble")
+	p.Requires.AllOf.Add(ConflictCastes, "Serf")
+	p.Requires.NoneOf.Add(ConflictCastes, "Noble")
+	p.Requires.AnyOf.Add(ConflictRaces, "Gnome")
+	m.RegisterProfession("Paladin", p)
+
+	err := m.Validate()
+	s.Require().NotNil(err)
+
+	v, ok := err.(*ValidationError)
+	s.Require().True(ok)
+	s.Contains(v.Problems, "race Dwarf conflicts references unknown castes: Missing")
+	s.Contains(v.Problems, "profession Paladin requires.any references unknown races: Gnome")
+	s.Contains(v.Problems, "profession Paladin requires all of several castes: Noble, Serf")
+	s.Contains(v.Problems, "profession Paladin both requires and excludes castes: Noble")
+	s.Len(v.Problems, 4)
+}
+
+func (s *ValidateTestSuite) TestValidate_NegativeWeights() {
+	m := NewManifest()
+	s.Require().Nil(LoadRaces("testdata/game/data/race", "test_race_negative_weights.yml", m))
+
+	p := BlankProfession()
+	p.Weight = -1.0
+	m.RegisterProfession("Thief", p)
+
+	err := m.Validate()
+	s.Require().NotNil(err)
+
+	v, ok := err.(*ValidationError)
+	s.Require().True(ok)
+	s.Equal([]string{
+		"race Dwarf weights castes Noble by a negative factor: -0.5",
+		"profession Thief has a negative weight: -1",
+	}, v.Problems)
+}
@@ -0,0 +1,358 @@
+//------------------------------------------------------------------------------
+//    Copyright 2019 Jeff Sharpe (zeropointx.io)
+//
+//    Licensed under the Apache License, Version 2.0 (the "License");
+//    you may not use this file except in compliance with the License.
+//    You may obtain a copy of the License at
+//
+//        http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS,
+//    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//    See the License for the specific language governing permissions and
+//    limitations under the License.
+//------------------------------------------------------------------------------
+
+package content
+
+import (
+	"fmt"
+	log "github.com/sirupsen/logrus"
+	"github.com/zpxio/heromanager/internal/game/data/attributes"
+	"github.com/zpxio/heromanager/internal/game/data/classifier"
+	"github.com/zpxio/heromanager/internal/game/data/derived"
+	"github.com/zpxio/heromanager/internal/game/data/economy"
+	"github.com/zpxio/heromanager/internal/game/data/encounter"
+	"github.com/zpxio/heromanager/internal/game/data/enemy"
+	"github.com/zpxio/heromanager/internal/game/data/item"
+	"github.com/zpxio/heromanager/internal/game/data/loot"
+	"github.com/zpxio/heromanager/internal/game/data/progression"
+	"github.com/zpxio/heromanager/internal/game/data/quest"
+	"github.com/zpxio/heromanager/internal/game/data/recruitment"
+	"github.com/zpxio/heromanager/internal/game/data/rolling"
+	"github.com/zpxio/heromanager/internal/game/data/skills"
+	"github.com/zpxio/heromanager/internal/game/util"
+)
+
+const (
+	KindDerived     = "derived"
+	KindRolling     = "rolling"
+	KindProgression = "progression"
+	KindSkills      = "skills"
+	KindQuests      = "quests"
+	KindEnemies     = "enemies"
+	KindEncounters  = "encounters"
+	KindItems       = "items"
+	KindLoot        = "loot"
+	KindEconomy     = "economy"
+	KindRecruitment = "recruitment"
+)
+
+// Set is every piece of game data loaded from a game directory, after
+// applying its content packs.
+type Set struct {
+	Classifiers *classifier.ClassifierManifest
+	Derived     *derived.Definitions
+	Rolling     *rolling.Config
+	Progression *progression.Config
+	Skills      *skills.Definitions
+	Quests      *quest.Definitions
+	Enemies     *enemy.Definitions
+	Encounters  *encounter.Definitions
+	Items       *item.Definitions
+	Loot        *loot.Definitions
+	Economy     *economy.Config
+	Recruitment *recruitment.Config
+	Packs       []Pack
+}
+
+func NewSet() *Set {
+	return &Set{
+		Classifiers: classifier.NewManifest(),
+		Derived:     derived.NewDefinitions(),
+		Rolling:     rolling.NewConfig(),
+		Progression: progression.NewConfig(),
+		Skills:      skills.NewDefinitions(),
+		Quests:      quest.NewDefinitions(),
+		Enemies:     enemy.NewDefinitions(),
+		Encounters:  encounter.NewDefinitions(),
+		Items:       item.NewDefinitions(),
+		Loot:        loot.NewDefinitions(),
+		Economy:     economy.NewConfig(),
+		Recruitment: recruitment.NewConfig(),
+		Packs:       make([]Pack, 0),
+	}
+}
+
+// Load reads the base data in a game directory, layers every enabled content
+// pack over it in order, and validates the result.
+func Load(gameDir string) (*Set, error) {
+	log.Infof("Loading game data from: %s", gameDir)
+	set := NewSet()
+
+	err := classifier.LoadAllClassifiers(gameDir, set.Classifiers)
+	if err != nil {
+		return nil, err
+	}
+
+	files, err := util.KindFiles(gameDir, KindDerived)
+	if err != nil {
+		return nil, err
+	}
+	for _, file := range files {
+		err = derived.LoadDerived(gameDir, file, set.Derived)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	files, err = util.KindFiles(gameDir, KindRolling)
+	if err != nil {
+		return nil, err
+	}
+	for _, file := range files {
+		err = rolling.LoadRolling(gameDir, file, set.Rolling)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	files, err = util.KindFiles(gameDir, KindProgression)
+	if err != nil {
+		return nil, err
+	}
+	for _, file := range files {
+		err = progression.LoadProgression(gameDir, file, set.Progression)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	files, err = util.KindFiles(gameDir, KindSkills)
+	if err != nil {
+		return nil, err
+	}
+	for _, file := range files {
+		err = skills.LoadSkills(gameDir, file, set.Skills)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	files, err = util.KindFiles(gameDir, KindQuests)
+	if err != nil {
+		return nil, err
+	}
+	for _, file := range files {
+		err = quest.LoadQuests(gameDir, file, set.Quests)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	files, err = util.KindFiles(gameDir, KindEnemies)
+	if err != nil {
+		return nil, err
+	}
+	for _, file := range files {
+		err = enemy.LoadEnemies(gameDir, file, set.Enemies)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	files, err = util.KindFiles(gameDir, KindEncounters)
+	if err != nil {
+		return nil, err
+	}
+	for _, file := range files {
+		err = encounter.LoadEncounters(gameDir, file, set.Encounters)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	files, err = util.KindFiles(gameDir, KindItems)
+	if err != nil {
+		return nil, err
+	}
+	for _, file := range files {
+		err = item.LoadItems(gameDir, file, set.Items)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	files, err = util.KindFiles(gameDir, KindLoot)
+	if err != nil {
+		return nil, err
+	}
+	for _, file := range files {
+		err = loot.LoadLoot(gameDir, file, set.Loot)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	files, err = util.KindFiles(gameDir, KindEconomy)
+	if err != nil {
+		return nil, err
+	}
+	for _, file := range files {
+		err = economy.LoadEconomy(gameDir, file, set.Economy)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	files, err = util.KindFiles(gameDir, KindRecruitment)
+	if err != nil {
+		return nil, err
+	}
+	for _, file := range files {
+		err = recruitment.LoadRecruitment(gameDir, file, set.Recruitment)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	set.Packs, err = LoadPacks(gameDir)
+	if err != nil {
+		return nil, err
+	}
+	for _, p := range set.Packs {
+		err = p.Apply(gameDir, set)
+		if err != nil {
+			return nil, fmt.Errorf("pack %s: %s", p.Id, err)
+		}
+	}
+
+	err = set.Classifiers.ResolveInheritance()
+	if err != nil {
+		return nil, err
+	}
+
+	// Fill the key caches now, since a loaded set is shared between
+	// goroutines once the world swaps it in.
+	for _, d := range set.Classifiers.Declared() {
+		set.Classifiers.All(d.Target)
+	}
+	set.Derived.All()
+	set.Skills.Policy()
+	set.Quests.All()
+	set.Enemies.All()
+	set.Encounters.All()
+	set.Items.All()
+	set.Loot.All()
+
+	return set, set.Validate()
+}
+
+// Validate checks the loaded data as a whole.
+func (set *Set) Validate() error {
+	err := set.Classifiers.Validate()
+	if err != nil {
+		return err
+	}
+
+	err = set.Derived.Validate(attributes.Policy())
+	if err != nil {
+		return fmt.Errorf("invalid derived stats: %s", err)
+	}
+
+	err = set.Skills.Validate(attributes.Policy())
+	if err != nil {
+		return fmt.Errorf("invalid skills: %s", err)
+	}
+
+	// Classifiers may only grant known skills
+	for _, d := range set.Classifiers.Dimensions() {
+		for _, id := range set.Classifiers.All(d.Target) {
+			c, _ := set.Classifiers.Resolve(d.Target, id)
+			for skill := range c.Skills {
+				if _, found := set.Skills.Resolve(skill); !found {
+					return fmt.Errorf("%s %s grants unknown skill: %s", d.Kind, id, skill)
+				}
+			}
+		}
+	}
+
+	err = set.Quests.Validate(attributes.Policy(), set.Skills)
+	if err != nil {
+		return fmt.Errorf("invalid quests: %s", err)
+	}
+
+	err = set.Enemies.Validate(attributes.Policy())
+	if err != nil {
+		return fmt.Errorf("invalid enemies: %s", err)
+	}
+
+	err = set.Encounters.Validate(set.Enemies)
+	if err != nil {
+		return fmt.Errorf("invalid encounters: %s", err)
+	}
+
+	err = set.Items.Validate(set.Classifiers)
+	if err != nil {
+		return fmt.Errorf("invalid items: %s", err)
+	}
+
+	err = set.Loot.Validate(set.Items, set.Classifiers)
+	if err != nil {
+		return fmt.Errorf("invalid loot: %s", err)
+	}
+
+	// Quests may only name known encounter tables
+	for _, id := range set.Quests.All() {
+		q, _ := set.Quests.Resolve(id)
+		if _, found := set.Encounters.Resolve(q.Encounter); q.Encounter != "" && !found {
+			return fmt.Errorf("quest %s meets unknown encounter table: %s", id, q.Encounter)
+		}
+		if _, found := set.Loot.Resolve(q.Rewards.Loot); q.Rewards.Loot != "" && !found {
+			return fmt.Errorf("quest %s rewards unknown loot table: %s", id, q.Rewards.Loot)
+		}
+	}
+
+	// Enemies may only drop known loot tables
+	for _, id := range set.Enemies.All() {
+		e, _ := set.Enemies.Resolve(id)
+		if _, found := set.Loot.Resolve(e.Loot); e.Loot != "" && !found {
+			return fmt.Errorf("enemy %s drops unknown loot table: %s", id, e.Loot)
+		}
+	}
+
+	// Rolling strategies are optional, but must be complete when present
+	if set.Rolling.Default != "" || len(set.Rolling.Strategies) > 0 {
+		err = set.Rolling.Validate()
+		if err != nil {
+			return fmt.Errorf("invalid rolling strategies: %s", err)
+		}
+	}
+
+	// Progression is optional too
+	if !set.Progression.Empty() {
+		err = set.Progression.Validate()
+		if err != nil {
+			return fmt.Errorf("invalid progression: %s", err)
+		}
+	}
+
+	// So is the economy
+	if !set.Economy.Empty() {
+		err = set.Economy.Validate()
+		if err != nil {
+			return fmt.Errorf("invalid economy: %s", err)
+		}
+	}
+
+	// And recruitment
+	if !set.Recruitment.Empty() {
+		err = set.Recruitment.Validate(set.Classifiers, set.Rolling)
+		if err != nil {
+			return fmt.Errorf("invalid recruitment: %s", err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,149 @@
+//------------------------------------------------------------------------------
+//    Copyright 2019 Jeff Sharpe (zeropointx.io)
+//
+//    Licensed under the Apache License, Version 2.0 (the "License");
+//    you may not use this file except in compliance with the License.
+//    You may obtain a copy of the License at
+//
+//        http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS,
+//    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//    See the License for the specific language governing permissions and
+//    limitations under the License.
+//------------------------------------------------------------------------------
+
+package content
+
+import (
+	"github.com/stretchr/testify/suite"
+	"github.com/zpxio/heromanager/internal/game/data/attributes"
+	"github.com/zpxio/heromanager/internal/game/data/classifier"
+	"github.com/zpxio/heromanager/internal/game/data/damage"
+	"github.com/zpxio/heromanager/internal/game/data/encounter"
+	"github.com/zpxio/heromanager/internal/game/data/enemy"
+	"github.com/zpxio/heromanager/internal/game/data/quest"
+	"github.com/zpxio/heromanager/internal/game/data/skills"
+	"testing"
+)
+
+type ContentTestSuite struct {
+	suite.Suite
+}
+
+func TestContentSuite(t *testing.T) {
+	suite.Run(t, new(ContentTestSuite))
+}
+
+func (s *ContentTestSuite) TestLoadPacks() {
+	packs, err := LoadPacks("testdata/game/data/layered")
+
+	s.Require().Nil(err)
+	s.Require().Len(packs, 2)
+	s.Equal("seasonal", packs[0].Id)
+	s.Equal("Winter Festival", packs[0].Name)
+	s.Equal("packs/seasonal", packs[0].Dir)
+	s.Equal("testing", packs[1].Id)
+}
+
+func (s *ContentTestSuite) TestLoadPacks_None() {
+	packs, err := LoadPacks("testdata/game/data/tree")
+
+	s.Require().Nil(err)
+	s.Empty(packs)
+}
+
+func (s *ContentTestSuite) TestLoad() {
+	set, err := Load("testdata/game/data/layered")
+	s.Require().Nil(err)
+
+	m := set.Classifiers
+	s.ElementsMatch([]string{"Dwarf", "Elf", "Snowfolk"}, m.AllRaces())
+	s.ElementsMatch([]string{"Noble", "Serf", "Outcast"}, m.AllCastes())
+
+	dwarf, found := m.ResolveRace("Dwarf")
+	s.Require().True(found)
+	s.Equal("Dwarf", dwarf.Name)
+	s.Equal(float32(2.0), dwarf.Weight)
+	s.Equal([]string{"stout", "festive"}, dwarf.Tags)
+	s.InDelta(1.2, dwarf.Attributes.Factor(attributes.Brawn), 0.0001)
+	s.InDelta(1.08, dwarf.Attributes.Factor(attributes.Insight), 0.0001)
+	s.Equal([]classifier.Origin{
+		{Source: "races.yml", Action: classifier.ActionAdd},
+		{Source: "seasonal/races.yml", Action: classifier.ActionPatch},
+	}, m.Origins(classifier.ConflictRaces, "Dwarf"))
+
+	snow, found := m.ResolveRace("Snowfolk")
+	s.Require().True(found)
+	s.Equal(float32(0.2), snow.Weight)
+	s.InDelta(1.12, snow.Attributes.Factor(attributes.Insight), 0.0001)
+
+	outcast, found := m.ResolveCaste("Outcast")
+	s.Require().True(found)
+	s.False(outcast.Conflicts.AllowRace("Snowfolk"))
+
+	initiative, found := set.Derived.Resolve("Initiative")
+	s.Require().True(found)
+	s.Equal("Initiative", initiative.Name)
+	s.Equal("Finesse * 3", initiative.Formula.String())
+	s.Equal([]string{"Health", "Initiative", "Warmth"}, set.Derived.All())
+
+	s.Equal("festive", set.Rolling.Default)
+	s.Len(set.Rolling.Strategies, 2)
+}
+
+func (s *ContentTestSuite) TestLoad_Effective() {
+	set, err := Load("testdata/game/data/layered")
+	s.Require().Nil(err)
+
+	races := set.Classifiers.Effective()[classifier.ConflictRaces]
+	s.Require().Len(races, 3)
+	s.Equal("Dwarf", races[0].Id)
+	s.Equal("Snowfolk", races[2].Id)
+	s.Equal([]classifier.Origin{{Source: "seasonal/races.yml", Action: classifier.ActionAdd}}, races[2].Origins)
+}
+
+func (s *ContentTestSuite) TestLoad_BadPack() {
+	set, err := Load("testdata/game/data/layered_bad")
+
+	s.Nil(set)
+	s.Require().NotNil(err)
+	s.Contains(err.Error(), "pack broken")
+	s.Contains(err.Error(), "cannot patch unknown races: Orc")
+}
+
+func (s *ContentTestSuite) TestValidate_UnknownSkill() {
+	set := NewSet()
+	set.Skills.Register("Smithing", skills.Skill{Name: "Smithing", Attributes: []string{attributes.Brawn}})
+
+	smith := classifier.BlankProfession()
+	smith.Skills["Smithing"] = 10.0
+	set.Classifiers.RegisterProfession("Smith", smith)
+	s.Nil(set.Validate())
+
+	juggler := classifier.BlankProfession()
+	juggler.Skills["Juggling"] = 10.0
+	set.Classifiers.RegisterProfession("Juggler", juggler)
+
+	err := set.Validate()
+	s.Require().NotNil(err)
+	s.Contains(err.Error(), "profession Juggler grants unknown skill: Juggling")
+}
+
+func (s *ContentTestSuite) TestValidate_UnknownEncounter() {
+	set := NewSet()
+	set.Quests.Register("Cellar", quest.Quest{Name: "Cellar", Duration: 1, Party: quest.Party{Min: 1, Max: 1}, Encounter: "Vermin"})
+
+	err := set.Validate()
+	s.Require().NotNil(err)
+	s.Contains(err.Error(), "quest Cellar meets unknown encounter table: Vermin")
+
+	set.Enemies.Register("Rat", enemy.Enemy{Name: "Rat", Weight: 1, Attacks: []damage.Attack{{Name: "Bite", Type: damage.Toxin, Damage: 1}}})
+	set.Encounters.Register("Vermin", encounter.Table{
+		Difficulty: encounter.Difficulty{Min: 1, Max: 2},
+		Size:       encounter.Size{Min: 1, Max: 1},
+		Enemies:    []encounter.Entry{{Enemy: "Rat"}},
+	})
+	s.Nil(set.Validate())
+}
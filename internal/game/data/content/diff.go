@@ -0,0 +1,262 @@
+//------------------------------------------------------------------------------
+//    Copyright 2019 Jeff Sharpe (zeropointx.io)
+//
+//    Licensed under the Apache License, Version 2.0 (the "License");
+//    you may not use this file except in compliance with the License.
+//    You may obtain a copy of the License at
+//
+//        http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS,
+//    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//    See the License for the specific language governing permissions and
+//    limitations under the License.
+//------------------------------------------------------------------------------
+
+package content
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/zpxio/heromanager/internal/game/data/classifier"
+	"github.com/zpxio/heromanager/internal/game/data/rolling"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// Changes lists the IDs added, removed and changed between two data sets.
+type Changes struct {
+	Added   []string `json:"added,omitempty"`
+	Removed []string `json:"removed,omitempty"`
+	Changed []string `json:"changed,omitempty"`
+}
+
+func (c Changes) Empty() bool {
+	return len(c.Added) == 0 && len(c.Removed) == 0 && len(c.Changed) == 0
+}
+
+func (c Changes) String() string {
+	return fmt.Sprintf("+%d -%d ~%d", len(c.Added), len(c.Removed), len(c.Changed))
+}
+
+// Diff describes what changed between two data sets.
+type Diff struct {
+	Classifiers    map[string]Changes `json:"classifiers"`
+	Derived        Changes            `json:"derived"`
+	Rolling        Changes            `json:"rolling"`
+	RollingDefault string             `json:"rollingDefault,omitempty"`
+	Progression    bool               `json:"progression,omitempty"`
+	Economy        bool               `json:"economy,omitempty"`
+	Recruitment    bool               `json:"recruitment,omitempty"`
+	Skills         Changes            `json:"skills"`
+	Quests         Changes            `json:"quests"`
+	Enemies        Changes            `json:"enemies"`
+	Encounters     Changes            `json:"encounters"`
+	Items          Changes            `json:"items"`
+	Loot           Changes            `json:"loot"`
+	Packs          Changes            `json:"packs"`
+}
+
+func (d Diff) Empty() bool {
+	for _, c := range d.Classifiers {
+		if !c.Empty() {
+			return false
+		}
+	}
+
+	return d.Derived.Empty() && d.Rolling.Empty() && d.RollingDefault == "" && !d.Progression && !d.Economy && !d.Recruitment && d.Skills.Empty() && d.Quests.Empty() &&
+		d.Enemies.Empty() && d.Encounters.Empty() && d.Items.Empty() && d.Loot.Empty() && d.Packs.Empty()
+}
+
+func (d Diff) String() string {
+	if d.Empty() {
+		return "no changes"
+	}
+
+	parts := make([]string, 0)
+	targets := make([]string, 0, len(d.Classifiers))
+	for target := range d.Classifiers {
+		targets = append(targets, target)
+	}
+	sort.Strings(targets)
+	for _, target := range targets {
+		parts = append(parts, fmt.Sprintf("%s %s", target, d.Classifiers[target]))
+	}
+	parts = append(parts, fmt.Sprintf("derived %s", d.Derived), fmt.Sprintf("rolling %s", d.Rolling), fmt.Sprintf("skills %s", d.Skills), fmt.Sprintf("quests %s", d.Quests),
+		fmt.Sprintf("enemies %s", d.Enemies), fmt.Sprintf("encounters %s", d.Encounters),
+		fmt.Sprintf("items %s", d.Items), fmt.Sprintf("loot %s", d.Loot), fmt.Sprintf("packs %s", d.Packs))
+	if d.RollingDefault != "" {
+		parts = append(parts, "rolling default "+d.RollingDefault)
+	}
+	if d.Progression {
+		parts = append(parts, "progression changed")
+	}
+	if d.Economy {
+		parts = append(parts, "economy changed")
+	}
+	if d.Recruitment {
+		parts = append(parts, "recruitment changed")
+	}
+
+	return strings.Join(parts, ", ")
+}
+
+// dimensions lists the dimensions declared in either manifest, those of the
+// first coming first.
+func dimensions(from *classifier.ClassifierManifest, to *classifier.ClassifierManifest) []classifier.Dimension {
+	all := from.Declared()
+	for _, d := range to.Declared() {
+		if _, declared := from.Lookup(d.Target); !declared {
+			all = append(all, d)
+		}
+	}
+
+	return all
+}
+
+// Compare reports the differences from one data set to the next.
+func Compare(from *Set, to *Set) Diff {
+	diff := Diff{Classifiers: make(map[string]Changes)}
+
+	for _, d := range dimensions(from.Classifiers, to.Classifiers) {
+		changes := compare(
+			from.Classifiers.All(d.Target),
+			to.Classifiers.All(d.Target),
+			func(id string) bool {
+				a, _ := from.Classifiers.Resolve(d.Target, id)
+				b, _ := to.Classifiers.Resolve(d.Target, id)
+				return sameJSON(a, b)
+			})
+		if !changes.Empty() {
+			diff.Classifiers[d.Target] = changes
+		}
+	}
+
+	diff.Derived = compare(from.Derived.All(), to.Derived.All(), func(id string) bool {
+		a, _ := from.Derived.Resolve(id)
+		b, _ := to.Derived.Resolve(id)
+		return a.Name == b.Name && a.Formula.String() == b.Formula.String()
+	})
+
+	diff.Rolling = compare(strategyNames(from.Rolling.Strategies), strategyNames(to.Rolling.Strategies), func(name string) bool {
+		return reflect.DeepEqual(from.Rolling.Strategies[name], to.Rolling.Strategies[name])
+	})
+	if from.Rolling.Default != to.Rolling.Default {
+		diff.RollingDefault = to.Rolling.Default
+	}
+
+	diff.Progression = !sameJSON(from.Progression, to.Progression)
+	diff.Economy = !sameJSON(from.Economy, to.Economy)
+	diff.Recruitment = !sameJSON(from.Recruitment, to.Recruitment)
+
+	diff.Skills = compare(from.Skills.All(), to.Skills.All(), func(id string) bool {
+		a, _ := from.Skills.Resolve(id)
+		b, _ := to.Skills.Resolve(id)
+		return reflect.DeepEqual(a, b)
+	})
+
+	diff.Quests = compare(from.Quests.All(), to.Quests.All(), func(id string) bool {
+		a, _ := from.Quests.Resolve(id)
+		b, _ := to.Quests.Resolve(id)
+		return reflect.DeepEqual(a, b)
+	})
+
+	diff.Enemies = compare(from.Enemies.All(), to.Enemies.All(), func(id string) bool {
+		a, _ := from.Enemies.Resolve(id)
+		b, _ := to.Enemies.Resolve(id)
+		return reflect.DeepEqual(a, b)
+	})
+
+	diff.Encounters = compare(from.Encounters.All(), to.Encounters.All(), func(id string) bool {
+		a, _ := from.Encounters.Resolve(id)
+		b, _ := to.Encounters.Resolve(id)
+		return reflect.DeepEqual(a, b)
+	})
+
+	diff.Items = compare(from.Items.All(), to.Items.All(), func(id string) bool {
+		a, _ := from.Items.Resolve(id)
+		b, _ := to.Items.Resolve(id)
+		return sameJSON(a, b)
+	})
+
+	diff.Loot = compare(from.Loot.All(), to.Loot.All(), func(id string) bool {
+		a, _ := from.Loot.Resolve(id)
+		b, _ := to.Loot.Resolve(id)
+		return reflect.DeepEqual(a, b)
+	})
+
+	diff.Packs = compare(packIds(from.Packs), packIds(to.Packs), func(id string) bool {
+		return reflect.DeepEqual(findPack(from.Packs, id), findPack(to.Packs, id))
+	})
+
+	return diff
+}
+
+func compare(from []string, to []string, same func(id string) bool) Changes {
+	changes := Changes{}
+
+	before := make(map[string]bool, len(from))
+	for _, id := range from {
+		before[id] = true
+	}
+	after := make(map[string]bool, len(to))
+	for _, id := range to {
+		after[id] = true
+	}
+
+	for _, id := range to {
+		if !before[id] {
+			changes.Added = append(changes.Added, id)
+		} else if !same(id) {
+			changes.Changed = append(changes.Changed, id)
+		}
+	}
+	for _, id := range from {
+		if !after[id] {
+			changes.Removed = append(changes.Removed, id)
+		}
+	}
+
+	sort.Strings(changes.Added)
+	sort.Strings(changes.Removed)
+	sort.Strings(changes.Changed)
+
+	return changes
+}
+
+func sameJSON(a interface{}, b interface{}) bool {
+	aData, aErr := json.Marshal(a)
+	bData, bErr := json.Marshal(b)
+
+	return aErr == nil && bErr == nil && string(aData) == string(bData)
+}
+
+func strategyNames(strategies map[string]rolling.Spec) []string {
+	names := make([]string, 0, len(strategies))
+	for name := range strategies {
+		names = append(names, name)
+	}
+
+	return names
+}
+
+func packIds(packs []Pack) []string {
+	ids := make([]string, len(packs))
+	for i, p := range packs {
+		ids[i] = p.Id
+	}
+
+	return ids
+}
+
+func findPack(packs []Pack, id string) *Pack {
+	for i := range packs {
+		if packs[i].Id == id {
+			return &packs[i]
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,78 @@
+//------------------------------------------------------------------------------
+//    Copyright 2019 Jeff Sharpe (zeropointx.io)
+//
+//    Licensed under the Apache License, Version 2.0 (the "License");
+//    you may not use this file except in compliance with the License.
+//    You may obtain a copy of the License at
+//
+//        http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS,
+//    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//    See the License for the specific language governing permissions and
+//    limitations under the License.
+//------------------------------------------------------------------------------
+
+package content
+
+import (
+	"github.com/stretchr/testify/suite"
+	"github.com/zpxio/heromanager/internal/game/data/classifier"
+	"github.com/zpxio/heromanager/internal/game/data/derived"
+	"github.com/zpxio/heromanager/internal/game/data/formula"
+	"testing"
+)
+
+type DiffTestSuite struct {
+	suite.Suite
+}
+
+func TestDiffSuite(t *testing.T) {
+	suite.Run(t, new(DiffTestSuite))
+}
+
+func (s *DiffTestSuite) TestCompare_Same() {
+	a, err := Load("testdata/game/data/layered")
+	s.Require().Nil(err)
+	b, err := Load("testdata/game/data/layered")
+	s.Require().Nil(err)
+
+	diff := Compare(a, b)
+	s.True(diff.Empty())
+	s.Equal("no changes", diff.String())
+}
+
+func (s *DiffTestSuite) TestCompare() {
+	from, err := Load("testdata/game/data/tree")
+	s.Require().Nil(err)
+	to, err := Load("testdata/game/data/layered")
+	s.Require().Nil(err)
+
+	diff := Compare(from, to)
+	s.False(diff.Empty())
+
+	s.Equal(Changes{
+		Added:   []string{"Snowfolk"},
+		Removed: []string{"Gnome", "Human"},
+		Changed: []string{"Dwarf", "Elf"},
+	}, diff.Classifiers[classifier.ConflictRaces])
+	s.Equal(Changes{Added: []string{"Outcast", "Serf"}}, diff.Classifiers[classifier.ConflictCastes])
+	s.Equal(Changes{Added: []string{"Health", "Initiative", "Warmth"}}, diff.Derived)
+	s.Equal(Changes{Added: []string{"dice", "festive"}}, diff.Rolling)
+	s.Equal("festive", diff.RollingDefault)
+	s.Equal(Changes{Added: []string{"seasonal", "testing"}}, diff.Packs)
+}
+
+func (s *DiffTestSuite) TestCompare_Derived() {
+	from := NewSet()
+	from.Derived.Register("Health", healthStat("Vigor"))
+	to := NewSet()
+	to.Derived.Register("Health", healthStat("Vigor * 2"))
+
+	s.Equal(Changes{Changed: []string{"Health"}}, Compare(from, to).Derived)
+}
+
+func healthStat(source string) derived.Stat {
+	return derived.Stat{Name: "Health", Formula: *formula.MustParse(source)}
+}
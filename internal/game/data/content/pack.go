@@ -0,0 +1,143 @@
+//------------------------------------------------------------------------------
+//    Copyright 2019 Jeff Sharpe (zeropointx.io)
+//
+//    Licensed under the Apache License, Version 2.0 (the "License");
+//    you may not use this file except in compliance with the License.
+//    You may obtain a copy of the License at
+//
+//        http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS,
+//    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//    See the License for the specific language governing permissions and
+//    limitations under the License.
+//------------------------------------------------------------------------------
+
+package content
+
+import (
+	"errors"
+	"fmt"
+	log "github.com/sirupsen/logrus"
+	"github.com/zpxio/heromanager/internal/game/data/classifier"
+	"github.com/zpxio/heromanager/internal/game/data/derived"
+	"github.com/zpxio/heromanager/internal/game/data/rolling"
+	"github.com/zpxio/heromanager/internal/game/data/schema"
+	"github.com/zpxio/heromanager/internal/game/util"
+	"gopkg.in/yaml.v2"
+	"io/fs"
+	"path/filepath"
+	"sort"
+)
+
+const (
+	PacksDir     = "packs"
+	PackManifest = "pack.yml"
+)
+
+// Pack is an optional layer of content applied over the base data. Each pack
+// lives in its own directory under packs/, described by a pack.yml manifest,
+// and mirrors the base data layout with files of add, patch and remove
+// sections.
+type Pack struct {
+	Id          string `yaml:"-" json:"id"`
+	Name        string `yaml:"name" json:"name"`
+	Description string `yaml:"description" json:"description,omitempty"`
+	Order       int    `yaml:"order" json:"order"`
+	Disabled    bool   `yaml:"disabled" json:"disabled,omitempty"`
+	Dir         string `yaml:"-" json:"dir"`
+}
+
+// LoadPacks reads the manifest of every enabled pack in a game directory,
+// ordered by their declared order and then by ID. A game directory without
+// packs has none.
+func LoadPacks(gameDir string) ([]Pack, error) {
+	packs := make([]Pack, 0)
+
+	entries, err := util.ReadDataDir(gameDir, PacksDir)
+	if errors.Is(err, fs.ErrNotExist) {
+		return packs, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+
+		dir := filepath.Join(PacksDir, entry.Name())
+		data, err := util.GameFileData(gameDir, filepath.Join(dir, PackManifest))
+		if err != nil {
+			return nil, fmt.Errorf("pack %s has no readable %s: %s", entry.Name(), PackManifest, err)
+		}
+
+		err = schema.ValidateData(filepath.Join(dir, PackManifest), PackSchema(), data)
+		if err != nil {
+			return nil, err
+		}
+
+		p := Pack{}
+		err = yaml.UnmarshalStrict(data, &p)
+		if err != nil {
+			return nil, fmt.Errorf("pack %s has an invalid %s: %s", entry.Name(), PackManifest, err)
+		}
+		p.Id = entry.Name()
+		p.Dir = dir
+
+		if p.Disabled {
+			log.Infof("Skipping disabled pack: %s", p.Id)
+			continue
+		}
+		packs = append(packs, p)
+	}
+
+	sort.SliceStable(packs, func(i, j int) bool {
+		if packs[i].Order != packs[j].Order {
+			return packs[i].Order < packs[j].Order
+		}
+		return packs[i].Id < packs[j].Id
+	})
+
+	return packs, nil
+}
+
+// Apply layers the pack over a set of content.
+func (p Pack) Apply(gameDir string, set *Set) error {
+	log.Infof("Applying pack: %s", p.Id)
+
+	packDir := filepath.Join(gameDir, p.Dir)
+
+	for _, d := range set.Classifiers.Declared() {
+		err := classifier.LoadLayers(d, packDir, p.Id, set.Classifiers)
+		if err != nil {
+			return err
+		}
+	}
+
+	files, err := util.KindFiles(packDir, KindDerived)
+	if err != nil {
+		return err
+	}
+	for _, file := range files {
+		err = derived.LoadLayer(packDir, file, p.Id, set.Derived)
+		if err != nil {
+			return err
+		}
+	}
+
+	files, err = util.KindFiles(packDir, KindRolling)
+	if err != nil {
+		return err
+	}
+	for _, file := range files {
+		err = rolling.LoadLayer(packDir, file, p.Id, set.Rolling)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,123 @@
+//------------------------------------------------------------------------------
+//    Copyright 2019 Jeff Sharpe (zeropointx.io)
+//
+//    Licensed under the Apache License, Version 2.0 (the "License");
+//    you may not use this file except in compliance with the License.
+//    You may obtain a copy of the License at
+//
+//        http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS,
+//    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//    See the License for the specific language governing permissions and
+//    limitations under the License.
+//------------------------------------------------------------------------------
+
+package content
+
+import (
+	"encoding/json"
+	"github.com/zpxio/heromanager/internal/game/data/attributes"
+	"github.com/zpxio/heromanager/internal/game/data/classifier"
+	"github.com/zpxio/heromanager/internal/game/data/derived"
+	"github.com/zpxio/heromanager/internal/game/data/economy"
+	"github.com/zpxio/heromanager/internal/game/data/encounter"
+	"github.com/zpxio/heromanager/internal/game/data/enemy"
+	"github.com/zpxio/heromanager/internal/game/data/item"
+	"github.com/zpxio/heromanager/internal/game/data/loot"
+	"github.com/zpxio/heromanager/internal/game/data/progression"
+	"github.com/zpxio/heromanager/internal/game/data/quest"
+	"github.com/zpxio/heromanager/internal/game/data/recruitment"
+	"github.com/zpxio/heromanager/internal/game/data/rolling"
+	"github.com/zpxio/heromanager/internal/game/data/schema"
+	"github.com/zpxio/heromanager/internal/game/data/skills"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+)
+
+// SchemaExtension names generated schema documents, such as
+// races.schema.json.
+const SchemaExtension = ".schema.json"
+
+// PackSchema describes a content pack manifest.
+func PackSchema() *schema.Schema {
+	return schema.Document("pack", "Describes a content pack layered over the base game data.", schema.Of(Pack{}))
+}
+
+// AttributesSchema describes a table of attribute modifiers, as used by the
+// attributes of every classifier.
+func AttributesSchema() *schema.Schema {
+	return schema.Document("attributes", "Adjusts attributes by a factor, keyed by attribute.",
+		attributes.NewAttributeModifier().JSONSchema())
+}
+
+// Schemas describes every kind of game data file, keyed by schema file name.
+// Classifier dimensions declared in the manifest are included.
+func Schemas(manifest *classifier.ClassifierManifest) map[string]*schema.Schema {
+	schemas := map[string]*schema.Schema{
+		"dimensions":           classifier.DimensionsSchema(),
+		KindDerived:            derived.FileSchema(),
+		KindDerived + ".layer": derived.LayerSchema(),
+		KindRolling:            rolling.FileSchema(),
+		KindRolling + ".layer": rolling.LayerSchema(),
+		KindProgression:        progression.FileSchema(),
+		KindSkills:             skills.FileSchema(),
+		KindQuests:             quest.FileSchema(),
+		KindEnemies:            enemy.FileSchema(),
+		KindEncounters:         encounter.FileSchema(),
+		KindItems:              item.FileSchema(),
+		KindLoot:               loot.FileSchema(),
+		KindEconomy:            economy.FileSchema(),
+		KindRecruitment:        recruitment.FileSchema(),
+		"pack":                 PackSchema(),
+		"attributes":           AttributesSchema(),
+	}
+	for _, d := range manifest.Declared() {
+		schemas[d.Target] = classifier.FileSchema(d, manifest)
+		schemas[d.Target+".layer"] = classifier.LayerSchema(d, manifest)
+	}
+
+	named := make(map[string]*schema.Schema, len(schemas))
+	for name, s := range schemas {
+		named[name+SchemaExtension] = s
+	}
+
+	return named
+}
+
+// WriteSchemas writes every schema document to a directory, returning the
+// files written.
+func WriteSchemas(targetDir string, manifest *classifier.ClassifierManifest) ([]string, error) {
+	schemas := Schemas(manifest)
+
+	names := make([]string, 0, len(schemas))
+	for name := range schemas {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	err := os.MkdirAll(targetDir, 0755)
+	if err != nil {
+		return nil, err
+	}
+
+	written := make([]string, 0, len(names))
+	for _, name := range names {
+		document, err := json.MarshalIndent(schemas[name], "", "  ")
+		if err != nil {
+			return written, err
+		}
+
+		target := filepath.Join(targetDir, name)
+		err = ioutil.WriteFile(target, append(document, '\n'), 0644)
+		if err != nil {
+			return written, err
+		}
+		written = append(written, target)
+	}
+
+	return written, nil
+}
@@ -0,0 +1,68 @@
+//------------------------------------------------------------------------------
+//    Copyright 2019 Jeff Sharpe (zeropointx.io)
+//
+//    Licensed under the Apache License, Version 2.0 (the "License");
+//    you may not use this file except in compliance with the License.
+//    You may obtain a copy of the License at
+//
+//        http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS,
+//    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//    See the License for the specific language governing permissions and
+//    limitations under the License.
+//------------------------------------------------------------------------------
+
+package content
+
+import (
+	"encoding/json"
+	"github.com/stretchr/testify/suite"
+	"github.com/zpxio/heromanager/internal/game/data/classifier"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+)
+
+type SchemaTestSuite struct {
+	suite.Suite
+}
+
+func TestSchemaSuite(t *testing.T) {
+	suite.Run(t, new(SchemaTestSuite))
+}
+
+func (s *SchemaTestSuite) TestSchemas() {
+	schemas := Schemas(classifier.NewManifest())
+
+	for _, name := range []string{"races", "castes", "professions", "dimensions", "derived", "rolling", "pack", "attributes"} {
+		s.Contains(schemas, name+SchemaExtension)
+	}
+	s.Contains(schemas, "races.layer"+SchemaExtension)
+	s.Contains(schemas, "rolling.layer"+SchemaExtension)
+}
+
+func (s *SchemaTestSuite) TestSchemas_Declared() {
+	m := classifier.NewManifest()
+	s.Require().Nil(m.Declare(classifier.Dimension{Target: "faiths", Kind: "faith"}))
+
+	s.Contains(Schemas(m), "faiths"+SchemaExtension)
+	s.NotContains(Schemas(classifier.NewManifest()), "faiths"+SchemaExtension)
+}
+
+func (s *SchemaTestSuite) TestWriteSchemas() {
+	dir := s.T().TempDir()
+
+	written, err := WriteSchemas(dir, classifier.NewManifest())
+	s.Require().Nil(err)
+	s.Len(written, len(Schemas(classifier.NewManifest())))
+
+	data, err := ioutil.ReadFile(filepath.Join(dir, "races"+SchemaExtension))
+	s.Require().Nil(err)
+
+	document := map[string]interface{}{}
+	s.Require().Nil(json.Unmarshal(data, &document))
+	s.Equal("races", document["title"])
+	s.Equal("object", document["type"])
+}
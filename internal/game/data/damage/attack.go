@@ -0,0 +1,53 @@
+//------------------------------------------------------------------------------
+//    Copyright 2019 Jeff Sharpe (zeropointx.io)
+//
+//    Licensed under the Apache License, Version 2.0 (the "License");
+//    you may not use this file except in compliance with the License.
+//    You may obtain a copy of the License at
+//
+//        http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS,
+//    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//    See the License for the specific language governing permissions and
+//    limitations under the License.
+//------------------------------------------------------------------------------
+
+package damage
+
+import (
+	"fmt"
+	"github.com/zpxio/heromanager/internal/game/data/schema"
+)
+
+// Attack deals an amount of damage of a single type.
+type Attack struct {
+	Name   string  `yaml:"name" json:"name"`
+	Type   string  `yaml:"type" json:"type"`
+	Damage float64 `yaml:"damage" json:"damage"`
+}
+
+// attackData has the layout of Attack without its schema method.
+type attackData Attack
+
+func (a Attack) Validate() error {
+	if !ValidType(a.Type) {
+		return fmt.Errorf("attack %s has unknown damage type: %s", a.Name, a.Type)
+	}
+	if a.Damage < 0 {
+		return fmt.Errorf("attack %s has negative damage: %v", a.Name, a.Damage)
+	}
+
+	return nil
+}
+
+// JSONSchema describes an attack, limiting it to the known damage types.
+func (a Attack) JSONSchema() *schema.Schema {
+	s := schema.Of(attackData{})
+	s.Properties["type"] = schema.Enum(types...)
+	s.Properties["damage"].Minimum = new(float64)
+	s.Required = []string{"name", "type", "damage"}
+
+	return s
+}
@@ -17,6 +17,7 @@
 package damage
 
 const (
+	Physical  string = "Physical"
 	Fire      string = "Fire"
 	Cold      string = "Cold"
 	Energy    string = "Energy"
@@ -26,3 +27,20 @@ const (
 	Toxin     string = "Toxin"
 	Alcohol   string = "Alcohol"
 )
+
+var types = []string{Physical, Fire, Cold, Energy, Corrosion, Soul, Light, Toxin, Alcohol}
+
+// Types lists every damage type.
+func Types() []string {
+	return append([]string{}, types...)
+}
+
+func ValidType(t string) bool {
+	for _, known := range types {
+		if known == t {
+			return true
+		}
+	}
+
+	return false
+}
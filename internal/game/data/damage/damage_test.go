@@ -0,0 +1,63 @@
+//------------------------------------------------------------------------------
+//    Copyright 2019 Jeff Sharpe (zeropointx.io)
+//
+//    Licensed under the Apache License, Version 2.0 (the "License");
+//    you may not use this file except in compliance with the License.
+//    You may obtain a copy of the License at
+//
+//        http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS,
+//    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//    See the License for the specific language governing permissions and
+//    limitations under the License.
+//------------------------------------------------------------------------------
+
+package damage
+
+import (
+	"github.com/stretchr/testify/suite"
+	"github.com/zpxio/heromanager/internal/game/data/table"
+	"testing"
+)
+
+type DamageTestSuite struct {
+	suite.Suite
+}
+
+func TestDamageSuite(t *testing.T) {
+	suite.Run(t, new(DamageTestSuite))
+}
+
+func (s *DamageTestSuite) TestValidType() {
+	s.True(ValidType(Physical))
+	s.True(ValidType(Alcohol))
+	s.False(ValidType("Sonic"))
+}
+
+func (s *DamageTestSuite) TestApply() {
+	r := NewResistances()
+	r.Set(Fire, 0.25)
+	r.Set(Toxin, -0.5)
+	r.Set(Cold, 3.0)
+
+	s.Equal(7.5, Apply(10, Fire, r))
+	s.Equal(15.0, Apply(10, Toxin, r))
+	s.Equal(0.0, Apply(10, Cold, r))
+	s.Equal(10.0, Apply(10, Physical, r))
+	s.Equal(10.0, Apply(10, Fire, table.Values{}))
+}
+
+func (s *DamageTestSuite) TestAttack_Validate() {
+	s.Nil(Attack{Name: "Bite", Type: Toxin, Damage: 3}.Validate())
+	s.NotNil(Attack{Name: "Shout", Type: "Sonic", Damage: 3}.Validate())
+	s.NotNil(Attack{Name: "Heal", Type: Light, Damage: -3}.Validate())
+}
+
+func (s *DamageTestSuite) TestAttack_JSONSchema() {
+	a := Attack{}.JSONSchema()
+
+	s.Empty(a.Validate(map[interface{}]interface{}{"name": "Bite", "type": "Toxin", "damage": 3}))
+	s.NotEmpty(a.Validate(map[interface{}]interface{}{"name": "Bite", "type": "Sonic", "damage": 3}))
+}
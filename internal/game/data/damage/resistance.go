@@ -0,0 +1,47 @@
+//------------------------------------------------------------------------------
+//    Copyright 2019 Jeff Sharpe (zeropointx.io)
+//
+//    Licensed under the Apache License, Version 2.0 (the "License");
+//    you may not use this file except in compliance with the License.
+//    You may obtain a copy of the License at
+//
+//        http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS,
+//    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//    See the License for the specific language governing permissions and
+//    limitations under the License.
+//------------------------------------------------------------------------------
+
+package damage
+
+import "github.com/zpxio/heromanager/internal/game/data/table"
+
+// Resistances reduce damage of a type by their share, so 0.25 takes a quarter
+// off, 1 grants immunity, and negative values are weaknesses.
+const (
+	MinResistance     float64 = -1.0
+	MaxResistance     float64 = 1.0
+	DefaultResistance float64 = 0.0
+)
+
+var policy = table.NewPolicy(MinResistance, MaxResistance, DefaultResistance, types)
+
+// Policy is the table policy for resistances, keyed by damage type.
+func Policy() *table.Policy {
+	return policy
+}
+
+func NewResistances() table.Values {
+	return table.NewValues(policy)
+}
+
+// Apply reduces an amount of damage of a type by the matching resistance.
+func Apply(amount float64, damageType string, resistances table.Values) float64 {
+	if resistances.Policy() == nil || !resistances.Policy().ValidKey(damageType) {
+		return amount
+	}
+
+	return amount * (1.0 - resistances.Get(damageType))
+}
@@ -0,0 +1,216 @@
+//------------------------------------------------------------------------------
+//    Copyright 2019 Jeff Sharpe (zeropointx.io)
+//
+//    Licensed under the Apache License, Version 2.0 (the "License");
+//    you may not use this file except in compliance with the License.
+//    You may obtain a copy of the License at
+//
+//        http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS,
+//    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//    See the License for the specific language governing permissions and
+//    limitations under the License.
+//------------------------------------------------------------------------------
+
+package derived
+
+import (
+	"fmt"
+	log "github.com/sirupsen/logrus"
+	"github.com/zpxio/heromanager/internal/game/data/formula"
+	"github.com/zpxio/heromanager/internal/game/data/schema"
+	"github.com/zpxio/heromanager/internal/game/data/table"
+	"github.com/zpxio/heromanager/internal/game/util"
+	"gopkg.in/yaml.v2"
+	"sort"
+)
+
+// Stat is a value computed from a table of attributes using a data-defined
+// formula, such as Health or Initiative.
+type Stat struct {
+	Name    string             `yaml:"name" json:"name"`
+	Formula formula.Expression `yaml:"formula" json:"formula"`
+}
+
+type Definitions struct {
+	stats    map[string]Stat
+	keys     []string
+	revision uint64
+}
+
+func NewDefinitions() *Definitions {
+	return &Definitions{
+		stats: make(map[string]Stat),
+		keys:  make([]string, 0),
+	}
+}
+
+func (d *Definitions) Register(id string, s Stat) {
+	log.Infof("Registering Derived Stat: %s", id)
+	d.stats[id] = s
+	d.keys = nil
+	d.revision++
+}
+
+// Remove drops a stat, reporting whether it existed.
+func (d *Definitions) Remove(id string) bool {
+	if _, found := d.stats[id]; !found {
+		return false
+	}
+
+	log.Infof("Removing Derived Stat: %s", id)
+	delete(d.stats, id)
+	d.keys = nil
+	d.revision++
+
+	return true
+}
+
+func (d *Definitions) Resolve(id string) (*Stat, bool) {
+	s, found := d.stats[id]
+
+	if found {
+		return &s, true
+	} else {
+		return nil, false
+	}
+}
+
+func (d *Definitions) All() []string {
+	if d.keys == nil {
+		d.keys = make([]string, 0, len(d.stats))
+		for id := range d.stats {
+			d.keys = append(d.keys, id)
+		}
+		sort.Strings(d.keys)
+	}
+
+	return d.keys
+}
+
+func (d *Definitions) Revision() uint64 {
+	return d.revision
+}
+
+// Validate ensures every formula only references keys valid for the policy.
+func (d *Definitions) Validate(policy *table.Policy) error {
+	for _, id := range d.All() {
+		s := d.stats[id]
+		for _, name := range s.Formula.Variables() {
+			if !policy.ValidKey(name) {
+				return fmt.Errorf("derived stat %s references unknown value: %s", id, name)
+			}
+		}
+	}
+
+	return nil
+}
+
+func LoadDerived(gameDir string, derivedFile string, definitions *Definitions) error {
+	derivedYaml, err := util.GameFileData(gameDir, derivedFile)
+	if err != nil {
+		log.Errorf("yamlFile.Get err %v ", err)
+		return err
+	}
+
+	err = schema.ValidateData(derivedFile, FileSchema(), derivedYaml)
+	if err != nil {
+		log.Errorf("invalid derived stat data: %s", err)
+		return err
+	}
+
+	stats := make(map[string]Stat)
+	err = yaml.Unmarshal(derivedYaml, &stats)
+	if err != nil {
+		log.Errorf("failed to parse derived stat data: %s", err)
+		return err
+	}
+
+	// Register the stats
+	for id, s := range stats {
+		definitions.Register(id, s)
+	}
+
+	return nil
+}
+
+// Layer is a set of changes to the derived stats, as shipped by a content
+// pack. A patch replaces the name or formula it declares.
+type Layer struct {
+	Add    map[string]Stat `yaml:"add" json:"add"`
+	Patch  map[string]Stat `yaml:"patch" json:"patch"`
+	Remove []string        `yaml:"remove" json:"remove"`
+}
+
+// Apply removes, adds and then patches stats. Adding an existing stat, or
+// patching or removing a missing one, is an error and leaves the definitions
+// unchanged.
+func (d *Definitions) Apply(source string, layer Layer) error {
+	exists := make(map[string]bool)
+	for _, id := range d.All() {
+		exists[id] = true
+	}
+
+	for _, id := range layer.Remove {
+		if !exists[id] {
+			return fmt.Errorf("%s cannot remove unknown derived stat: %s", source, id)
+		}
+		exists[id] = false
+	}
+	for id := range layer.Add {
+		if exists[id] {
+			return fmt.Errorf("%s cannot add existing derived stat: %s", source, id)
+		}
+		exists[id] = true
+	}
+	for id := range layer.Patch {
+		if !exists[id] {
+			return fmt.Errorf("%s cannot patch unknown derived stat: %s", source, id)
+		}
+	}
+
+	for _, id := range layer.Remove {
+		d.Remove(id)
+	}
+	for id, s := range layer.Add {
+		d.Register(id, s)
+	}
+	for id, patch := range layer.Patch {
+		s := d.stats[id]
+		if patch.Name != "" {
+			s.Name = patch.Name
+		}
+		if patch.Formula.String() != "" {
+			s.Formula = patch.Formula
+		}
+		d.Register(id, s)
+	}
+
+	return nil
+}
+
+// LoadLayer applies a derived stat layer file.
+func LoadLayer(gameDir string, layerFile string, source string, definitions *Definitions) error {
+	layerYaml, err := util.GameFileData(gameDir, layerFile)
+	if err != nil {
+		log.Errorf("yamlFile.Get err %v ", err)
+		return err
+	}
+
+	err = schema.ValidateData(source+"/"+layerFile, LayerSchema(), layerYaml)
+	if err != nil {
+		log.Errorf("invalid derived stat layer: %s", err)
+		return err
+	}
+
+	layer := Layer{}
+	err = yaml.UnmarshalStrict(layerYaml, &layer)
+	if err != nil {
+		log.Errorf("failed to parse derived stat layer: %s", err)
+		return err
+	}
+
+	return definitions.Apply(source+"/"+layerFile, layer)
+}
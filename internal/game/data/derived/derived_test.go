@@ -0,0 +1,100 @@
+//------------------------------------------------------------------------------
+//    Copyright 2019 Jeff Sharpe (zeropointx.io)
+//
+//    Licensed under the Apache License, Version 2.0 (the "License");
+//    you may not use this file except in compliance with the License.
+//    You may obtain a copy of the License at
+//
+//        http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS,
+//    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//    See the License for the specific language governing permissions and
+//    limitations under the License.
+//------------------------------------------------------------------------------
+
+package derived
+
+import (
+	"github.com/stretchr/testify/suite"
+	"github.com/zpxio/heromanager/internal/game/data/attributes"
+	"github.com/zpxio/heromanager/internal/game/data/formula"
+	"testing"
+)
+
+type DerivedTestSuite struct {
+	suite.Suite
+}
+
+func TestDerivedSuite(t *testing.T) {
+	suite.Run(t, new(DerivedTestSuite))
+}
+
+func (s *DerivedTestSuite) TestLoadDerived() {
+	d := NewDefinitions()
+	err := LoadDerived("testdata/game/data/derived", "test_derived_simple.yml", d)
+
+	s.Require().Nil(err)
+	s.Equal([]string{"Health", "Initiative"}, d.All())
+
+	health, found := d.Resolve("Health")
+	s.Require().True(found)
+	s.Equal("Vigor * 5 + Brawn", health.Formula.String())
+
+	s.Nil(d.Validate(attributes.Policy()))
+}
+
+func (s *DerivedTestSuite) TestLoadDerived_BadFormula() {
+	d := NewDefinitions()
+	err := LoadDerived("testdata/game/data/derived", "test_derived_bad.yml", d)
+
+	s.NotNil(err)
+	s.Empty(d.All())
+}
+
+func (s *DerivedTestSuite) TestValidate_UnknownAttribute() {
+	d := NewDefinitions()
+	err := LoadDerived("testdata/game/data/derived", "test_derived_unknown.yml", d)
+	s.Require().Nil(err)
+
+	s.NotNil(d.Validate(attributes.Policy()))
+}
+
+func (s *DerivedTestSuite) TestRegister_Revision() {
+	d := NewDefinitions()
+	r := d.Revision()
+
+	d.Register("Health", Stat{Name: "Health", Formula: *formula.MustParse("Vigor")})
+
+	s.NotEqual(r, d.Revision())
+	s.Len(d.All(), 1)
+}
+
+func (s *DerivedTestSuite) TestApply() {
+	d := NewDefinitions()
+	s.Require().Nil(LoadDerived("testdata/game/data/derived", "test_derived_simple.yml", d))
+
+	err := d.Apply("pack", Layer{
+		Add:    map[string]Stat{"Focus": {Name: "Focus", Formula: *formula.MustParse("Insight")}},
+		Patch:  map[string]Stat{"Health": {Formula: *formula.MustParse("Vigor * 6")}},
+		Remove: []string{"Initiative"},
+	})
+	s.Require().Nil(err)
+
+	s.Equal([]string{"Focus", "Health"}, d.All())
+	health, _ := d.Resolve("Health")
+	s.Equal("Health", health.Name)
+	s.Equal("Vigor * 6", health.Formula.String())
+}
+
+func (s *DerivedTestSuite) TestApply_Errors() {
+	d := NewDefinitions()
+	s.Require().Nil(LoadDerived("testdata/game/data/derived", "test_derived_simple.yml", d))
+	r := d.Revision()
+
+	s.NotNil(d.Apply("pack", Layer{Add: map[string]Stat{"Health": {}}}))
+	s.NotNil(d.Apply("pack", Layer{Patch: map[string]Stat{"Focus": {}}}))
+	s.NotNil(d.Apply("pack", Layer{Remove: []string{"Focus"}}))
+	s.Equal(r, d.Revision())
+}
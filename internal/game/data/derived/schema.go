@@ -0,0 +1,34 @@
+//------------------------------------------------------------------------------
+//    Copyright 2019 Jeff Sharpe (zeropointx.io)
+//
+//    Licensed under the Apache License, Version 2.0 (the "License");
+//    you may not use this file except in compliance with the License.
+//    You may obtain a copy of the License at
+//
+//        http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS,
+//    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//    See the License for the specific language governing permissions and
+//    limitations under the License.
+//------------------------------------------------------------------------------
+
+package derived
+
+import (
+	"github.com/zpxio/heromanager/internal/game/data/schema"
+)
+
+// FileSchema describes a derived stat data file, keyed by stat ID.
+func FileSchema() *schema.Schema {
+	stat := schema.Of(Stat{})
+	stat.Required = []string{"formula"}
+
+	return schema.Document("derived", "Defines stats computed from attributes, keyed by ID.", schema.MapOf(stat))
+}
+
+// LayerSchema describes a content pack layer file for derived stats.
+func LayerSchema() *schema.Schema {
+	return schema.Document("derived layer", "Adds, patches and removes derived stats.", schema.Of(Layer{}))
+}
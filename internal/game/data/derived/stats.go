@@ -0,0 +1,85 @@
+//------------------------------------------------------------------------------
+//    Copyright 2019 Jeff Sharpe (zeropointx.io)
+//
+//    Licensed under the Apache License, Version 2.0 (the "License");
+//    you may not use this file except in compliance with the License.
+//    You may obtain a copy of the License at
+//
+//        http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS,
+//    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//    See the License for the specific language governing permissions and
+//    limitations under the License.
+//------------------------------------------------------------------------------
+
+package derived
+
+import (
+	log "github.com/sirupsen/logrus"
+	"github.com/zpxio/heromanager/internal/game/data/table"
+)
+
+// Stats evaluates derived stats against a source table and caches the results
+// until either the source values or the definitions change.
+type Stats struct {
+	definitions *Definitions
+	source      *table.Values
+
+	valuesRevision     uint64
+	definitionRevision uint64
+	cached             map[string]float64
+}
+
+func NewStats(definitions *Definitions, source *table.Values) *Stats {
+	return &Stats{definitions: definitions, source: source}
+}
+
+func (s *Stats) stale() bool {
+	return s.cached == nil ||
+		s.valuesRevision != s.source.Revision() ||
+		s.definitionRevision != s.definitions.Revision()
+}
+
+func (s *Stats) recompute() {
+	s.cached = make(map[string]float64, len(s.definitions.All()))
+
+	lookup := func(name string) (float64, bool) {
+		return s.source.Get(name), true
+	}
+
+	for _, id := range s.definitions.All() {
+		stat, _ := s.definitions.Resolve(id)
+		value, err := stat.Formula.Evaluate(lookup)
+		if err != nil {
+			log.Warnf("failed to evaluate derived stat %s: %s", id, err)
+			value = 0.0
+		}
+		s.cached[id] = value
+	}
+
+	s.valuesRevision = s.source.Revision()
+	s.definitionRevision = s.definitions.Revision()
+}
+
+func (s *Stats) Get(id string) float64 {
+	if s.stale() {
+		s.recompute()
+	}
+
+	return s.cached[id]
+}
+
+func (s *Stats) All() map[string]float64 {
+	if s.stale() {
+		s.recompute()
+	}
+
+	all := make(map[string]float64, len(s.cached))
+	for id, value := range s.cached {
+		all[id] = value
+	}
+
+	return all
+}
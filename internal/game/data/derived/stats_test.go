@@ -0,0 +1,97 @@
+//------------------------------------------------------------------------------
+//    Copyright 2019 Jeff Sharpe (zeropointx.io)
+//
+//    Licensed under the Apache License, Version 2.0 (the "License");
+//    you may not use this file except in compliance with the License.
+//    You may obtain a copy of the License at
+//
+//        http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS,
+//    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//    See the License for the specific language governing permissions and
+//    limitations under the License.
+//------------------------------------------------------------------------------
+
+package derived
+
+import (
+	"github.com/stretchr/testify/suite"
+	"github.com/zpxio/heromanager/internal/game/data/attributes"
+	"github.com/zpxio/heromanager/internal/game/data/formula"
+	"testing"
+)
+
+type StatsTestSuite struct {
+	suite.Suite
+	definitions *Definitions
+}
+
+func TestStatsSuite(t *testing.T) {
+	s := new(StatsTestSuite)
+	s.definitions = NewDefinitions()
+	s.definitions.Register("Health", Stat{Name: "Health", Formula: *formula.MustParse("Vigor * 5 + Brawn")})
+	s.definitions.Register("Initiative", Stat{Name: "Initiative", Formula: *formula.MustParse("Finesse * 2")})
+
+	suite.Run(t, s)
+}
+
+func (s *StatsTestSuite) TestGet() {
+	v := attributes.NewAttributeValues()
+	v.Set(attributes.Vigor, 10)
+	v.Set(attributes.Brawn, 4)
+	v.Set(attributes.Finesse, 3)
+
+	stats := NewStats(s.definitions, &v)
+
+	s.Equal(54.0, stats.Get("Health"))
+	s.Equal(6.0, stats.Get("Initiative"))
+	s.Equal(0.0, stats.Get("Missing"))
+	s.Len(stats.All(), 2)
+}
+
+func (s *StatsTestSuite) TestGet_Recompute() {
+	v := attributes.NewAttributeValues()
+	v.Set(attributes.Vigor, 10)
+
+	stats := NewStats(s.definitions, &v)
+	s.Equal(50.0, stats.Get("Health"))
+
+	// Cached until the source changes
+	s.False(stats.stale())
+
+	v.Set(attributes.Vigor, 12)
+	s.True(stats.stale())
+	s.Equal(60.0, stats.Get("Health"))
+}
+
+func (s *StatsTestSuite) TestGet_DefinitionChange() {
+	definitions := NewDefinitions()
+	definitions.Register("Health", Stat{Name: "Health", Formula: *formula.MustParse("Vigor")})
+
+	v := attributes.NewAttributeValues()
+	v.Set(attributes.Vigor, 10)
+
+	stats := NewStats(definitions, &v)
+	s.Equal(10.0, stats.Get("Health"))
+
+	definitions.Register("Health", Stat{Name: "Health", Formula: *formula.MustParse("Vigor * 2")})
+	s.Equal(20.0, stats.Get("Health"))
+}
+
+func (s *StatsTestSuite) TestGet_Replaced() {
+	v := attributes.NewAttributeValues()
+	v.Set(attributes.Vigor, 8)
+
+	stats := NewStats(s.definitions, &v)
+	s.Equal(40.0, stats.Get("Health"))
+
+	// A replacement table with the same number of writes is still a change
+	replacement := attributes.NewAttributeValues()
+	replacement.Set(attributes.Vigor, 16)
+	v = replacement
+
+	s.True(stats.stale())
+	s.Equal(80.0, stats.Get("Health"))
+}
@@ -0,0 +1,115 @@
+//------------------------------------------------------------------------------
+//    Copyright 2019 Jeff Sharpe (zeropointx.io)
+//
+//    Licensed under the Apache License, Version 2.0 (the "License");
+//    you may not use this file except in compliance with the License.
+//    You may obtain a copy of the License at
+//
+//        http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS,
+//    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//    See the License for the specific language governing permissions and
+//    limitations under the License.
+//------------------------------------------------------------------------------
+
+package economy
+
+import (
+	"fmt"
+	log "github.com/sirupsen/logrus"
+	"github.com/zpxio/heromanager/internal/game/data/schema"
+	"github.com/zpxio/heromanager/internal/game/util"
+	"gopkg.in/yaml.v2"
+)
+
+// Config sets the guild's finances. Every interval of ticks is a payday,
+// when each hero is paid their wage and the guild pays its upkeep.
+type Config struct {
+	// Currency names the money the treasury holds.
+	Currency string `yaml:"currency" json:"currency"`
+
+	// Starting funds the treasury when the guild opens.
+	Starting int `yaml:"starting" json:"starting"`
+
+	Interval uint64 `yaml:"interval" json:"interval"`
+	Upkeep   int    `yaml:"upkeep" json:"upkeep"`
+
+	// Wage is paid to every hero each payday, on top of the wages of the
+	// hero's classifiers.
+	Wage int `yaml:"wage" json:"wage"`
+}
+
+func NewConfig() *Config {
+	return &Config{}
+}
+
+// Empty reports whether no economy data was loaded.
+func (c *Config) Empty() bool {
+	return c.Interval == 0 && c.Currency == ""
+}
+
+// Payday reports whether wages and upkeep are due on a tick.
+func (c *Config) Payday(tick uint64) bool {
+	return c.Interval > 0 && tick%c.Interval == 0
+}
+
+// NextPayday returns the first payday after a tick, or zero without paydays.
+func (c *Config) NextPayday(tick uint64) uint64 {
+	if c.Interval == 0 {
+		return 0
+	}
+
+	return (tick/c.Interval + 1) * c.Interval
+}
+
+// Validate ensures paydays come around and no amount is negative.
+func (c *Config) Validate() error {
+	if c.Currency == "" {
+		return fmt.Errorf("economy needs a currency")
+	}
+	if c.Interval < 1 {
+		return fmt.Errorf("economy needs a payday interval of at least one tick")
+	}
+	if c.Starting < 0 || c.Upkeep < 0 || c.Wage < 0 {
+		return fmt.Errorf("economy amounts cannot be negative")
+	}
+
+	return nil
+}
+
+func LoadEconomy(gameDir string, economyFile string, config *Config) error {
+	economyYaml, err := util.GameFileData(gameDir, economyFile)
+	if err != nil {
+		log.Errorf("yamlFile.Get err %v ", err)
+		return err
+	}
+
+	err = schema.ValidateData(economyFile, FileSchema(), economyYaml)
+	if err != nil {
+		log.Errorf("invalid economy data: %s", err)
+		return err
+	}
+
+	err = yaml.Unmarshal(economyYaml, config)
+	if err != nil {
+		log.Errorf("failed to parse economy data: %s", err)
+		return err
+	}
+
+	return config.Validate()
+}
+
+// FileSchema describes an economy data file.
+func FileSchema() *schema.Schema {
+	c := schema.Of(Config{})
+	c.Required = []string{"currency", "interval"}
+	for _, name := range []string{"starting", "upkeep", "wage"} {
+		c.Properties[name].Minimum = new(float64)
+	}
+	one := 1.0
+	c.Properties["interval"].Minimum = &one
+
+	return schema.Document("economy", "Defines the guild currency, paydays, upkeep and wages.", c)
+}
@@ -0,0 +1,70 @@
+//------------------------------------------------------------------------------
+//    Copyright 2019 Jeff Sharpe (zeropointx.io)
+//
+//    Licensed under the Apache License, Version 2.0 (the "License");
+//    you may not use this file except in compliance with the License.
+//    You may obtain a copy of the License at
+//
+//        http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS,
+//    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//    See the License for the specific language governing permissions and
+//    limitations under the License.
+//------------------------------------------------------------------------------
+
+package economy
+
+import (
+	"github.com/stretchr/testify/suite"
+	"github.com/zpxio/heromanager/internal/game/data/schema"
+	"testing"
+)
+
+type EconomyTestSuite struct {
+	suite.Suite
+}
+
+func TestEconomySuite(t *testing.T) {
+	suite.Run(t, new(EconomyTestSuite))
+}
+
+func (s *EconomyTestSuite) TestLoadEconomy() {
+	c := NewConfig()
+	err := LoadEconomy("testdata/game/data/economy", "test_economy_simple.yml", c)
+
+	s.Require().Nil(err)
+	s.Equal(Config{Currency: "crowns", Starting: 100, Interval: 5, Upkeep: 10, Wage: 3}, *c)
+	s.False(c.Empty())
+}
+
+func (s *EconomyTestSuite) TestLoadEconomy_Schema() {
+	c := NewConfig()
+	err := LoadEconomy("testdata/game/data/economy", "test_economy_bad_interval.yml", c)
+
+	s.Require().NotNil(err)
+	_, ok := err.(*schema.ValidationError)
+	s.True(ok)
+}
+
+func (s *EconomyTestSuite) TestPayday() {
+	c := Config{Currency: "crowns", Interval: 5}
+
+	s.False(c.Payday(4))
+	s.True(c.Payday(5))
+	s.True(c.Payday(10))
+	s.Equal(uint64(5), c.NextPayday(4))
+	s.Equal(uint64(10), c.NextPayday(5))
+
+	s.True(NewConfig().Empty())
+	s.False(NewConfig().Payday(5))
+	s.Equal(uint64(0), NewConfig().NextPayday(5))
+}
+
+func (s *EconomyTestSuite) TestValidate() {
+	s.Nil((&Config{Currency: "crowns", Interval: 1}).Validate())
+	s.NotNil((&Config{Interval: 1}).Validate())
+	s.NotNil((&Config{Currency: "crowns"}).Validate())
+	s.NotNil((&Config{Currency: "crowns", Interval: 1, Upkeep: -1}).Validate())
+}
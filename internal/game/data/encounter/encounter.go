@@ -0,0 +1,219 @@
+//------------------------------------------------------------------------------
+//    Copyright 2019 Jeff Sharpe (zeropointx.io)
+//
+//    Licensed under the Apache License, Version 2.0 (the "License");
+//    you may not use this file except in compliance with the License.
+//    You may obtain a copy of the License at
+//
+//        http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS,
+//    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//    See the License for the specific language governing permissions and
+//    limitations under the License.
+//------------------------------------------------------------------------------
+
+package encounter
+
+import (
+	"fmt"
+	log "github.com/sirupsen/logrus"
+	"github.com/zpxio/heromanager/internal/game/data/enemy"
+	"github.com/zpxio/heromanager/internal/game/data/schema"
+	"github.com/zpxio/heromanager/internal/game/util"
+	"gopkg.in/yaml.v2"
+	"math/rand"
+	"sort"
+)
+
+// Table is a weighted list of enemies met on quests within a range of
+// difficulty. Each encounter rolls a number of enemies within its size.
+type Table struct {
+	Name       string     `yaml:"name" json:"name"`
+	Difficulty Difficulty `yaml:"difficulty" json:"difficulty"`
+	Size       Size       `yaml:"size" json:"size"`
+	Enemies    []Entry    `yaml:"enemies" json:"enemies"`
+}
+
+// Difficulty bounds the quest difficulties a table is used for.
+type Difficulty struct {
+	Min float64 `yaml:"min" json:"min"`
+	Max float64 `yaml:"max" json:"max"`
+}
+
+// Size bounds the number of enemies in an encounter.
+type Size struct {
+	Min int `yaml:"min" json:"min"`
+	Max int `yaml:"max" json:"max"`
+}
+
+// Entry is an enemy in a table. Entries without a weight use the weight of
+// the enemy itself.
+type Entry struct {
+	Enemy  string  `yaml:"enemy" json:"enemy"`
+	Weight float32 `yaml:"weight" json:"weight,omitempty"`
+}
+
+// Covers reports whether the table is used for a quest difficulty.
+func (t Table) Covers(difficulty float64) bool {
+	return difficulty >= t.Difficulty.Min && difficulty <= t.Difficulty.Max
+}
+
+// Roll picks the enemies of an encounter, returning their IDs.
+func (t Table) Roll(enemies *enemy.Definitions, rng *rand.Rand) []string {
+	weights := make([]float32, len(t.Enemies))
+	for i, entry := range t.Enemies {
+		weights[i] = entry.Weight
+		if e, found := enemies.Resolve(entry.Enemy); found && entry.Weight == 0 {
+			weights[i] = e.Rarity()
+		}
+	}
+
+	count := t.Size.Min + rng.Intn(t.Size.Max-t.Size.Min+1)
+	rolled := make([]string, 0, count)
+	for i := 0; i < count; i++ {
+		rolled = append(rolled, t.Enemies[util.PickWeightedIndex(weights, rng.Float32())].Enemy)
+	}
+
+	return rolled
+}
+
+type Definitions struct {
+	tables map[string]Table
+	keys   []string
+}
+
+func NewDefinitions() *Definitions {
+	return &Definitions{
+		tables: make(map[string]Table),
+		keys:   make([]string, 0),
+	}
+}
+
+func (d *Definitions) Register(id string, t Table) {
+	log.Infof("Registering Encounter Table: %s", id)
+	d.tables[id] = t
+	d.keys = nil
+}
+
+func (d *Definitions) Resolve(id string) (*Table, bool) {
+	t, found := d.tables[id]
+
+	if found {
+		return &t, true
+	} else {
+		return nil, false
+	}
+}
+
+func (d *Definitions) All() []string {
+	if d.keys == nil {
+		d.keys = make([]string, 0, len(d.tables))
+		for id := range d.tables {
+			d.keys = append(d.keys, id)
+		}
+		sort.Strings(d.keys)
+	}
+
+	return d.keys
+}
+
+// Covering lists the tables used for a quest difficulty.
+func (d *Definitions) Covering(difficulty float64) []string {
+	covering := make([]string, 0)
+	for _, id := range d.All() {
+		if d.tables[id].Covers(difficulty) {
+			covering = append(covering, id)
+		}
+	}
+
+	return covering
+}
+
+// Select picks one of the tables used for a quest difficulty, each being
+// equally likely.
+func (d *Definitions) Select(difficulty float64, rng *rand.Rand) (string, bool) {
+	covering := d.Covering(difficulty)
+	if len(covering) == 0 {
+		return "", false
+	}
+
+	return covering[rng.Intn(len(covering))], true
+}
+
+// Validate ensures every table can be rolled, and only lists known enemies.
+func (d *Definitions) Validate(enemies *enemy.Definitions) error {
+	for _, id := range d.All() {
+		t := d.tables[id]
+		if t.Difficulty.Max < t.Difficulty.Min {
+			return fmt.Errorf("encounter table %s has an invalid difficulty: %v-%v", id, t.Difficulty.Min, t.Difficulty.Max)
+		}
+		if t.Size.Min < 1 || t.Size.Max < t.Size.Min {
+			return fmt.Errorf("encounter table %s has an invalid size: %d-%d", id, t.Size.Min, t.Size.Max)
+		}
+		if len(t.Enemies) == 0 {
+			return fmt.Errorf("encounter table %s lists no enemies", id)
+		}
+
+		total := float32(0)
+		for _, entry := range t.Enemies {
+			e, found := enemies.Resolve(entry.Enemy)
+			if !found {
+				return fmt.Errorf("encounter table %s lists unknown enemy: %s", id, entry.Enemy)
+			}
+			if entry.Weight < 0 {
+				return fmt.Errorf("encounter table %s weighs %s negatively", id, entry.Enemy)
+			}
+			total += entry.Weight
+			if entry.Weight == 0 {
+				total += e.Rarity()
+			}
+		}
+		if total <= 0 {
+			return fmt.Errorf("encounter table %s has no weight", id)
+		}
+	}
+
+	return nil
+}
+
+func LoadEncounters(gameDir string, encounterFile string, definitions *Definitions) error {
+	encounterYaml, err := util.GameFileData(gameDir, encounterFile)
+	if err != nil {
+		log.Errorf("yamlFile.Get err %v ", err)
+		return err
+	}
+
+	err = schema.ValidateData(encounterFile, FileSchema(), encounterYaml)
+	if err != nil {
+		log.Errorf("invalid encounter data: %s", err)
+		return err
+	}
+
+	tables := make(map[string]Table)
+	err = yaml.Unmarshal(encounterYaml, &tables)
+	if err != nil {
+		log.Errorf("failed to parse encounter data: %s", err)
+		return err
+	}
+
+	// Register the tables
+	for id, t := range tables {
+		definitions.Register(id, t)
+	}
+
+	return nil
+}
+
+// FileSchema describes an encounter table data file, keyed by table ID.
+func FileSchema() *schema.Schema {
+	t := schema.Of(Table{})
+	t.Required = []string{"difficulty", "size", "enemies"}
+	t.Properties["difficulty"].Required = []string{"min", "max"}
+	t.Properties["size"].Required = []string{"min", "max"}
+	t.Properties["enemies"].Items.Required = []string{"enemy"}
+	t.Properties["enemies"].Items.Properties["weight"].Minimum = new(float64)
+
+	return schema.Document("encounters", "Defines weighted tables of enemies met on quests, keyed by ID.", schema.MapOf(t))
+}
@@ -0,0 +1,139 @@
+//------------------------------------------------------------------------------
+//    Copyright 2019 Jeff Sharpe (zeropointx.io)
+//
+//    Licensed under the Apache License, Version 2.0 (the "License");
+//    you may not use this file except in compliance with the License.
+//    You may obtain a copy of the License at
+//
+//        http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS,
+//    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//    See the License for the specific language governing permissions and
+//    limitations under the License.
+//------------------------------------------------------------------------------
+
+package encounter
+
+import (
+	"github.com/stretchr/testify/suite"
+	"github.com/zpxio/heromanager/internal/game/data/damage"
+	"github.com/zpxio/heromanager/internal/game/data/enemy"
+	"github.com/zpxio/heromanager/internal/game/data/schema"
+	"math/rand"
+	"testing"
+)
+
+type EncounterTestSuite struct {
+	suite.Suite
+}
+
+func TestEncounterSuite(t *testing.T) {
+	suite.Run(t, new(EncounterTestSuite))
+}
+
+func testEnemies() *enemy.Definitions {
+	d := enemy.NewDefinitions()
+	bite := []damage.Attack{{Name: "Bite", Type: damage.Toxin, Damage: 1}}
+	d.Register("Rat", enemy.Enemy{Name: "Rat", Weight: 1, Attacks: bite})
+	d.Register("Troll", enemy.Enemy{Name: "Troll", Weight: 0, Attacks: bite})
+
+	return d
+}
+
+func loadTables() *Definitions {
+	d := NewDefinitions()
+	err := LoadEncounters("testdata/game/data/encounter", "test_encounter_simple.yml", d)
+	if err != nil {
+		panic(err)
+	}
+
+	return d
+}
+
+func (s *EncounterTestSuite) TestLoadEncounters() {
+	d := loadTables()
+
+	s.Equal([]string{"Bridge", "Sewers"}, d.All())
+	s.Nil(d.Validate(testEnemies()))
+
+	t, found := d.Resolve("Sewers")
+	s.Require().True(found)
+	s.Equal(Difficulty{Min: 1, Max: 3}, t.Difficulty)
+	s.Equal(Size{Min: 2, Max: 3}, t.Size)
+	s.Equal([]Entry{{Enemy: "Rat"}}, t.Enemies)
+}
+
+func (s *EncounterTestSuite) TestLoadEncounters_Schema() {
+	d := NewDefinitions()
+	err := LoadEncounters("testdata/game/data/encounter", "test_encounter_bad_size.yml", d)
+
+	s.Require().NotNil(err)
+	_, ok := err.(*schema.ValidationError)
+	s.True(ok)
+	s.Empty(d.All())
+}
+
+func (s *EncounterTestSuite) TestCovering() {
+	d := loadTables()
+
+	s.Equal([]string{"Sewers"}, d.Covering(1))
+	s.Equal([]string{"Bridge", "Sewers"}, d.Covering(3))
+	s.Empty(d.Covering(0))
+	s.Empty(d.Covering(9))
+
+	id, found := d.Select(2, rand.New(rand.NewSource(1)))
+	s.True(found)
+	s.Equal("Sewers", id)
+	_, found = d.Select(9, rand.New(rand.NewSource(1)))
+	s.False(found)
+}
+
+func (s *EncounterTestSuite) TestRoll() {
+	d := loadTables()
+	rng := rand.New(rand.NewSource(1))
+
+	sewers, _ := d.Resolve("Sewers")
+	for i := 0; i < 20; i++ {
+		rolled := sewers.Roll(testEnemies(), rng)
+		s.True(len(rolled) >= 2 && len(rolled) <= 3)
+		for _, id := range rolled {
+			s.Equal("Rat", id)
+		}
+	}
+
+	bridge, _ := d.Resolve("Bridge")
+	s.Equal([]string{"Troll"}, bridge.Roll(testEnemies(), rng))
+}
+
+func (s *EncounterTestSuite) TestRoll_EnemyWeight() {
+	t := Table{Size: Size{Min: 1, Max: 1}, Enemies: []Entry{{Enemy: "Troll"}, {Enemy: "Rat"}}}
+	rng := rand.New(rand.NewSource(1))
+
+	for i := 0; i < 20; i++ {
+		s.Equal([]string{"Rat"}, t.Roll(testEnemies(), rng))
+	}
+}
+
+func (s *EncounterTestSuite) TestValidate() {
+	valid := func() Table {
+		return Table{Difficulty: Difficulty{Min: 1, Max: 2}, Size: Size{Min: 1, Max: 2}, Enemies: []Entry{{Enemy: "Rat"}}}
+	}
+
+	for name, mutate := range map[string]func(t *Table){
+		"difficulty": func(t *Table) { t.Difficulty = Difficulty{Min: 3, Max: 1} },
+		"size":       func(t *Table) { t.Size = Size{} },
+		"empty":      func(t *Table) { t.Enemies = nil },
+		"unknown":    func(t *Table) { t.Enemies = []Entry{{Enemy: "Dragon"}} },
+		"negative":   func(t *Table) { t.Enemies[0].Weight = -1 },
+		"weightless": func(t *Table) { t.Enemies = []Entry{{Enemy: "Troll"}} },
+	} {
+		t := valid()
+		mutate(&t)
+
+		d := NewDefinitions()
+		d.Register("Test", t)
+		s.NotNil(d.Validate(testEnemies()), name)
+	}
+}
@@ -0,0 +1,199 @@
+//------------------------------------------------------------------------------
+//    Copyright 2019 Jeff Sharpe (zeropointx.io)
+//
+//    Licensed under the Apache License, Version 2.0 (the "License");
+//    you may not use this file except in compliance with the License.
+//    You may obtain a copy of the License at
+//
+//        http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS,
+//    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//    See the License for the specific language governing permissions and
+//    limitations under the License.
+//------------------------------------------------------------------------------
+
+package enemy
+
+import (
+	"fmt"
+	log "github.com/sirupsen/logrus"
+	"github.com/zpxio/heromanager/internal/game/data/attributes"
+	"github.com/zpxio/heromanager/internal/game/data/damage"
+	"github.com/zpxio/heromanager/internal/game/data/schema"
+	"github.com/zpxio/heromanager/internal/game/data/table"
+	"github.com/zpxio/heromanager/internal/game/util"
+	"gopkg.in/yaml.v2"
+	"sort"
+)
+
+const DefaultWeight float32 = 1.0
+
+// Enemy is an archetype of opponent heroes can meet, such as a giant rat.
+type Enemy struct {
+	Name string `yaml:"name" json:"name"`
+
+	// Weight is how common the enemy is in encounter tables that do not
+	// weigh it themselves.
+	Weight float32 `yaml:"weight" json:"weight"`
+
+	// Rank places the enemy in its formation, from the front at zero.
+	Rank int `yaml:"rank" json:"rank"`
+
+	// Attributes are keyed by attribute, and Resistances by damage type.
+	Attributes  map[string]float64 `yaml:"attributes" json:"attributes"`
+	Resistances map[string]float64 `yaml:"resistances" json:"resistances,omitempty"`
+	Attacks     []damage.Attack    `yaml:"attacks" json:"attacks"`
+
+	// Loot names the table rolled into the guild stash when the enemy is
+	// defeated.
+	Loot string `yaml:"loot" json:"loot,omitempty"`
+}
+
+// enemyData has the layout of Enemy without its unmarshal method.
+type enemyData Enemy
+
+func (e *Enemy) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	loaded := enemyData{Weight: DefaultWeight}
+	err := unmarshal(&loaded)
+	if err != nil {
+		return err
+	}
+
+	*e = Enemy(loaded)
+
+	return nil
+}
+
+// Rarity implements util.Weighted using the enemy weight.
+func (e Enemy) Rarity() float32 {
+	return e.Weight
+}
+
+// Values returns the attributes of the enemy as a table.
+func (e Enemy) Values() table.Values {
+	values := attributes.NewAttributeValues()
+	values.Load(e.Attributes)
+
+	return values
+}
+
+// ResistanceValues returns the resistances of the enemy as a table.
+func (e Enemy) ResistanceValues() table.Values {
+	resistances := damage.NewResistances()
+	resistances.Load(e.Resistances)
+
+	return resistances
+}
+
+type Definitions struct {
+	enemies map[string]Enemy
+	keys    []string
+}
+
+func NewDefinitions() *Definitions {
+	return &Definitions{
+		enemies: make(map[string]Enemy),
+		keys:    make([]string, 0),
+	}
+}
+
+func (d *Definitions) Register(id string, e Enemy) {
+	log.Infof("Registering Enemy: %s", id)
+	d.enemies[id] = e
+	d.keys = nil
+}
+
+func (d *Definitions) Resolve(id string) (*Enemy, bool) {
+	e, found := d.enemies[id]
+
+	if found {
+		return &e, true
+	} else {
+		return nil, false
+	}
+}
+
+func (d *Definitions) All() []string {
+	if d.keys == nil {
+		d.keys = make([]string, 0, len(d.enemies))
+		for id := range d.enemies {
+			d.keys = append(d.keys, id)
+		}
+		sort.Strings(d.keys)
+	}
+
+	return d.keys
+}
+
+// Validate ensures every enemy can fight, and only refers to known
+// attributes and damage types.
+func (d *Definitions) Validate(attributes *table.Policy) error {
+	for _, id := range d.All() {
+		e := d.enemies[id]
+		if e.Weight < 0 {
+			return fmt.Errorf("enemy %s has a negative weight: %v", id, e.Weight)
+		}
+		if len(e.Attacks) == 0 {
+			return fmt.Errorf("enemy %s has no attacks", id)
+		}
+		for name := range e.Attributes {
+			if !attributes.ValidKey(name) {
+				return fmt.Errorf("enemy %s has unknown attribute: %s", id, name)
+			}
+		}
+		for t := range e.Resistances {
+			if !damage.ValidType(t) {
+				return fmt.Errorf("enemy %s resists unknown damage type: %s", id, t)
+			}
+		}
+		for _, a := range e.Attacks {
+			if err := a.Validate(); err != nil {
+				return fmt.Errorf("enemy %s: %s", id, err)
+			}
+		}
+	}
+
+	return nil
+}
+
+func LoadEnemies(gameDir string, enemyFile string, definitions *Definitions) error {
+	enemyYaml, err := util.GameFileData(gameDir, enemyFile)
+	if err != nil {
+		log.Errorf("yamlFile.Get err %v ", err)
+		return err
+	}
+
+	err = schema.ValidateData(enemyFile, FileSchema(), enemyYaml)
+	if err != nil {
+		log.Errorf("invalid enemy data: %s", err)
+		return err
+	}
+
+	enemies := make(map[string]Enemy)
+	err = yaml.Unmarshal(enemyYaml, &enemies)
+	if err != nil {
+		log.Errorf("failed to parse enemy data: %s", err)
+		return err
+	}
+
+	// Register the enemies
+	for id, e := range enemies {
+		definitions.Register(id, e)
+	}
+
+	return nil
+}
+
+// FileSchema describes an enemy data file, keyed by enemy ID.
+func FileSchema() *schema.Schema {
+	e := schema.Of(enemyData{})
+	e.Properties["weight"].Minimum = new(float64)
+	e.Properties["rank"].Minimum = new(float64)
+	e.Properties["attributes"].PropertyNames = schema.Enum(attributes.Policy().ValidKeys()...)
+	e.Properties["resistances"].PropertyNames = schema.Enum(damage.Types()...)
+	e.Required = []string{"name", "attacks"}
+
+	return schema.Document("enemies", "Defines enemy archetypes heroes can meet, keyed by ID.", schema.MapOf(e))
+}
@@ -0,0 +1,94 @@
+//------------------------------------------------------------------------------
+//    Copyright 2019 Jeff Sharpe (zeropointx.io)
+//
+//    Licensed under the Apache License, Version 2.0 (the "License");
+//    you may not use this file except in compliance with the License.
+//    You may obtain a copy of the License at
+//
+//        http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS,
+//    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//    See the License for the specific language governing permissions and
+//    limitations under the License.
+//------------------------------------------------------------------------------
+
+package enemy
+
+import (
+	"github.com/stretchr/testify/suite"
+	"github.com/zpxio/heromanager/internal/game/data/attributes"
+	"github.com/zpxio/heromanager/internal/game/data/damage"
+	"github.com/zpxio/heromanager/internal/game/data/schema"
+	"testing"
+)
+
+type EnemyTestSuite struct {
+	suite.Suite
+}
+
+func TestEnemySuite(t *testing.T) {
+	suite.Run(t, new(EnemyTestSuite))
+}
+
+func validEnemy() Enemy {
+	return Enemy{Name: "Test", Weight: DefaultWeight, Attacks: []damage.Attack{{Name: "Hit", Type: damage.Physical, Damage: 1}}}
+}
+
+func (s *EnemyTestSuite) TestLoadEnemies() {
+	d := NewDefinitions()
+	err := LoadEnemies("testdata/game/data/enemy", "test_enemy_simple.yml", d)
+
+	s.Require().Nil(err)
+	s.Equal([]string{"Rat", "Troll"}, d.All())
+	s.Nil(d.Validate(attributes.Policy()))
+
+	rat, found := d.Resolve("Rat")
+	s.Require().True(found)
+	s.Equal(DefaultWeight, rat.Rarity())
+	s.Equal(0, rat.Rank)
+	values := rat.Values()
+	s.Equal(4.0, values.Get(attributes.Brawn))
+	s.Equal(0.0, values.Get(attributes.Allure))
+	resistances := rat.ResistanceValues()
+	s.Equal(0.5, resistances.Get(damage.Toxin))
+	s.Equal([]damage.Attack{{Name: "Bite", Type: damage.Toxin, Damage: 2}}, rat.Attacks)
+
+	troll, _ := d.Resolve("Troll")
+	s.Equal(float32(0.25), troll.Rarity())
+	s.Equal(1, troll.Rank)
+	resistances = troll.ResistanceValues()
+	s.Equal(-0.5, resistances.Get(damage.Fire))
+}
+
+func (s *EnemyTestSuite) TestLoadEnemies_Schema() {
+	d := NewDefinitions()
+	err := LoadEnemies("testdata/game/data/enemy", "test_enemy_bad_attack.yml", d)
+
+	s.Require().NotNil(err)
+	_, ok := err.(*schema.ValidationError)
+	s.True(ok)
+	s.Empty(d.All())
+}
+
+func (s *EnemyTestSuite) TestValidate() {
+	d := NewDefinitions()
+	d.Register("Test", validEnemy())
+	s.Nil(d.Validate(attributes.Policy()))
+
+	for name, mutate := range map[string]func(e *Enemy){
+		"weight":     func(e *Enemy) { e.Weight = -1 },
+		"attacks":    func(e *Enemy) { e.Attacks = nil },
+		"attack":     func(e *Enemy) { e.Attacks[0].Type = "Sonic" },
+		"attribute":  func(e *Enemy) { e.Attributes = map[string]float64{"Luck": 10} },
+		"resistance": func(e *Enemy) { e.Resistances = map[string]float64{"Sonic": 0.5} },
+	} {
+		e := validEnemy()
+		mutate(&e)
+
+		d := NewDefinitions()
+		d.Register("Test", e)
+		s.NotNil(d.Validate(attributes.Policy()), name)
+	}
+}
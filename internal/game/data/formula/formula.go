@@ -0,0 +1,422 @@
+//------------------------------------------------------------------------------
+//    Copyright 2019 Jeff Sharpe (zeropointx.io)
+//
+//    Licensed under the Apache License, Version 2.0 (the "License");
+//    you may not use this file except in compliance with the License.
+//    You may obtain a copy of the License at
+//
+//        http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS,
+//    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//    See the License for the specific language governing permissions and
+//    limitations under the License.
+//------------------------------------------------------------------------------
+
+package formula
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/zpxio/heromanager/internal/game/data/schema"
+	"math"
+	"sort"
+	"strconv"
+	"unicode"
+)
+
+// MaxDepth bounds the nesting of parsed expressions so that data files cannot
+// produce arbitrarily deep evaluation.
+const MaxDepth = 64
+
+// Lookup resolves a variable name to its value.
+type Lookup func(name string) (float64, bool)
+
+// Expression is a parsed arithmetic formula over named variables. Only
+// numbers, variables, the operators + - * / and a fixed set of functions are
+// supported, so evaluating data-defined formulas is always safe.
+type Expression struct {
+	source string
+	root   node
+}
+
+type node interface {
+	eval(lookup Lookup) (float64, error)
+	vars(set map[string]bool)
+}
+
+type number float64
+
+type variable string
+
+type unary struct {
+	op      rune
+	operand node
+}
+
+type binary struct {
+	op          rune
+	left, right node
+}
+
+type call struct {
+	name string
+	args []node
+}
+
+var functions = map[string]struct {
+	arity int
+	fn    func(args []float64) float64
+}{
+	"min":   {2, func(a []float64) float64 { return math.Min(a[0], a[1]) }},
+	"max":   {2, func(a []float64) float64 { return math.Max(a[0], a[1]) }},
+	"floor": {1, func(a []float64) float64 { return math.Floor(a[0]) }},
+	"ceil":  {1, func(a []float64) float64 { return math.Ceil(a[0]) }},
+	"round": {1, func(a []float64) float64 { return math.Round(a[0]) }},
+	"abs":   {1, func(a []float64) float64 { return math.Abs(a[0]) }},
+}
+
+func Parse(source string) (*Expression, error) {
+	p := parser{source: []rune(source)}
+
+	root, err := p.expression(0)
+	if err != nil {
+		return nil, err
+	}
+
+	p.skipSpace()
+	if p.pos < len(p.source) {
+		return nil, fmt.Errorf("unexpected %q at position %d in formula: %s", p.source[p.pos], p.pos, source)
+	}
+
+	return &Expression{source: source, root: root}, nil
+}
+
+func MustParse(source string) *Expression {
+	e, err := Parse(source)
+	if err != nil {
+		panic(err)
+	}
+
+	return e
+}
+
+func (e *Expression) String() string {
+	return e.source
+}
+
+func (e *Expression) Evaluate(lookup Lookup) (float64, error) {
+	if e.root == nil {
+		return 0.0, nil
+	}
+
+	return e.root.eval(lookup)
+}
+
+// Variables returns the sorted set of variable names referenced by the
+// expression.
+func (e *Expression) Variables() []string {
+	set := make(map[string]bool)
+	if e.root != nil {
+		e.root.vars(set)
+	}
+
+	names := make([]string, 0, len(set))
+	for name := range set {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	return names
+}
+
+func (e *Expression) load(source string) error {
+	parsed, err := Parse(source)
+	if err != nil {
+		return err
+	}
+
+	*e = *parsed
+
+	return nil
+}
+
+func (e Expression) MarshalJSON() ([]byte, error) {
+	return json.Marshal(e.source)
+}
+
+func (e Expression) MarshalYAML() (interface{}, error) {
+	return e.source, nil
+}
+
+// JSONSchema describes an expression by its source text.
+func (e Expression) JSONSchema() *schema.Schema {
+	return schema.String()
+}
+
+func (e *Expression) UnmarshalJSON(data []byte) error {
+	source := ""
+	err := json.Unmarshal(data, &source)
+	if err != nil {
+		return err
+	}
+
+	return e.load(source)
+}
+
+func (e *Expression) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	source := ""
+	err := unmarshal(&source)
+	if err != nil {
+		return err
+	}
+
+	return e.load(source)
+}
+
+func (n number) eval(lookup Lookup) (float64, error) {
+	return float64(n), nil
+}
+
+func (n number) vars(set map[string]bool) {}
+
+func (v variable) eval(lookup Lookup) (float64, error) {
+	value, ok := lookup(string(v))
+	if !ok {
+		return 0.0, fmt.Errorf("unknown variable: %s", string(v))
+	}
+
+	return value, nil
+}
+
+func (v variable) vars(set map[string]bool) {
+	set[string(v)] = true
+}
+
+func (u unary) eval(lookup Lookup) (float64, error) {
+	value, err := u.operand.eval(lookup)
+	if err != nil {
+		return 0.0, err
+	}
+
+	if u.op == '-' {
+		return -value, nil
+	}
+
+	return value, nil
+}
+
+func (u unary) vars(set map[string]bool) {
+	u.operand.vars(set)
+}
+
+func (b binary) eval(lookup Lookup) (float64, error) {
+	left, err := b.left.eval(lookup)
+	if err != nil {
+		return 0.0, err
+	}
+
+	right, err := b.right.eval(lookup)
+	if err != nil {
+		return 0.0, err
+	}
+
+	switch b.op {
+	case '+':
+		return left + right, nil
+	case '-':
+		return left - right, nil
+	case '*':
+		return left * right, nil
+	case '/':
+		if right == 0.0 {
+			return 0.0, fmt.Errorf("division by zero")
+		}
+		return left / right, nil
+	}
+
+	return 0.0, fmt.Errorf("unknown operator: %c", b.op)
+}
+
+func (b binary) vars(set map[string]bool) {
+	b.left.vars(set)
+	b.right.vars(set)
+}
+
+func (c call) eval(lookup Lookup) (float64, error) {
+	args := make([]float64, len(c.args))
+	for i, arg := range c.args {
+		value, err := arg.eval(lookup)
+		if err != nil {
+			return 0.0, err
+		}
+		args[i] = value
+	}
+
+	return functions[c.name].fn(args), nil
+}
+
+func (c call) vars(set map[string]bool) {
+	for _, arg := range c.args {
+		arg.vars(set)
+	}
+}
+
+type parser struct {
+	source []rune
+	pos    int
+}
+
+func (p *parser) skipSpace() {
+	for p.pos < len(p.source) && unicode.IsSpace(p.source[p.pos]) {
+		p.pos++
+	}
+}
+
+func (p *parser) peek() rune {
+	p.skipSpace()
+	if p.pos < len(p.source) {
+		return p.source[p.pos]
+	}
+
+	return 0
+}
+
+// expression := term (('+' | '-') term)*
+func (p *parser) expression(depth int) (node, error) {
+	if depth > MaxDepth {
+		return nil, fmt.Errorf("formula nested too deeply")
+	}
+
+	left, err := p.term(depth)
+	if err != nil {
+		return nil, err
+	}
+
+	for op := p.peek(); op == '+' || op == '-'; op = p.peek() {
+		p.pos++
+		right, err := p.term(depth)
+		if err != nil {
+			return nil, err
+		}
+		left = binary{op: op, left: left, right: right}
+	}
+
+	return left, nil
+}
+
+// term := factor (('*' | '/') factor)*
+func (p *parser) term(depth int) (node, error) {
+	left, err := p.factor(depth)
+	if err != nil {
+		return nil, err
+	}
+
+	for op := p.peek(); op == '*' || op == '/'; op = p.peek() {
+		p.pos++
+		right, err := p.factor(depth)
+		if err != nil {
+			return nil, err
+		}
+		left = binary{op: op, left: left, right: right}
+	}
+
+	return left, nil
+}
+
+// factor := ('-' | '+') factor | number | identifier | call | '(' expression ')'
+func (p *parser) factor(depth int) (node, error) {
+	if depth > MaxDepth {
+		return nil, fmt.Errorf("formula nested too deeply")
+	}
+
+	c := p.peek()
+	switch {
+	case c == 0:
+		return nil, fmt.Errorf("unexpected end of formula")
+	case c == '-' || c == '+':
+		p.pos++
+		operand, err := p.factor(depth + 1)
+		if err != nil {
+			return nil, err
+		}
+		return unary{op: c, operand: operand}, nil
+	case c == '(':
+		p.pos++
+		inner, err := p.expression(depth + 1)
+		if err != nil {
+			return nil, err
+		}
+		if p.peek() != ')' {
+			return nil, fmt.Errorf("missing ')' at position %d", p.pos)
+		}
+		p.pos++
+		return inner, nil
+	case unicode.IsDigit(c) || c == '.':
+		return p.number()
+	case unicode.IsLetter(c) || c == '_':
+		return p.identifier(depth)
+	}
+
+	return nil, fmt.Errorf("unexpected %q at position %d", c, p.pos)
+}
+
+func (p *parser) number() (node, error) {
+	start := p.pos
+	for p.pos < len(p.source) && (unicode.IsDigit(p.source[p.pos]) || p.source[p.pos] == '.') {
+		p.pos++
+	}
+
+	value, err := strconv.ParseFloat(string(p.source[start:p.pos]), 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid number at position %d: %s", start, string(p.source[start:p.pos]))
+	}
+
+	return number(value), nil
+}
+
+func (p *parser) identifier(depth int) (node, error) {
+	start := p.pos
+	for p.pos < len(p.source) && (unicode.IsLetter(p.source[p.pos]) || unicode.IsDigit(p.source[p.pos]) || p.source[p.pos] == '_') {
+		p.pos++
+	}
+	name := string(p.source[start:p.pos])
+
+	if p.peek() != '(' {
+		return variable(name), nil
+	}
+
+	f, ok := functions[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown function: %s", name)
+	}
+	p.pos++
+
+	args := make([]node, 0, f.arity)
+	if p.peek() != ')' {
+		for {
+			arg, err := p.expression(depth + 1)
+			if err != nil {
+				return nil, err
+			}
+			args = append(args, arg)
+
+			if p.peek() != ',' {
+				break
+			}
+			p.pos++
+		}
+	}
+
+	if p.peek() != ')' {
+		return nil, fmt.Errorf("missing ')' after arguments to %s", name)
+	}
+	p.pos++
+
+	if len(args) != f.arity {
+		return nil, fmt.Errorf("function %s expects %d arguments, got %d", name, f.arity, len(args))
+	}
+
+	return call{name: name, args: args}, nil
+}
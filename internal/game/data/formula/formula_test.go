@@ -0,0 +1,149 @@
+//------------------------------------------------------------------------------
+//    Copyright 2019 Jeff Sharpe (zeropointx.io)
+//
+//    Licensed under the Apache License, Version 2.0 (the "License");
+//    you may not use this file except in compliance with the License.
+//    You may obtain a copy of the License at
+//
+//        http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS,
+//    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//    See the License for the specific language governing permissions and
+//    limitations under the License.
+//------------------------------------------------------------------------------
+
+package formula
+
+import (
+	"encoding/json"
+	"github.com/stretchr/testify/suite"
+	"gopkg.in/yaml.v2"
+	"strings"
+	"testing"
+)
+
+type FormulaTestSuite struct {
+	suite.Suite
+}
+
+func TestFormulaSuite(t *testing.T) {
+	suite.Run(t, new(FormulaTestSuite))
+}
+
+func testLookup(values map[string]float64) Lookup {
+	return func(name string) (float64, bool) {
+		v, ok := values[name]
+		return v, ok
+	}
+}
+
+func (s *FormulaTestSuite) evaluate(source string, values map[string]float64) float64 {
+	e, err := Parse(source)
+	s.Require().Nil(err)
+
+	result, err := e.Evaluate(testLookup(values))
+	s.Require().Nil(err)
+
+	return result
+}
+
+func (s *FormulaTestSuite) TestEvaluate_Constants() {
+	s.Equal(7.0, s.evaluate("1 + 2 * 3", nil))
+	s.Equal(9.0, s.evaluate("(1 + 2) * 3", nil))
+	s.Equal(-4.0, s.evaluate("-(2 * 2)", nil))
+	s.Equal(2.5, s.evaluate("10 / 4", nil))
+	s.Equal(1.0, s.evaluate("5 - 3 - 1", nil))
+	s.Equal(0.5, s.evaluate(".5", nil))
+}
+
+func (s *FormulaTestSuite) TestEvaluate_Variables() {
+	values := map[string]float64{"Vigor": 10, "Brawn": 4}
+
+	s.Equal(54.0, s.evaluate("Vigor*5 + Brawn", values))
+	s.Equal(-6.0, s.evaluate("Brawn - Vigor", values))
+}
+
+func (s *FormulaTestSuite) TestEvaluate_Functions() {
+	values := map[string]float64{"Finesse": 7.5}
+
+	s.Equal(7.5, s.evaluate("max(Finesse, 2)", values))
+	s.Equal(2.0, s.evaluate("min(Finesse, 2)", values))
+	s.Equal(7.0, s.evaluate("floor(Finesse)", values))
+	s.Equal(8.0, s.evaluate("ceil(Finesse)", values))
+	s.Equal(8.0, s.evaluate("round(Finesse)", values))
+	s.Equal(7.5, s.evaluate("abs(-Finesse)", values))
+}
+
+func (s *FormulaTestSuite) TestEvaluate_UnknownVariable() {
+	e := MustParse("Luck * 2")
+
+	_, err := e.Evaluate(testLookup(nil))
+	s.NotNil(err)
+}
+
+func (s *FormulaTestSuite) TestEvaluate_DivisionByZero() {
+	e := MustParse("Brawn / 0")
+
+	_, err := e.Evaluate(testLookup(map[string]float64{"Brawn": 1}))
+	s.NotNil(err)
+}
+
+func (s *FormulaTestSuite) TestParse_Invalid() {
+	invalid := []string{
+		"",
+		"1 +",
+		"(1 + 2",
+		"1 2",
+		"Brawn $ 2",
+		"explode(Brawn)",
+		"max(1)",
+		"min(1, 2",
+		"1..2",
+	}
+
+	for _, source := range invalid {
+		_, err := Parse(source)
+		s.NotNil(err, "Expected parse failure: %s", source)
+	}
+}
+
+func (s *FormulaTestSuite) TestParse_DepthLimit() {
+	source := strings.Repeat("(", MaxDepth+2) + "1" + strings.Repeat(")", MaxDepth+2)
+
+	_, err := Parse(source)
+	s.NotNil(err)
+}
+
+func (s *FormulaTestSuite) TestVariables() {
+	e := MustParse("Vigor*5 + max(Brawn, Vigor) - 3")
+
+	s.Equal([]string{"Brawn", "Vigor"}, e.Variables())
+}
+
+func (s *FormulaTestSuite) TestUnmarshalYAML() {
+	composed := struct {
+		Formula Expression `yaml:"formula"`
+	}{}
+
+	err := yaml.Unmarshal([]byte(`formula: "Vigor * 2"`), &composed)
+	s.Require().Nil(err)
+	s.Equal("Vigor * 2", composed.Formula.String())
+
+	err = yaml.Unmarshal([]byte(`formula: "Vigor * "`), &composed)
+	s.NotNil(err)
+}
+
+func (s *FormulaTestSuite) TestJSON_RoundTrip() {
+	e := MustParse("Brawn + 1")
+
+	data, err := json.Marshal(e)
+	s.Require().Nil(err)
+	s.Equal(`"Brawn + 1"`, string(data))
+
+	r := Expression{}
+	err = json.Unmarshal(data, &r)
+	s.Require().Nil(err)
+	s.Equal(e.Variables(), r.Variables())
+}
@@ -0,0 +1,232 @@
+//------------------------------------------------------------------------------
+//    Copyright 2019 Jeff Sharpe (zeropointx.io)
+//
+//    Licensed under the Apache License, Version 2.0 (the "License");
+//    you may not use this file except in compliance with the License.
+//    You may obtain a copy of the License at
+//
+//        http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS,
+//    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//    See the License for the specific language governing permissions and
+//    limitations under the License.
+//------------------------------------------------------------------------------
+
+package item
+
+import (
+	"fmt"
+	log "github.com/sirupsen/logrus"
+	"github.com/zpxio/heromanager/internal/game/data/attributes"
+	"github.com/zpxio/heromanager/internal/game/data/classifier"
+	"github.com/zpxio/heromanager/internal/game/data/damage"
+	"github.com/zpxio/heromanager/internal/game/data/schema"
+	"github.com/zpxio/heromanager/internal/game/data/table"
+	"github.com/zpxio/heromanager/internal/game/util"
+	"gopkg.in/yaml.v2"
+	"sort"
+)
+
+// Equipment slots, each holding at most one item.
+const (
+	SlotHead    = "head"
+	SlotBody    = "body"
+	SlotHands   = "hands"
+	SlotFeet    = "feet"
+	SlotWeapon  = "weapon"
+	SlotShield  = "shield"
+	SlotTrinket = "trinket"
+)
+
+var slots = []string{SlotHead, SlotBody, SlotHands, SlotFeet, SlotWeapon, SlotShield, SlotTrinket}
+
+// Slots lists every equipment slot, in the order equipment is applied.
+func Slots() []string {
+	return append([]string{}, slots...)
+}
+
+func ValidSlot(slot string) bool {
+	for _, s := range slots {
+		if s == slot {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Item is something heroes can carry and, when it has a slot, equip.
+type Item struct {
+	Name   string  `yaml:"name" json:"name"`
+	Slot   string  `yaml:"slot" json:"slot,omitempty"`
+	Weight float64 `yaml:"weight" json:"weight"`
+	Value  int     `yaml:"value" json:"value"`
+
+	// Attributes adjust the attributes of the hero equipping the item, and
+	// Resistances add to its resistances, keyed by damage type.
+	Attributes  table.Modifier     `yaml:"attributes" json:"attributes"`
+	Resistances map[string]float64 `yaml:"resistances" json:"resistances,omitempty"`
+
+	// Attack replaces the unarmed strike of a hero equipping the item.
+	Attack *damage.Attack `yaml:"attack" json:"attack,omitempty"`
+
+	// Requires limits who may equip the item by their classifiers.
+	Requires classifier.Requirements `yaml:"requires" json:"requires"`
+}
+
+// itemData has the layout of Item without its unmarshal method.
+type itemData Item
+
+func Initialize() Item {
+	return Item{
+		Attributes:  attributes.NewAttributeModifier(),
+		Resistances: make(map[string]float64),
+		Requires:    classifier.EmptyRequirements(),
+	}
+}
+
+func (i *Item) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	loaded := itemData(Initialize())
+	err := unmarshal(&loaded)
+	if err != nil {
+		return err
+	}
+
+	*i = Item(loaded)
+
+	return nil
+}
+
+// Equippable reports whether the item has an equipment slot.
+func (i Item) Equippable() bool {
+	return i.Slot != ""
+}
+
+type Definitions struct {
+	items map[string]Item
+	keys  []string
+}
+
+func NewDefinitions() *Definitions {
+	return &Definitions{
+		items: make(map[string]Item),
+		keys:  make([]string, 0),
+	}
+}
+
+func (d *Definitions) Register(id string, i Item) {
+	log.Infof("Registering Item: %s", id)
+	d.items[id] = i
+	d.keys = nil
+}
+
+func (d *Definitions) Resolve(id string) (*Item, bool) {
+	i, found := d.items[id]
+
+	if found {
+		return &i, true
+	} else {
+		return nil, false
+	}
+}
+
+func (d *Definitions) All() []string {
+	if d.keys == nil {
+		d.keys = make([]string, 0, len(d.items))
+		for id := range d.items {
+			d.keys = append(d.keys, id)
+		}
+		sort.Strings(d.keys)
+	}
+
+	return d.keys
+}
+
+// Validate ensures every item has a known slot, sensible weight and value,
+// and only refers to known damage types and classifiers.
+func (d *Definitions) Validate(manifest *classifier.ClassifierManifest) error {
+	for _, id := range d.All() {
+		i := d.items[id]
+		if i.Slot != "" && !ValidSlot(i.Slot) {
+			return fmt.Errorf("item %s has unknown slot: %s", id, i.Slot)
+		}
+		if i.Weight < 0 || i.Value < 0 {
+			return fmt.Errorf("item %s has a negative weight or value", id)
+		}
+		for t := range i.Resistances {
+			if !damage.ValidType(t) {
+				return fmt.Errorf("item %s resists unknown damage type: %s", id, t)
+			}
+		}
+		if i.Attack != nil {
+			if err := i.Attack.Validate(); err != nil {
+				return fmt.Errorf("item %s: %s", id, err)
+			}
+		}
+
+		groups := map[string]*classifier.ConflictGroup{
+			classifier.RequireAll:  &i.Requires.AllOf,
+			classifier.RequireAny:  &i.Requires.AnyOf,
+			classifier.RequireNone: &i.Requires.NoneOf,
+		}
+		for _, label := range []string{classifier.RequireAll, classifier.RequireAny, classifier.RequireNone} {
+			for _, target := range groups[label].Targets() {
+				if target == classifier.ConflictTags {
+					continue
+				}
+				if _, declared := manifest.Lookup(target); !declared {
+					return fmt.Errorf("item %s requires.%s references undeclared dimension: %s", id, label, target)
+				}
+				for _, ref := range groups[label].Ids(target) {
+					if _, found := manifest.Resolve(target, ref); !found {
+						return fmt.Errorf("item %s requires.%s references unknown %s: %s", id, label, target, ref)
+					}
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+func LoadItems(gameDir string, itemFile string, definitions *Definitions) error {
+	itemYaml, err := util.GameFileData(gameDir, itemFile)
+	if err != nil {
+		log.Errorf("yamlFile.Get err %v ", err)
+		return err
+	}
+
+	err = schema.ValidateData(itemFile, FileSchema(), itemYaml)
+	if err != nil {
+		log.Errorf("invalid item data: %s", err)
+		return err
+	}
+
+	items := make(map[string]Item)
+	err = yaml.Unmarshal(itemYaml, &items)
+	if err != nil {
+		log.Errorf("failed to parse item data: %s", err)
+		return err
+	}
+
+	// Register the items
+	for id, i := range items {
+		definitions.Register(id, i)
+	}
+
+	return nil
+}
+
+// FileSchema describes an item data file, keyed by item ID.
+func FileSchema() *schema.Schema {
+	i := schema.Of(itemData(Initialize()))
+	i.Properties["slot"] = schema.Enum(slots...)
+	i.Properties["weight"].Minimum = new(float64)
+	i.Properties["value"].Minimum = new(float64)
+	i.Properties["resistances"].PropertyNames = schema.Enum(damage.Types()...)
+	i.Required = []string{"name"}
+
+	return schema.Document("items", "Defines items heroes can carry and equip, keyed by ID.", schema.MapOf(i))
+}
@@ -0,0 +1,102 @@
+//------------------------------------------------------------------------------
+//    Copyright 2019 Jeff Sharpe (zeropointx.io)
+//
+//    Licensed under the Apache License, Version 2.0 (the "License");
+//    you may not use this file except in compliance with the License.
+//    You may obtain a copy of the License at
+//
+//        http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS,
+//    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//    See the License for the specific language governing permissions and
+//    limitations under the License.
+//------------------------------------------------------------------------------
+
+package item
+
+import (
+	"github.com/stretchr/testify/suite"
+	"github.com/zpxio/heromanager/internal/game/data/attributes"
+	"github.com/zpxio/heromanager/internal/game/data/classifier"
+	"github.com/zpxio/heromanager/internal/game/data/damage"
+	"github.com/zpxio/heromanager/internal/game/data/schema"
+	"testing"
+)
+
+type ItemTestSuite struct {
+	suite.Suite
+}
+
+func TestItemSuite(t *testing.T) {
+	suite.Run(t, new(ItemTestSuite))
+}
+
+func testManifest() *classifier.ClassifierManifest {
+	m := classifier.NewManifest()
+	m.RegisterRace("Elf", classifier.BlankRace())
+
+	return m
+}
+
+func (s *ItemTestSuite) TestSlots() {
+	s.Equal(SlotHead, Slots()[0])
+	s.True(ValidSlot(SlotTrinket))
+	s.False(ValidSlot("aura"))
+}
+
+func (s *ItemTestSuite) TestLoadItems() {
+	d := NewDefinitions()
+	err := LoadItems("testdata/game/data/item", "test_item_simple.yml", d)
+
+	s.Require().Nil(err)
+	s.Equal([]string{"FurCloak", "Rope", "Sword"}, d.All())
+	s.Nil(d.Validate(testManifest()))
+
+	sword, found := d.Resolve("Sword")
+	s.Require().True(found)
+	s.True(sword.Equippable())
+	s.Equal(4.0, sword.Weight)
+	s.Equal(30, sword.Value)
+	s.InDelta(1.1, sword.Attributes.Factor(attributes.Brawn), 0.0001)
+	s.Equal(&damage.Attack{Name: "Slash", Type: damage.Physical, Damage: 8}, sword.Attack)
+
+	cloak, _ := d.Resolve("FurCloak")
+	s.Equal(0.3, cloak.Resistances[damage.Cold])
+	s.True(cloak.Requires.NoneOf.Contains(classifier.ConflictRaces, "Elf"))
+	s.Nil(cloak.Attack)
+
+	rope, _ := d.Resolve("Rope")
+	s.False(rope.Equippable())
+	s.Equal(1.0, rope.Attributes.Factor(attributes.Brawn))
+}
+
+func (s *ItemTestSuite) TestLoadItems_Schema() {
+	d := NewDefinitions()
+	err := LoadItems("testdata/game/data/item", "test_item_bad_slot.yml", d)
+
+	s.Require().NotNil(err)
+	_, ok := err.(*schema.ValidationError)
+	s.True(ok)
+	s.Empty(d.All())
+}
+
+func (s *ItemTestSuite) TestValidate() {
+	for name, mutate := range map[string]func(i *Item){
+		"slot":        func(i *Item) { i.Slot = "aura" },
+		"weight":      func(i *Item) { i.Weight = -1 },
+		"value":       func(i *Item) { i.Value = -1 },
+		"resistance":  func(i *Item) { i.Resistances["Sonic"] = 0.5 },
+		"attack":      func(i *Item) { i.Attack = &damage.Attack{Name: "Shout", Type: "Sonic"} },
+		"requirement": func(i *Item) { i.Requires.AnyOf.Add(classifier.ConflictRaces, "Dwarf") },
+	} {
+		i := Initialize()
+		i.Name = "Test"
+		mutate(&i)
+
+		d := NewDefinitions()
+		d.Register("Test", i)
+		s.NotNil(d.Validate(testManifest()), name)
+	}
+}
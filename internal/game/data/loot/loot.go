@@ -0,0 +1,276 @@
+//------------------------------------------------------------------------------
+//    Copyright 2019 Jeff Sharpe (zeropointx.io)
+//
+//    Licensed under the Apache License, Version 2.0 (the "License");
+//    you may not use this file except in compliance with the License.
+//    You may obtain a copy of the License at
+//
+//        http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS,
+//    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//    See the License for the specific language governing permissions and
+//    limitations under the License.
+//------------------------------------------------------------------------------
+
+package loot
+
+import (
+	"fmt"
+	log "github.com/sirupsen/logrus"
+	"github.com/zpxio/heromanager/internal/game/data/classifier"
+	"github.com/zpxio/heromanager/internal/game/data/item"
+	"github.com/zpxio/heromanager/internal/game/data/schema"
+	"github.com/zpxio/heromanager/internal/game/util"
+	"gopkg.in/yaml.v2"
+	"sort"
+)
+
+// MaxDepth limits how deeply tables may nest.
+const MaxDepth = 8
+
+const DefaultWeight float32 = 1.0
+
+// Table drops every guaranteed entry, then picks weighted entries a number of
+// times within its rolls.
+type Table struct {
+	Name       string  `yaml:"name" json:"name"`
+	Rolls      Range   `yaml:"rolls" json:"rolls"`
+	Guaranteed []Entry `yaml:"guaranteed" json:"guaranteed,omitempty"`
+	Entries    []Entry `yaml:"entries" json:"entries,omitempty"`
+}
+
+// tableData has the layout of Table without its unmarshal method.
+type tableData Table
+
+func (t *Table) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	loaded := tableData{Rolls: Range{Min: 1, Max: 1}}
+	err := unmarshal(&loaded)
+	if err != nil {
+		return err
+	}
+
+	*t = Table(loaded)
+
+	return nil
+}
+
+// Range bounds a random count, inclusive.
+type Range struct {
+	Min int `yaml:"min" json:"min"`
+	Max int `yaml:"max" json:"max"`
+}
+
+// Entry drops either a quantity of an item, or the result of rolling another
+// table that many times. Entries only apply when their condition is met.
+type Entry struct {
+	Item     string    `yaml:"item" json:"item,omitempty"`
+	Table    string    `yaml:"table" json:"table,omitempty"`
+	Weight   float32   `yaml:"weight" json:"weight"`
+	Quantity Range     `yaml:"quantity" json:"quantity"`
+	When     Condition `yaml:"when" json:"when,omitempty"`
+}
+
+// entryData has the layout of Entry without its unmarshal method.
+type entryData Entry
+
+func (e *Entry) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	loaded := entryData{Weight: DefaultWeight, Quantity: Range{Min: 1, Max: 1}}
+	err := unmarshal(&loaded)
+	if err != nil {
+		return err
+	}
+
+	*e = Entry(loaded)
+
+	return nil
+}
+
+// Rarity implements util.Weighted using the entry weight.
+func (e Entry) Rarity() float32 {
+	return e.Weight
+}
+
+// Condition limits an entry to parties with one of the listed professions,
+// or to quests within a range of tiers. Empty conditions always apply.
+type Condition struct {
+	Professions []string `yaml:"professions" json:"professions,omitempty"`
+	Tier        *Range   `yaml:"tier" json:"tier,omitempty"`
+}
+
+// Context describes what loot is rolled for.
+type Context struct {
+	Professions []string
+	Tier        int
+}
+
+func (c Condition) Allows(ctx Context) bool {
+	if c.Tier != nil && (ctx.Tier < c.Tier.Min || ctx.Tier > c.Tier.Max) {
+		return false
+	}
+	if len(c.Professions) == 0 {
+		return true
+	}
+
+	for _, p := range c.Professions {
+		for _, present := range ctx.Professions {
+			if p == present {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+type Definitions struct {
+	tables map[string]Table
+	keys   []string
+}
+
+func NewDefinitions() *Definitions {
+	return &Definitions{
+		tables: make(map[string]Table),
+		keys:   make([]string, 0),
+	}
+}
+
+func (d *Definitions) Register(id string, t Table) {
+	log.Infof("Registering Loot Table: %s", id)
+	d.tables[id] = t
+	d.keys = nil
+}
+
+func (d *Definitions) Resolve(id string) (*Table, bool) {
+	t, found := d.tables[id]
+
+	if found {
+		return &t, true
+	} else {
+		return nil, false
+	}
+}
+
+func (d *Definitions) All() []string {
+	if d.keys == nil {
+		d.keys = make([]string, 0, len(d.tables))
+		for id := range d.tables {
+			d.keys = append(d.keys, id)
+		}
+		sort.Strings(d.keys)
+	}
+
+	return d.keys
+}
+
+// Validate ensures every table can be rolled, only drops known items, only
+// conditions on known professions, and only nests known tables without
+// cycles.
+func (d *Definitions) Validate(items *item.Definitions, manifest *classifier.ClassifierManifest) error {
+	for _, id := range d.All() {
+		t := d.tables[id]
+		if t.Rolls.Min < 0 || t.Rolls.Max < t.Rolls.Min {
+			return fmt.Errorf("loot table %s has invalid rolls: %d-%d", id, t.Rolls.Min, t.Rolls.Max)
+		}
+		if len(t.Guaranteed) == 0 && len(t.Entries) == 0 {
+			return fmt.Errorf("loot table %s drops nothing", id)
+		}
+
+		for _, e := range append(append([]Entry{}, t.Guaranteed...), t.Entries...) {
+			if (e.Item == "") == (e.Table == "") {
+				return fmt.Errorf("loot table %s has an entry needing either an item or a table", id)
+			}
+			if _, found := items.Resolve(e.Item); e.Item != "" && !found {
+				return fmt.Errorf("loot table %s drops unknown item: %s", id, e.Item)
+			}
+			if _, found := d.tables[e.Table]; e.Table != "" && !found {
+				return fmt.Errorf("loot table %s nests unknown table: %s", id, e.Table)
+			}
+			if e.Weight < 0 {
+				return fmt.Errorf("loot table %s has a negative weight", id)
+			}
+			if e.Quantity.Min < 0 || e.Quantity.Max < e.Quantity.Min {
+				return fmt.Errorf("loot table %s has an invalid quantity: %d-%d", id, e.Quantity.Min, e.Quantity.Max)
+			}
+			for _, p := range e.When.Professions {
+				if _, found := manifest.ResolveProfession(p); !found {
+					return fmt.Errorf("loot table %s conditions on unknown profession: %s", id, p)
+				}
+			}
+			if e.When.Tier != nil && e.When.Tier.Max < e.When.Tier.Min {
+				return fmt.Errorf("loot table %s has an invalid tier: %d-%d", id, e.When.Tier.Min, e.When.Tier.Max)
+			}
+		}
+
+		if chain := d.cycle(id, []string{id}); chain != nil {
+			return fmt.Errorf("loot table %s nests itself: %v", id, chain)
+		}
+	}
+
+	return nil
+}
+
+// cycle returns a chain of nested tables leading back to its start, or
+// tables nested deeper than MaxDepth.
+func (d *Definitions) cycle(id string, chain []string) []string {
+	t := d.tables[id]
+	for _, e := range append(append([]Entry{}, t.Guaranteed...), t.Entries...) {
+		if e.Table == "" {
+			continue
+		}
+
+		next := append(append([]string{}, chain...), e.Table)
+		if e.Table == chain[0] || len(next) > MaxDepth {
+			return next
+		}
+		if found := d.cycle(e.Table, next); found != nil {
+			return found
+		}
+	}
+
+	return nil
+}
+
+func LoadLoot(gameDir string, lootFile string, definitions *Definitions) error {
+	lootYaml, err := util.GameFileData(gameDir, lootFile)
+	if err != nil {
+		log.Errorf("yamlFile.Get err %v ", err)
+		return err
+	}
+
+	err = schema.ValidateData(lootFile, FileSchema(), lootYaml)
+	if err != nil {
+		log.Errorf("invalid loot data: %s", err)
+		return err
+	}
+
+	tables := make(map[string]Table)
+	err = yaml.Unmarshal(lootYaml, &tables)
+	if err != nil {
+		log.Errorf("failed to parse loot data: %s", err)
+		return err
+	}
+
+	// Register the tables
+	for id, t := range tables {
+		definitions.Register(id, t)
+	}
+
+	return nil
+}
+
+// FileSchema describes a loot table data file, keyed by table ID.
+func FileSchema() *schema.Schema {
+	t := schema.Of(tableData{})
+	t.Properties["rolls"].Required = []string{"min", "max"}
+
+	entry := schema.Of(entryData{})
+	entry.Properties["weight"].Minimum = new(float64)
+	entry.Properties["quantity"].Required = []string{"min", "max"}
+	entry.Properties["when"].Properties["tier"].Required = []string{"min", "max"}
+	t.Properties["guaranteed"].Items = entry
+	t.Properties["entries"].Items = entry
+
+	return schema.Document("loot", "Defines weighted loot tables, which may nest, keyed by ID.", schema.MapOf(t))
+}
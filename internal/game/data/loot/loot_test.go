@@ -0,0 +1,188 @@
+//------------------------------------------------------------------------------
+//    Copyright 2019 Jeff Sharpe (zeropointx.io)
+//
+//    Licensed under the Apache License, Version 2.0 (the "License");
+//    you may not use this file except in compliance with the License.
+//    You may obtain a copy of the License at
+//
+//        http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS,
+//    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//    See the License for the specific language governing permissions and
+//    limitations under the License.
+//------------------------------------------------------------------------------
+
+package loot
+
+import (
+	"github.com/stretchr/testify/suite"
+	"github.com/zpxio/heromanager/internal/game/data/classifier"
+	"github.com/zpxio/heromanager/internal/game/data/item"
+	"github.com/zpxio/heromanager/internal/game/data/schema"
+	"math/rand"
+	"testing"
+)
+
+type LootTestSuite struct {
+	suite.Suite
+}
+
+func TestLootSuite(t *testing.T) {
+	suite.Run(t, new(LootTestSuite))
+}
+
+func testItems() *item.Definitions {
+	d := item.NewDefinitions()
+	for _, id := range []string{"Coin", "Gem", "Charm", "Ring"} {
+		d.Register(id, item.Item{Name: id})
+	}
+
+	return d
+}
+
+func testManifest() *classifier.ClassifierManifest {
+	m := classifier.NewManifest()
+	m.RegisterProfession("Hunter", classifier.BlankProfession())
+
+	return m
+}
+
+func loadTables() *Definitions {
+	d := NewDefinitions()
+	err := LoadLoot("testdata/game/data/loot", "test_loot_simple.yml", d)
+	if err != nil {
+		panic(err)
+	}
+
+	return d
+}
+
+func (s *LootTestSuite) TestLoadLoot() {
+	d := loadTables()
+
+	s.Equal([]string{"Chest", "Trinkets"}, d.All())
+	s.Nil(d.Validate(testItems(), testManifest()))
+
+	chest, found := d.Resolve("Chest")
+	s.Require().True(found)
+	s.Equal(Range{Min: 1, Max: 2}, chest.Rolls)
+	s.Equal(Range{Min: 1, Max: 3}, chest.Guaranteed[0].Quantity)
+	s.Equal(float32(3), chest.Entries[0].Weight)
+	s.Equal(&Range{Min: 2, Max: 5}, chest.Entries[1].When.Tier)
+
+	trinkets, _ := d.Resolve("Trinkets")
+	s.Equal(Range{Min: 1, Max: 1}, trinkets.Rolls)
+	s.Equal(Entry{Item: "Charm", Weight: 1, Quantity: Range{Min: 1, Max: 1}}, trinkets.Entries[0])
+	s.Equal([]string{"Hunter"}, trinkets.Entries[1].When.Professions)
+}
+
+func (s *LootTestSuite) TestLoadLoot_Schema() {
+	d := NewDefinitions()
+	err := LoadLoot("testdata/game/data/loot", "test_loot_bad_rolls.yml", d)
+
+	s.Require().NotNil(err)
+	_, ok := err.(*schema.ValidationError)
+	s.True(ok)
+	s.Empty(d.All())
+}
+
+func (s *LootTestSuite) TestAllows() {
+	c := Condition{Professions: []string{"Hunter"}, Tier: &Range{Min: 2, Max: 3}}
+
+	s.True(Condition{}.Allows(Context{}))
+	s.True(c.Allows(Context{Professions: []string{"Trader", "Hunter"}, Tier: 2}))
+	s.False(c.Allows(Context{Professions: []string{"Trader"}, Tier: 2}))
+	s.False(c.Allows(Context{Professions: []string{"Hunter"}, Tier: 4}))
+}
+
+func (s *LootTestSuite) TestRoll() {
+	d := loadTables()
+	rng := rand.New(rand.NewSource(1))
+
+	for i := 0; i < 50; i++ {
+		drops := d.Roll("Chest", Context{}, rng)
+		s.True(drops["Coin"] >= 1 && drops["Coin"] <= 3)
+		s.True(drops["Charm"] >= 1 && drops["Charm"] <= 2)
+		s.Len(drops, 2)
+	}
+
+	s.Empty(d.Roll("Missing", Context{}, rng))
+}
+
+func (s *LootTestSuite) TestRoll_Conditions() {
+	d := loadTables()
+	rng := rand.New(rand.NewSource(1))
+
+	seen := make(Drops)
+	for i := 0; i < 100; i++ {
+		seen.Add(d.Roll("Chest", Context{Professions: []string{"Hunter"}, Tier: 3}, rng))
+	}
+
+	s.Contains(seen, "Gem")
+	s.Contains(seen, "Ring")
+	s.Contains(seen, "Charm")
+}
+
+func (s *LootTestSuite) TestSimulate() {
+	d := loadTables()
+	rng := rand.New(rand.NewSource(1))
+
+	rates := d.Simulate("Trinkets", Context{Professions: []string{"Hunter"}}, 3000, rng)
+	s.Require().Len(rates, 2)
+	s.Equal("Charm", rates[0].Item)
+	s.InDelta(1.0/3, rates[0].Chance, 0.05)
+	s.Equal("Ring", rates[1].Item)
+	s.InDelta(2.0/3, rates[1].Chance, 0.05)
+	s.Equal(1, rates[1].Max)
+
+	rates = d.Simulate("Chest", Context{}, 3000, rng)
+	s.Equal("Coin", rates[1].Item)
+	s.Equal(1.0, rates[1].Chance)
+	s.InDelta(2, rates[1].Mean, 0.1)
+	s.Equal(3, rates[1].Max)
+}
+
+func (s *LootTestSuite) TestValidate() {
+	valid := func() map[string]Table {
+		return map[string]Table{
+			"Outer": {Rolls: Range{Min: 1, Max: 1}, Entries: []Entry{{Table: "Inner", Weight: 1, Quantity: Range{Min: 1, Max: 1}}}},
+			"Inner": {Rolls: Range{Min: 1, Max: 1}, Entries: []Entry{{Item: "Coin", Weight: 1, Quantity: Range{Min: 1, Max: 1}}}},
+		}
+	}
+
+	d := NewDefinitions()
+	for id, t := range valid() {
+		d.Register(id, t)
+	}
+	s.Nil(d.Validate(testItems(), testManifest()))
+
+	for name, mutate := range map[string]func(tables map[string]Table){
+		"rolls": func(tables map[string]Table) {
+			tables["Inner"] = Table{Rolls: Range{Min: 2, Max: 1}, Entries: tables["Inner"].Entries}
+		},
+		"empty":      func(tables map[string]Table) { tables["Inner"] = Table{Rolls: Range{Min: 1, Max: 1}} },
+		"both":       func(tables map[string]Table) { tables["Inner"].Entries[0].Table = "Outer" },
+		"neither":    func(tables map[string]Table) { tables["Inner"].Entries[0].Item = "" },
+		"item":       func(tables map[string]Table) { tables["Inner"].Entries[0].Item = "Sword" },
+		"table":      func(tables map[string]Table) { tables["Outer"].Entries[0].Table = "Missing" },
+		"weight":     func(tables map[string]Table) { tables["Inner"].Entries[0].Weight = -1 },
+		"quantity":   func(tables map[string]Table) { tables["Inner"].Entries[0].Quantity = Range{Min: 3, Max: 1} },
+		"tier":       func(tables map[string]Table) { tables["Inner"].Entries[0].When.Tier = &Range{Min: 3, Max: 1} },
+		"profession": func(tables map[string]Table) { tables["Inner"].Entries[0].When.Professions = []string{"Mage"} },
+		"cycle": func(tables map[string]Table) {
+			tables["Inner"].Entries[0].Item = ""
+			tables["Inner"].Entries[0].Table = "Outer"
+		},
+	} {
+		tables := valid()
+		mutate(tables)
+
+		d := NewDefinitions()
+		for id, t := range tables {
+			d.Register(id, t)
+		}
+		s.NotNil(d.Validate(testItems(), testManifest()), name)
+	}
+}
@@ -0,0 +1,134 @@
+//------------------------------------------------------------------------------
+//    Copyright 2019 Jeff Sharpe (zeropointx.io)
+//
+//    Licensed under the Apache License, Version 2.0 (the "License");
+//    you may not use this file except in compliance with the License.
+//    You may obtain a copy of the License at
+//
+//        http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS,
+//    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//    See the License for the specific language governing permissions and
+//    limitations under the License.
+//------------------------------------------------------------------------------
+
+package loot
+
+import (
+	"github.com/zpxio/heromanager/internal/game/util"
+	"math/rand"
+	"sort"
+)
+
+// Drops maps item IDs to dropped quantities.
+type Drops map[string]int
+
+// Add merges other drops into these.
+func (d Drops) Add(other Drops) {
+	for id, count := range other {
+		d[id] += count
+	}
+}
+
+// Roll evaluates a table for a context, returning everything it drops.
+// Unknown tables drop nothing.
+func (d *Definitions) Roll(id string, ctx Context, rng *rand.Rand) Drops {
+	drops := make(Drops)
+	d.roll(id, ctx, rng, drops, 0)
+
+	return drops
+}
+
+func (d *Definitions) roll(id string, ctx Context, rng *rand.Rand, drops Drops, depth int) {
+	t, found := d.tables[id]
+	if !found || depth >= MaxDepth {
+		return
+	}
+
+	for _, e := range t.Guaranteed {
+		if e.When.Allows(ctx) {
+			d.drop(e, ctx, rng, drops, depth)
+		}
+	}
+
+	options := make([]interface{}, 0, len(t.Entries))
+	for _, e := range t.Entries {
+		if e.Weight > 0 && e.When.Allows(ctx) {
+			options = append(options, e)
+		}
+	}
+	if len(options) == 0 {
+		return
+	}
+
+	for rolls := count(t.Rolls, rng); rolls > 0; rolls-- {
+		picked := options[util.Pick(options, rng.Float32())].(Entry)
+		d.drop(picked, ctx, rng, drops, depth)
+	}
+}
+
+func (d *Definitions) drop(e Entry, ctx Context, rng *rand.Rand, drops Drops, depth int) {
+	quantity := count(e.Quantity, rng)
+	if e.Table == "" {
+		if quantity > 0 {
+			drops[e.Item] += quantity
+		}
+		return
+	}
+
+	for ; quantity > 0; quantity-- {
+		d.roll(e.Table, ctx, rng, drops, depth+1)
+	}
+}
+
+func count(r Range, rng *rand.Rand) int {
+	if r.Max <= r.Min {
+		return r.Min
+	}
+
+	return r.Min + rng.Intn(r.Max-r.Min+1)
+}
+
+// Rate summarises how an item dropped over many simulated rolls.
+type Rate struct {
+	Item string `json:"item"`
+	// Chance is the fraction of rolls dropping at least one.
+	Chance float64 `json:"chance"`
+	// Mean is the average quantity dropped per roll.
+	Mean float64 `json:"mean"`
+	// Max is the largest quantity dropped by a single roll.
+	Max int `json:"max"`
+}
+
+// Simulate rolls a table repeatedly, returning the drop rate of every item
+// seen, sorted by item ID.
+func (d *Definitions) Simulate(id string, ctx Context, runs int, rng *rand.Rand) []Rate {
+	hits := make(map[string]int)
+	totals := make(Drops)
+	peaks := make(Drops)
+
+	for i := 0; i < runs; i++ {
+		for item, quantity := range d.Roll(id, ctx, rng) {
+			hits[item]++
+			totals[item] += quantity
+			if quantity > peaks[item] {
+				peaks[item] = quantity
+			}
+		}
+	}
+
+	rates := make([]Rate, 0, len(hits))
+	for item := range hits {
+		rates = append(rates, Rate{
+			Item:   item,
+			Chance: float64(hits[item]) / float64(runs),
+			Mean:   float64(totals[item]) / float64(runs),
+			Max:    peaks[item],
+		})
+	}
+	sort.Slice(rates, func(i, j int) bool { return rates[i].Item < rates[j].Item })
+
+	return rates
+}
@@ -0,0 +1,72 @@
+//------------------------------------------------------------------------------
+//    Copyright 2019 Jeff Sharpe (zeropointx.io)
+//
+//    Licensed under the Apache License, Version 2.0 (the "License");
+//    you may not use this file except in compliance with the License.
+//    You may obtain a copy of the License at
+//
+//        http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS,
+//    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//    See the License for the specific language governing permissions and
+//    limitations under the License.
+//------------------------------------------------------------------------------
+
+package progression
+
+import (
+	"github.com/zpxio/heromanager/internal/game/data/table"
+)
+
+// Gains divides the growth for a number of levels across the given keys,
+// weighted by the combined factor every modifier applies to each key. Keys
+// a modifier reduces to nothing do not grow; when no key can grow, the
+// points are split evenly.
+func (g Growth) Gains(keys []string, levels int, modifiers ...table.Modifier) map[string]float64 {
+	gains := make(map[string]float64, len(keys))
+	if levels <= 0 || len(keys) == 0 {
+		return gains
+	}
+
+	weights := make(map[string]float64, len(keys))
+	total := 0.0
+	for _, k := range keys {
+		weight := 1.0
+		for i := range modifiers {
+			weight *= modifiers[i].Factor(k)
+		}
+		if weight < 0.0 {
+			weight = 0.0
+		}
+		weights[k] = weight
+		total += weight
+	}
+
+	points := g.Points * float64(levels)
+	for _, k := range keys {
+		if total > 0.0 {
+			gains[k] = points * weights[k] / total
+		} else {
+			gains[k] = points / float64(len(keys))
+		}
+	}
+
+	return gains
+}
+
+// Apply raises a table of values by the growth for a number of levels,
+// returning the gain for each key. The table's policy still clamps every
+// value, so the recorded gain is the change actually made.
+func (g Growth) Apply(values *table.Values, keys []string, levels int, modifiers ...table.Modifier) map[string]float64 {
+	gains := g.Gains(keys, levels, modifiers...)
+
+	for _, k := range keys {
+		before := values.Get(k)
+		values.Set(k, before+gains[k])
+		gains[k] = values.Get(k) - before
+	}
+
+	return gains
+}
@@ -0,0 +1,72 @@
+//------------------------------------------------------------------------------
+//    Copyright 2019 Jeff Sharpe (zeropointx.io)
+//
+//    Licensed under the Apache License, Version 2.0 (the "License");
+//    you may not use this file except in compliance with the License.
+//    You may obtain a copy of the License at
+//
+//        http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS,
+//    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//    See the License for the specific language governing permissions and
+//    limitations under the License.
+//------------------------------------------------------------------------------
+
+package progression
+
+import (
+	"github.com/stretchr/testify/suite"
+	"github.com/zpxio/heromanager/internal/game/data/attributes"
+	"testing"
+)
+
+type GrowthTestSuite struct {
+	suite.Suite
+}
+
+func TestGrowthSuite(t *testing.T) {
+	suite.Run(t, new(GrowthTestSuite))
+}
+
+func (s *GrowthTestSuite) TestGains_Even() {
+	g := Growth{Points: 10}
+
+	gains := g.Gains([]string{attributes.Brawn, attributes.Vigor}, 2)
+
+	s.InDelta(10.0, gains[attributes.Brawn], 0.0001)
+	s.InDelta(10.0, gains[attributes.Vigor], 0.0001)
+}
+
+func (s *GrowthTestSuite) TestGains_Weighted() {
+	g := Growth{Points: 10}
+	race := attributes.NewAttributeModifier()
+	race.Load(map[string]float64{attributes.Brawn: 1.0})
+	caste := attributes.NewAttributeModifier()
+	caste.Load(map[string]float64{attributes.Vigor: -1.0})
+
+	gains := g.Gains([]string{attributes.Brawn, attributes.Insight, attributes.Vigor}, 1, race, caste)
+
+	s.InDelta(20.0/3.0, gains[attributes.Brawn], 0.0001)
+	s.InDelta(10.0/3.0, gains[attributes.Insight], 0.0001)
+	s.InDelta(0.0, gains[attributes.Vigor], 0.0001)
+}
+
+func (s *GrowthTestSuite) TestGains_None() {
+	g := Growth{Points: 10}
+
+	s.Empty(g.Gains([]string{attributes.Brawn}, 0))
+}
+
+func (s *GrowthTestSuite) TestApply_Clamped() {
+	g := Growth{Points: 20}
+	values := attributes.NewAttributeValues()
+	values.Set(attributes.Brawn, 195.0)
+
+	gains := g.Apply(&values, []string{attributes.Brawn, attributes.Vigor}, 1)
+
+	s.Equal(attributes.MaxAttributeValue, values.Get(attributes.Brawn))
+	s.InDelta(5.0, gains[attributes.Brawn], 0.0001)
+	s.InDelta(10.0, gains[attributes.Vigor], 0.0001)
+}
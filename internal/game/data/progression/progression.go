@@ -0,0 +1,212 @@
+//------------------------------------------------------------------------------
+//    Copyright 2019 Jeff Sharpe (zeropointx.io)
+//
+//    Licensed under the Apache License, Version 2.0 (the "License");
+//    you may not use this file except in compliance with the License.
+//    You may obtain a copy of the License at
+//
+//        http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS,
+//    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//    See the License for the specific language governing permissions and
+//    limitations under the License.
+//------------------------------------------------------------------------------
+
+package progression
+
+import (
+	"fmt"
+	log "github.com/sirupsen/logrus"
+	"github.com/zpxio/heromanager/internal/game/data/formula"
+	"github.com/zpxio/heromanager/internal/game/data/schema"
+	"github.com/zpxio/heromanager/internal/game/util"
+	"gopkg.in/yaml.v2"
+	"math"
+)
+
+// LevelVariable is the only variable available to a curve formula.
+const LevelVariable = "level"
+
+// Curve decides how much experience each level needs. Heroes start at level
+// one with no experience. Thresholds list the total experience needed for
+// each level from level two up; otherwise the formula computes it from the
+// level.
+type Curve struct {
+	MaxLevel   int                `yaml:"max" json:"max"`
+	Thresholds []int              `yaml:"thresholds" json:"thresholds,omitempty"`
+	Formula    formula.Expression `yaml:"formula" json:"formula,omitempty"`
+}
+
+// Growth raises attributes as heroes gain levels. Each level distributes
+// the points across every attribute, weighted by the factors of the hero's
+// attribute modifiers, so a race with a Brawn bonus grows stronger faster.
+type Growth struct {
+	Points float64 `yaml:"points" json:"points"`
+}
+
+// Recovery heals the wounds of heroes resting at the guild by Rate every
+// tick. Heroes away on an expedition do not recover, and without a rate
+// wounds never heal.
+type Recovery struct {
+	Rate float64 `yaml:"rate" json:"rate"`
+}
+
+// Config names the available level curves and the one heroes use.
+type Config struct {
+	Curve    string           `yaml:"curve" json:"curve"`
+	Curves   map[string]Curve `yaml:"curves" json:"curves"`
+	Growth   Growth           `yaml:"growth" json:"growth"`
+	Recovery Recovery         `yaml:"recovery" json:"recovery"`
+}
+
+func NewConfig() *Config {
+	return &Config{Curves: make(map[string]Curve)}
+}
+
+// Empty reports whether no progression data was loaded.
+func (c *Config) Empty() bool {
+	return c.Curve == "" && len(c.Curves) == 0
+}
+
+// DefaultCurve returns the curve heroes level along.
+func (c *Config) DefaultCurve() (*Curve, error) {
+	curve, found := c.Curves[c.Curve]
+	if !found {
+		return nil, fmt.Errorf("unknown level curve: %s", c.Curve)
+	}
+
+	return &curve, nil
+}
+
+// Validate ensures the default curve exists and every curve is well formed.
+func (c *Config) Validate() error {
+	for name, curve := range c.Curves {
+		if _, err := curve.thresholds(); err != nil {
+			return fmt.Errorf("level curve %s: %s", name, err)
+		}
+	}
+
+	if _, err := c.DefaultCurve(); err != nil {
+		return err
+	}
+
+	if c.Growth.Points < 0.0 {
+		return fmt.Errorf("attribute growth cannot be negative: %v", c.Growth.Points)
+	}
+
+	if c.Recovery.Rate < 0.0 {
+		return fmt.Errorf("wound recovery cannot be negative: %v", c.Recovery.Rate)
+	}
+
+	return nil
+}
+
+// thresholds lists the total experience needed for each level from level two
+// up to the maximum level, which must strictly increase.
+func (c Curve) thresholds() ([]int, error) {
+	if c.MaxLevel < 1 {
+		return nil, fmt.Errorf("needs a maximum level of at least 1")
+	}
+
+	hasFormula := c.Formula.String() != ""
+	if hasFormula == (len(c.Thresholds) > 0) && c.MaxLevel > 1 {
+		return nil, fmt.Errorf("needs either thresholds or a formula")
+	}
+
+	thresholds := c.Thresholds
+	if hasFormula {
+		for _, name := range c.Formula.Variables() {
+			if name != LevelVariable {
+				return nil, fmt.Errorf("formula references unknown value: %s", name)
+			}
+		}
+
+		thresholds = make([]int, 0, c.MaxLevel-1)
+		for level := 2; level <= c.MaxLevel; level++ {
+			xp, err := c.Formula.Evaluate(func(name string) (float64, bool) {
+				return float64(level), name == LevelVariable
+			})
+			if err != nil {
+				return nil, err
+			}
+			thresholds = append(thresholds, int(math.Ceil(xp)))
+		}
+	}
+
+	if len(thresholds) != c.MaxLevel-1 {
+		return nil, fmt.Errorf("needs %d thresholds for %d levels, found %d", c.MaxLevel-1, c.MaxLevel, len(thresholds))
+	}
+	previous := 0
+	for i, xp := range thresholds {
+		if xp <= previous {
+			return nil, fmt.Errorf("level %d needs more experience than level %d", i+2, i+1)
+		}
+		previous = xp
+	}
+
+	return thresholds, nil
+}
+
+// Required returns the total experience needed to reach a level.
+func (c Curve) Required(level int) int {
+	if level <= 1 {
+		return 0
+	}
+
+	thresholds, err := c.thresholds()
+	if err != nil || len(thresholds) == 0 {
+		return 0
+	}
+	if level > c.MaxLevel {
+		level = c.MaxLevel
+	}
+
+	return thresholds[level-2]
+}
+
+// Level returns the level reached with a total amount of experience.
+func (c Curve) Level(xp int) int {
+	thresholds, err := c.thresholds()
+	if err != nil {
+		return 1
+	}
+
+	level := 1
+	for _, required := range thresholds {
+		if xp < required {
+			break
+		}
+		level++
+	}
+
+	return level
+}
+
+func LoadProgression(gameDir string, progressionFile string, config *Config) error {
+	progressionYaml, err := util.GameFileData(gameDir, progressionFile)
+	if err != nil {
+		log.Errorf("yamlFile.Get err %v ", err)
+		return err
+	}
+
+	err = schema.ValidateData(progressionFile, FileSchema(), progressionYaml)
+	if err != nil {
+		log.Errorf("invalid progression data: %s", err)
+		return err
+	}
+
+	err = yaml.Unmarshal(progressionYaml, config)
+	if err != nil {
+		log.Errorf("failed to parse progression data: %s", err)
+		return err
+	}
+
+	return config.Validate()
+}
+
+// FileSchema describes a progression data file.
+func FileSchema() *schema.Schema {
+	return schema.Document("progression", "Defines level curves and attribute growth.", schema.Of(Config{}))
+}
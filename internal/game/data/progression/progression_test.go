@@ -0,0 +1,104 @@
+//------------------------------------------------------------------------------
+//    Copyright 2019 Jeff Sharpe (zeropointx.io)
+//
+//    Licensed under the Apache License, Version 2.0 (the "License");
+//    you may not use this file except in compliance with the License.
+//    You may obtain a copy of the License at
+//
+//        http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS,
+//    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//    See the License for the specific language governing permissions and
+//    limitations under the License.
+//------------------------------------------------------------------------------
+
+package progression
+
+import (
+	"github.com/stretchr/testify/suite"
+	"github.com/zpxio/heromanager/internal/game/data/formula"
+	"github.com/zpxio/heromanager/internal/game/data/schema"
+	"testing"
+)
+
+type ProgressionTestSuite struct {
+	suite.Suite
+}
+
+func TestProgressionSuite(t *testing.T) {
+	suite.Run(t, new(ProgressionTestSuite))
+}
+
+func (s *ProgressionTestSuite) TestLoadProgression() {
+	c := NewConfig()
+	err := LoadProgression("testdata/game/data/progression", "test_progression_simple.yml", c)
+
+	s.Require().Nil(err)
+	s.Equal("standard", c.Curve)
+	s.Equal(10.0, c.Growth.Points)
+	s.Equal(1.5, c.Recovery.Rate)
+
+	curve, err := c.DefaultCurve()
+	s.Require().Nil(err)
+	s.Equal(5, curve.MaxLevel)
+}
+
+func (s *ProgressionTestSuite) TestLoadProgression_NotIncreasing() {
+	c := NewConfig()
+	err := LoadProgression("testdata/game/data/progression", "test_progression_flat.yml", c)
+
+	s.NotNil(err)
+}
+
+func (s *ProgressionTestSuite) TestLoadProgression_Schema() {
+	c := NewConfig()
+	err := LoadProgression("testdata/game/data/race", "test_race_single.yml", c)
+
+	s.Require().NotNil(err)
+	_, ok := err.(*schema.ValidationError)
+	s.True(ok)
+}
+
+func (s *ProgressionTestSuite) TestCurve_Thresholds() {
+	curve := Curve{MaxLevel: 5, Thresholds: []int{100, 250, 450, 700}}
+
+	s.Equal(0, curve.Required(1))
+	s.Equal(100, curve.Required(2))
+	s.Equal(700, curve.Required(5))
+	s.Equal(700, curve.Required(9))
+
+	s.Equal(1, curve.Level(0))
+	s.Equal(1, curve.Level(99))
+	s.Equal(2, curve.Level(100))
+	s.Equal(4, curve.Level(699))
+	s.Equal(5, curve.Level(100000))
+}
+
+func (s *ProgressionTestSuite) TestCurve_Formula() {
+	curve := Curve{MaxLevel: 4, Formula: *formula.MustParse("level * level * 10")}
+
+	s.Equal(40, curve.Required(2))
+	s.Equal(90, curve.Required(3))
+	s.Equal(160, curve.Required(4))
+	s.Equal(3, curve.Level(100))
+}
+
+func (s *ProgressionTestSuite) TestValidate() {
+	valid := Config{Curve: "single", Curves: map[string]Curve{"single": {MaxLevel: 1}}}
+	s.Nil(valid.Validate())
+
+	for name, c := range map[string]Config{
+		"missing default": {Curve: "other", Curves: map[string]Curve{"single": {MaxLevel: 1}}},
+		"no levels":       {Curve: "zero", Curves: map[string]Curve{"zero": {}}},
+		"no thresholds":   {Curve: "empty", Curves: map[string]Curve{"empty": {MaxLevel: 3}}},
+		"both":            {Curve: "both", Curves: map[string]Curve{"both": {MaxLevel: 2, Thresholds: []int{10}, Formula: *formula.MustParse("level")}}},
+		"too few":         {Curve: "short", Curves: map[string]Curve{"short": {MaxLevel: 4, Thresholds: []int{10, 20}}}},
+		"unknown value":   {Curve: "f", Curves: map[string]Curve{"f": {MaxLevel: 3, Formula: *formula.MustParse("Brawn * level")}}},
+		"negative growth": {Curve: "single", Curves: map[string]Curve{"single": {MaxLevel: 1}}, Growth: Growth{Points: -1}},
+		"negative rate":   {Curve: "single", Curves: map[string]Curve{"single": {MaxLevel: 1}}, Recovery: Recovery{Rate: -1}},
+	} {
+		s.NotNil(c.Validate(), name)
+	}
+}
@@ -0,0 +1,241 @@
+//------------------------------------------------------------------------------
+//    Copyright 2019 Jeff Sharpe (zeropointx.io)
+//
+//    Licensed under the Apache License, Version 2.0 (the "License");
+//    you may not use this file except in compliance with the License.
+//    You may obtain a copy of the License at
+//
+//        http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS,
+//    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//    See the License for the specific language governing permissions and
+//    limitations under the License.
+//------------------------------------------------------------------------------
+
+package quest
+
+import (
+	"fmt"
+	log "github.com/sirupsen/logrus"
+	"github.com/zpxio/heromanager/internal/game/data/damage"
+	"github.com/zpxio/heromanager/internal/game/data/schema"
+	"github.com/zpxio/heromanager/internal/game/data/skills"
+	"github.com/zpxio/heromanager/internal/game/data/table"
+	"github.com/zpxio/heromanager/internal/game/util"
+	"gopkg.in/yaml.v2"
+	"sort"
+)
+
+// Quest is an expedition heroes can be sent on. It takes a number of ticks,
+// then resolves with a fight against any enemies met, and checks against the
+// party's best heroes.
+type Quest struct {
+	Name     string `yaml:"name" json:"name"`
+	Duration uint64 `yaml:"duration" json:"duration"`
+	Party    Party  `yaml:"party" json:"party"`
+
+	// Difficulty selects the encounter tables the party meets enemies from,
+	// unless Encounter names a table. Quests without either meet none.
+	Difficulty float64 `yaml:"difficulty" json:"difficulty,omitempty"`
+	Encounter  string  `yaml:"encounter" json:"encounter,omitempty"`
+
+	// Tier ranks the quest for loot, which may drop only at some tiers.
+	Tier int `yaml:"tier" json:"tier,omitempty"`
+
+	// Requires sets the minimum value of attributes or skills every party
+	// member must have, keyed by attribute or skill.
+	Requires map[string]float64 `yaml:"requires" json:"requires,omitempty"`
+	Checks   []Check            `yaml:"checks" json:"checks"`
+	Hazards  []Hazard           `yaml:"hazards" json:"hazards,omitempty"`
+	Rewards  Rewards            `yaml:"rewards" json:"rewards"`
+}
+
+// Party bounds the number of heroes on a quest.
+type Party struct {
+	Min int `yaml:"min" json:"min"`
+	Max int `yaml:"max" json:"max"`
+}
+
+// Check is rolled by the party member with the best rating in either a
+// skill or a single attribute.
+type Check struct {
+	Skill      string  `yaml:"skill" json:"skill,omitempty"`
+	Attribute  string  `yaml:"attribute" json:"attribute,omitempty"`
+	Difficulty float64 `yaml:"difficulty" json:"difficulty"`
+}
+
+// Hazard damages every party member when the quest resolves, by half as
+// much when the quest succeeds. Resistances to the damage type apply.
+type Hazard struct {
+	Type   string  `yaml:"type" json:"type"`
+	Damage float64 `yaml:"damage" json:"damage"`
+}
+
+// Rewards are given to every party member when the quest succeeds, except
+// for gold, paid into the guild treasury, and loot, which is rolled once
+// from the named table into the guild stash.
+type Rewards struct {
+	Experience int    `yaml:"xp" json:"xp"`
+	Gold       int    `yaml:"gold" json:"gold,omitempty"`
+	Loot       string `yaml:"loot" json:"loot,omitempty"`
+}
+
+// Stat returns the name of the attribute or skill the check rolls against.
+func (c Check) Stat() string {
+	if c.Skill != "" {
+		return c.Skill
+	}
+
+	return c.Attribute
+}
+
+// Rated returns the skill used to rate heroes for the check. An attribute
+// check rates heroes as an untrained skill governed by that attribute alone.
+func (c Check) Rated(definitions *skills.Definitions) (*skills.Skill, error) {
+	if c.Skill == "" {
+		return &skills.Skill{Name: c.Attribute, Attributes: []string{c.Attribute}}, nil
+	}
+
+	s, found := definitions.Resolve(c.Skill)
+	if !found {
+		return nil, fmt.Errorf("unknown skill: %s", c.Skill)
+	}
+
+	return s, nil
+}
+
+type Definitions struct {
+	quests map[string]Quest
+	keys   []string
+}
+
+func NewDefinitions() *Definitions {
+	return &Definitions{
+		quests: make(map[string]Quest),
+		keys:   make([]string, 0),
+	}
+}
+
+func (d *Definitions) Register(id string, q Quest) {
+	log.Infof("Registering Quest: %s", id)
+	d.quests[id] = q
+	d.keys = nil
+}
+
+func (d *Definitions) Resolve(id string) (*Quest, bool) {
+	q, found := d.quests[id]
+
+	if found {
+		return &q, true
+	} else {
+		return nil, false
+	}
+}
+
+func (d *Definitions) All() []string {
+	if d.keys == nil {
+		d.keys = make([]string, 0, len(d.quests))
+		for id := range d.quests {
+			d.keys = append(d.keys, id)
+		}
+		sort.Strings(d.keys)
+	}
+
+	return d.keys
+}
+
+// Validate ensures every quest can be run, and only refers to known
+// attributes, skills and damage types.
+func (d *Definitions) Validate(attributes *table.Policy, definitions *skills.Definitions) error {
+	known := func(name string) bool {
+		_, isSkill := definitions.Resolve(name)
+		return isSkill || attributes.ValidKey(name)
+	}
+
+	for _, id := range d.All() {
+		q := d.quests[id]
+		if q.Duration < 1 {
+			return fmt.Errorf("quest %s needs a duration of at least one tick", id)
+		}
+		if q.Party.Min < 1 || q.Party.Max < q.Party.Min {
+			return fmt.Errorf("quest %s has an invalid party size: %d-%d", id, q.Party.Min, q.Party.Max)
+		}
+		if q.Difficulty < 0 {
+			return fmt.Errorf("quest %s has a negative difficulty: %v", id, q.Difficulty)
+		}
+		if q.Rewards.Gold < 0 {
+			return fmt.Errorf("quest %s rewards negative gold: %d", id, q.Rewards.Gold)
+		}
+		if q.Tier < 0 {
+			return fmt.Errorf("quest %s has a negative tier: %d", id, q.Tier)
+		}
+		for name := range q.Requires {
+			if !known(name) {
+				return fmt.Errorf("quest %s requires unknown attribute or skill: %s", id, name)
+			}
+		}
+		for _, c := range q.Checks {
+			if (c.Skill == "") == (c.Attribute == "") {
+				return fmt.Errorf("quest %s has a check needing either a skill or an attribute", id)
+			}
+			if c.Attribute != "" && !attributes.ValidKey(c.Attribute) {
+				return fmt.Errorf("quest %s checks unknown attribute: %s", id, c.Attribute)
+			}
+			if _, err := c.Rated(definitions); err != nil {
+				return fmt.Errorf("quest %s checks %s", id, err)
+			}
+		}
+		for _, h := range q.Hazards {
+			if !damage.ValidType(h.Type) {
+				return fmt.Errorf("quest %s has a hazard of unknown damage type: %s", id, h.Type)
+			}
+		}
+	}
+
+	return nil
+}
+
+func LoadQuests(gameDir string, questFile string, definitions *Definitions) error {
+	questYaml, err := util.GameFileData(gameDir, questFile)
+	if err != nil {
+		log.Errorf("yamlFile.Get err %v ", err)
+		return err
+	}
+
+	err = schema.ValidateData(questFile, FileSchema(), questYaml)
+	if err != nil {
+		log.Errorf("invalid quest data: %s", err)
+		return err
+	}
+
+	quests := make(map[string]Quest)
+	err = yaml.Unmarshal(questYaml, &quests)
+	if err != nil {
+		log.Errorf("failed to parse quest data: %s", err)
+		return err
+	}
+
+	// Register the quests
+	for id, q := range quests {
+		definitions.Register(id, q)
+	}
+
+	return nil
+}
+
+// FileSchema describes a quest data file, keyed by quest ID.
+func FileSchema() *schema.Schema {
+	q := schema.Of(Quest{})
+	q.Required = []string{"duration", "party"}
+	q.Properties["difficulty"].Minimum = new(float64)
+	q.Properties["tier"].Minimum = new(float64)
+	q.Properties["rewards"].Properties["gold"].Minimum = new(float64)
+	q.Properties["party"].Required = []string{"min", "max"}
+	q.Properties["checks"].Items.Required = []string{"difficulty"}
+	q.Properties["hazards"].Items.Properties["type"] = schema.Enum(damage.Types()...)
+	q.Properties["hazards"].Items.Required = []string{"type", "damage"}
+
+	return schema.Document("quests", "Defines quests heroes can be sent on, keyed by ID.", schema.MapOf(q))
+}
@@ -0,0 +1,106 @@
+//------------------------------------------------------------------------------
+//    Copyright 2019 Jeff Sharpe (zeropointx.io)
+//
+//    Licensed under the Apache License, Version 2.0 (the "License");
+//    you may not use this file except in compliance with the License.
+//    You may obtain a copy of the License at
+//
+//        http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS,
+//    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//    See the License for the specific language governing permissions and
+//    limitations under the License.
+//------------------------------------------------------------------------------
+
+package quest
+
+import (
+	"github.com/stretchr/testify/suite"
+	"github.com/zpxio/heromanager/internal/game/data/attributes"
+	"github.com/zpxio/heromanager/internal/game/data/damage"
+	"github.com/zpxio/heromanager/internal/game/data/schema"
+	"github.com/zpxio/heromanager/internal/game/data/skills"
+	"testing"
+)
+
+type QuestTestSuite struct {
+	suite.Suite
+}
+
+func TestQuestSuite(t *testing.T) {
+	suite.Run(t, new(QuestTestSuite))
+}
+
+func testSkills() *skills.Definitions {
+	d := skills.NewDefinitions()
+	d.Register("Lore", skills.Skill{Name: "Lore", Attributes: []string{attributes.Insight}})
+
+	return d
+}
+
+func validQuest() Quest {
+	return Quest{Name: "Test", Duration: 1, Party: Party{Min: 1, Max: 1}}
+}
+
+func (s *QuestTestSuite) TestLoadQuests() {
+	d := NewDefinitions()
+	err := LoadQuests("testdata/game/data/quest", "test_quest_simple.yml", d)
+
+	s.Require().Nil(err)
+	s.Equal([]string{"Cellar", "Impossible"}, d.All())
+	s.Nil(d.Validate(attributes.Policy(), testSkills()))
+
+	q, found := d.Resolve("Cellar")
+	s.Require().True(found)
+	s.Equal(uint64(2), q.Duration)
+	s.Equal(Party{Min: 1, Max: 2}, q.Party)
+	s.Equal(20.0, q.Requires[attributes.Brawn])
+	s.Equal([]Check{{Attribute: attributes.Brawn}, {Skill: "Lore"}}, q.Checks)
+	s.Equal([]Hazard{{Type: damage.Toxin, Damage: 4}}, q.Hazards)
+	s.Equal(150, q.Rewards.Experience)
+}
+
+func (s *QuestTestSuite) TestLoadQuests_Schema() {
+	d := NewDefinitions()
+	err := LoadQuests("testdata/game/data/quest", "test_quest_bad_hazard.yml", d)
+
+	s.Require().NotNil(err)
+	_, ok := err.(*schema.ValidationError)
+	s.True(ok)
+	s.Empty(d.All())
+}
+
+func (s *QuestTestSuite) TestValidate() {
+	for name, mutate := range map[string]func(q *Quest){
+		"duration":        func(q *Quest) { q.Duration = 0 },
+		"party":           func(q *Quest) { q.Party = Party{Min: 2, Max: 1} },
+		"empty party":     func(q *Quest) { q.Party = Party{} },
+		"requirement":     func(q *Quest) { q.Requires = map[string]float64{"Juggling": 10} },
+		"check skill":     func(q *Quest) { q.Checks = []Check{{Skill: "Juggling"}} },
+		"check attribute": func(q *Quest) { q.Checks = []Check{{Attribute: "Luck"}} },
+		"check both":      func(q *Quest) { q.Checks = []Check{{Skill: "Lore", Attribute: attributes.Insight}} },
+		"check neither":   func(q *Quest) { q.Checks = []Check{{Difficulty: 10}} },
+		"hazard":          func(q *Quest) { q.Hazards = []Hazard{{Type: "Lava", Damage: 1}} },
+		"difficulty":      func(q *Quest) { q.Difficulty = -1 },
+	} {
+		q := validQuest()
+		mutate(&q)
+
+		d := NewDefinitions()
+		d.Register("Test", q)
+		s.NotNil(d.Validate(attributes.Policy(), testSkills()), name)
+	}
+}
+
+func (s *QuestTestSuite) TestCheck_Rated() {
+	rated, err := Check{Attribute: attributes.Vigor}.Rated(testSkills())
+	s.Require().Nil(err)
+	s.Equal([]string{attributes.Vigor}, rated.Attributes)
+
+	rated, err = Check{Skill: "Lore"}.Rated(testSkills())
+	s.Require().Nil(err)
+	s.Equal("Lore", rated.Name)
+	s.Equal("Lore", Check{Skill: "Lore"}.Stat())
+}
@@ -0,0 +1,174 @@
+//------------------------------------------------------------------------------
+//    Copyright 2019 Jeff Sharpe (zeropointx.io)
+//
+//    Licensed under the Apache License, Version 2.0 (the "License");
+//    you may not use this file except in compliance with the License.
+//    You may obtain a copy of the License at
+//
+//        http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS,
+//    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//    See the License for the specific language governing permissions and
+//    limitations under the License.
+//------------------------------------------------------------------------------
+
+package recruitment
+
+import (
+	"fmt"
+	log "github.com/sirupsen/logrus"
+	"github.com/zpxio/heromanager/internal/game/data/classifier"
+	"github.com/zpxio/heromanager/internal/game/data/rolling"
+	"github.com/zpxio/heromanager/internal/game/data/schema"
+	"github.com/zpxio/heromanager/internal/game/util"
+	"gopkg.in/yaml.v2"
+	"sort"
+)
+
+// Config sets up the pool of candidates heroes are hired from. The pool
+// refreshes every interval of ticks, generating candidates until it holds
+// its size, and each candidate leaves once its expiry has passed.
+type Config struct {
+	Interval uint64 `yaml:"interval" json:"interval"`
+	Size     int    `yaml:"size" json:"size"`
+	Expiry   uint64 `yaml:"expiry" json:"expiry"`
+
+	// Strategy names the rolling strategy for candidate attributes, or the
+	// default strategy when empty.
+	Strategy string `yaml:"strategy" json:"strategy,omitempty"`
+
+	// Options restrict the classifiers candidates are generated with, keyed
+	// by dimension target. Weights scale the chance of each classifier,
+	// keyed by dimension target and then ID, and Uniform ignores the
+	// classifiers' own weights.
+	Options map[string][]string           `yaml:"options" json:"options,omitempty"`
+	Weights map[string]map[string]float32 `yaml:"weights" json:"weights,omitempty"`
+	Uniform bool                          `yaml:"uniform" json:"uniform,omitempty"`
+
+	Fee Fee `yaml:"fee" json:"fee"`
+}
+
+// Fee is the cost of hiring a candidate: a base amount plus a number of the
+// candidate's wages.
+type Fee struct {
+	Base  int `yaml:"base" json:"base"`
+	Wages int `yaml:"wages" json:"wages"`
+}
+
+// For returns the fee for a candidate earning a wage.
+func (f Fee) For(wage int) int {
+	return f.Base + f.Wages*wage
+}
+
+func NewConfig() *Config {
+	return &Config{}
+}
+
+// Empty reports whether no recruitment data was loaded.
+func (c *Config) Empty() bool {
+	return c.Interval == 0 && c.Size == 0
+}
+
+// Due reports whether a pool last refreshed at one tick refreshes at
+// another. A pool that never refreshed is always due.
+func (c *Config) Due(refreshed uint64, tick uint64) bool {
+	return c.Interval > 0 && (refreshed == 0 || tick >= refreshed+c.Interval)
+}
+
+// Validate ensures the pool refreshes and holds candidates, that amounts are
+// not negative, and that the strategy and every classifier named are known.
+func (c *Config) Validate(manifest *classifier.ClassifierManifest, strategies *rolling.Config) error {
+	if c.Interval < 1 || c.Expiry < 1 {
+		return fmt.Errorf("recruitment needs an interval and expiry of at least one tick")
+	}
+	if c.Size < 1 {
+		return fmt.Errorf("recruitment needs a pool size of at least one")
+	}
+	if c.Fee.Base < 0 || c.Fee.Wages < 0 {
+		return fmt.Errorf("recruitment fees cannot be negative")
+	}
+
+	var err error
+	if c.Strategy != "" {
+		_, err = strategies.Strategy(c.Strategy)
+	} else {
+		_, err = strategies.DefaultStrategy()
+	}
+	if err != nil {
+		return fmt.Errorf("recruitment cannot roll attributes: %s", err)
+	}
+
+	targets := make([]string, 0, len(c.Options)+len(c.Weights))
+	for target := range c.Options {
+		targets = append(targets, target)
+	}
+	for target := range c.Weights {
+		targets = append(targets, target)
+	}
+	sort.Strings(targets)
+	declared := make(map[string]bool)
+	for _, d := range manifest.Dimensions() {
+		declared[d.Target] = true
+	}
+	for _, target := range targets {
+		if !declared[target] {
+			return fmt.Errorf("recruitment names unknown dimension: %s", target)
+		}
+	}
+
+	for _, d := range manifest.Dimensions() {
+		for _, id := range c.Options[d.Target] {
+			if _, found := manifest.Resolve(d.Target, id); !found {
+				return fmt.Errorf("recruitment offers unknown %s: %s", d.Kind, id)
+			}
+		}
+		for id, factor := range c.Weights[d.Target] {
+			if _, found := manifest.Resolve(d.Target, id); !found {
+				return fmt.Errorf("recruitment weighs unknown %s: %s", d.Kind, id)
+			}
+			if factor < 0 {
+				return fmt.Errorf("recruitment weighs %s %s negatively", d.Kind, id)
+			}
+		}
+	}
+
+	return nil
+}
+
+func LoadRecruitment(gameDir string, recruitmentFile string, config *Config) error {
+	recruitmentYaml, err := util.GameFileData(gameDir, recruitmentFile)
+	if err != nil {
+		log.Errorf("yamlFile.Get err %v ", err)
+		return err
+	}
+
+	err = schema.ValidateData(recruitmentFile, FileSchema(), recruitmentYaml)
+	if err != nil {
+		log.Errorf("invalid recruitment data: %s", err)
+		return err
+	}
+
+	err = yaml.Unmarshal(recruitmentYaml, config)
+	if err != nil {
+		log.Errorf("failed to parse recruitment data: %s", err)
+		return err
+	}
+
+	return nil
+}
+
+// FileSchema describes a recruitment data file.
+func FileSchema() *schema.Schema {
+	c := schema.Of(Config{})
+	c.Required = []string{"interval", "size", "expiry"}
+	one := 1.0
+	for _, name := range []string{"interval", "size", "expiry"} {
+		c.Properties[name].Minimum = &one
+	}
+	c.Properties["fee"].Properties["base"].Minimum = new(float64)
+	c.Properties["fee"].Properties["wages"].Minimum = new(float64)
+
+	return schema.Document("recruitment", "Defines the pool of candidates heroes are hired from.", c)
+}
@@ -0,0 +1,115 @@
+//------------------------------------------------------------------------------
+//    Copyright 2019 Jeff Sharpe (zeropointx.io)
+//
+//    Licensed under the Apache License, Version 2.0 (the "License");
+//    you may not use this file except in compliance with the License.
+//    You may obtain a copy of the License at
+//
+//        http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS,
+//    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//    See the License for the specific language governing permissions and
+//    limitations under the License.
+//------------------------------------------------------------------------------
+
+package recruitment
+
+import (
+	"github.com/stretchr/testify/suite"
+	"github.com/zpxio/heromanager/internal/game/data/classifier"
+	"github.com/zpxio/heromanager/internal/game/data/rolling"
+	"github.com/zpxio/heromanager/internal/game/data/schema"
+	"testing"
+)
+
+type RecruitmentTestSuite struct {
+	suite.Suite
+}
+
+func TestRecruitmentSuite(t *testing.T) {
+	suite.Run(t, new(RecruitmentTestSuite))
+}
+
+func testManifest() *classifier.ClassifierManifest {
+	m := classifier.NewManifest()
+	m.RegisterRace("Dwarf", classifier.BlankRace())
+	m.RegisterRace("Elf", classifier.BlankRace())
+	m.RegisterCaste("Noble", classifier.BlankCaste())
+
+	return m
+}
+
+func testStrategies() *rolling.Config {
+	c := rolling.NewConfig()
+	c.Strategies["dice"] = rolling.Spec{Type: "dice", Dice: 3, Sides: 6, Scale: 5}
+
+	return c
+}
+
+func loadConfig() *Config {
+	c := NewConfig()
+	err := LoadRecruitment("testdata/game/data/recruitment", "test_recruitment_simple.yml", c)
+	if err != nil {
+		panic(err)
+	}
+
+	return c
+}
+
+func (s *RecruitmentTestSuite) TestLoadRecruitment() {
+	c := loadConfig()
+
+	s.False(c.Empty())
+	s.Equal(uint64(10), c.Interval)
+	s.Equal(4, c.Size)
+	s.Equal(uint64(30), c.Expiry)
+	s.Equal(map[string][]string{"races": {"Dwarf", "Elf"}}, c.Options)
+	s.Equal(float32(0.5), c.Weights["castes"]["Noble"])
+	s.Equal(Fee{Base: 25, Wages: 3}, c.Fee)
+	s.Nil(c.Validate(testManifest(), testStrategies()))
+}
+
+func (s *RecruitmentTestSuite) TestLoadRecruitment_Schema() {
+	c := NewConfig()
+	err := LoadRecruitment("testdata/game/data/recruitment", "test_recruitment_no_size.yml", c)
+
+	s.Require().NotNil(err)
+	_, ok := err.(*schema.ValidationError)
+	s.True(ok)
+}
+
+func (s *RecruitmentTestSuite) TestDue() {
+	c := Config{Interval: 10}
+
+	s.True(c.Due(0, 3))
+	s.False(c.Due(3, 12))
+	s.True(c.Due(3, 13))
+	s.False(NewConfig().Due(0, 3))
+}
+
+func (s *RecruitmentTestSuite) TestFee() {
+	s.Equal(25, Fee{Base: 25}.For(7))
+	s.Equal(46, Fee{Base: 25, Wages: 3}.For(7))
+}
+
+func (s *RecruitmentTestSuite) TestValidate() {
+	for name, mutate := range map[string]func(c *Config){
+		"interval":  func(c *Config) { c.Interval = 0 },
+		"size":      func(c *Config) { c.Size = 0 },
+		"expiry":    func(c *Config) { c.Expiry = 0 },
+		"fee":       func(c *Config) { c.Fee.Base = -1 },
+		"strategy":  func(c *Config) { c.Strategy = "heroic" },
+		"default":   func(c *Config) { c.Strategy = "" },
+		"dimension": func(c *Config) { c.Options["faiths"] = []string{"Sun"} },
+		"option":    func(c *Config) { c.Options["races"] = []string{"Orc"} },
+		"weighed":   func(c *Config) { c.Weights["castes"]["Serf"] = 1 },
+		"negative":  func(c *Config) { c.Weights["castes"]["Noble"] = -1 },
+	} {
+		c := loadConfig()
+		mutate(c)
+
+		s.NotNil(c.Validate(testManifest(), testStrategies()), name)
+	}
+}
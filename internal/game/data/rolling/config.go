@@ -0,0 +1,220 @@
+//------------------------------------------------------------------------------
+//    Copyright 2019 Jeff Sharpe (zeropointx.io)
+//
+//    Licensed under the Apache License, Version 2.0 (the "License");
+//    you may not use this file except in compliance with the License.
+//    You may obtain a copy of the License at
+//
+//        http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS,
+//    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//    See the License for the specific language governing permissions and
+//    limitations under the License.
+//------------------------------------------------------------------------------
+
+package rolling
+
+import (
+	"fmt"
+	log "github.com/sirupsen/logrus"
+	"github.com/zpxio/heromanager/internal/game/data/schema"
+	"github.com/zpxio/heromanager/internal/game/util"
+	"gopkg.in/yaml.v2"
+)
+
+// Spec is the data form of a rolling strategy. Only the fields relevant to
+// the declared type are used.
+type Spec struct {
+	Type string `yaml:"type" json:"type"`
+
+	Dice  int     `yaml:"dice" json:"dice,omitempty"`
+	Sides int     `yaml:"sides" json:"sides,omitempty"`
+	Scale float64 `yaml:"scale" json:"scale,omitempty"`
+	Bonus float64 `yaml:"bonus" json:"bonus,omitempty"`
+
+	Mean   float64 `yaml:"mean" json:"mean,omitempty"`
+	StdDev float64 `yaml:"stddev" json:"stddev,omitempty"`
+
+	Budget  float64 `yaml:"budget" json:"budget,omitempty"`
+	Minimum float64 `yaml:"minimum" json:"minimum,omitempty"`
+	Step    float64 `yaml:"step" json:"step,omitempty"`
+
+	Templates map[string]map[string]float64 `yaml:"templates" json:"templates,omitempty"`
+	Variance  float64                       `yaml:"variance" json:"variance,omitempty"`
+	Fallback  string                        `yaml:"fallback" json:"fallback,omitempty"`
+}
+
+// Config names the available strategies and the one used by default.
+type Config struct {
+	Default    string          `yaml:"default" json:"default"`
+	Strategies map[string]Spec `yaml:"strategies" json:"strategies"`
+}
+
+func NewConfig() *Config {
+	return &Config{Strategies: make(map[string]Spec)}
+}
+
+func (c *Config) DefaultStrategy() (Strategy, error) {
+	return c.Strategy(c.Default)
+}
+
+func (c *Config) Strategy(name string) (Strategy, error) {
+	return c.build(name, map[string]bool{})
+}
+
+func (c *Config) build(name string, visited map[string]bool) (Strategy, error) {
+	if visited[name] {
+		return nil, fmt.Errorf("rolling strategy fallback cycle at: %s", name)
+	}
+	visited[name] = true
+
+	spec, found := c.Strategies[name]
+	if !found {
+		return nil, fmt.Errorf("unknown rolling strategy: %s", name)
+	}
+
+	switch spec.Type {
+	case TypeDice:
+		if spec.Dice < 1 || spec.Sides < 1 {
+			return nil, fmt.Errorf("rolling strategy %s needs positive dice and sides", name)
+		}
+		scale := spec.Scale
+		if scale == 0.0 {
+			scale = 1.0
+		}
+		return Dice{Count: spec.Dice, Sides: spec.Sides, Scale: scale, Bonus: spec.Bonus}, nil
+	case TypeNormal:
+		return Normal{Mean: spec.Mean, StdDev: spec.StdDev}, nil
+	case TypePointBuy:
+		return PointBuy{Budget: spec.Budget, Minimum: spec.Minimum, Step: spec.Step}, nil
+	case TypeTemplate:
+		t := Template{Templates: spec.Templates, Variance: spec.Variance}
+		if spec.Fallback != "" {
+			fallback, err := c.build(spec.Fallback, visited)
+			if err != nil {
+				return nil, err
+			}
+			t.Fallback = fallback
+		}
+		return t, nil
+	}
+
+	return nil, fmt.Errorf("rolling strategy %s has unknown type: %s", name, spec.Type)
+}
+
+// Validate ensures every declared strategy can be built.
+func (c *Config) Validate() error {
+	for name := range c.Strategies {
+		if _, err := c.Strategy(name); err != nil {
+			return err
+		}
+	}
+
+	if _, err := c.DefaultStrategy(); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func LoadRolling(gameDir string, rollingFile string, config *Config) error {
+	rollingYaml, err := util.GameFileData(gameDir, rollingFile)
+	if err != nil {
+		log.Errorf("yamlFile.Get err %v ", err)
+		return err
+	}
+
+	err = schema.ValidateData(rollingFile, FileSchema(), rollingYaml)
+	if err != nil {
+		log.Errorf("invalid rolling data: %s", err)
+		return err
+	}
+
+	err = yaml.Unmarshal(rollingYaml, config)
+	if err != nil {
+		log.Errorf("failed to parse rolling data: %s", err)
+		return err
+	}
+
+	return config.Validate()
+}
+
+// Layer is a set of changes to the rolling strategies, as shipped by a
+// content pack. A patch replaces the whole strategy, and a non-empty default
+// replaces the default strategy.
+type Layer struct {
+	Default string          `yaml:"default" json:"default,omitempty"`
+	Add     map[string]Spec `yaml:"add" json:"add"`
+	Patch   map[string]Spec `yaml:"patch" json:"patch"`
+	Remove  []string        `yaml:"remove" json:"remove"`
+}
+
+// Apply removes, adds and then patches strategies. Adding an existing
+// strategy, or patching or removing a missing one, is an error and leaves
+// the config unchanged. The result is not validated, since a later layer may
+// complete it.
+func (c *Config) Apply(source string, layer Layer) error {
+	exists := make(map[string]bool)
+	for name := range c.Strategies {
+		exists[name] = true
+	}
+
+	for _, name := range layer.Remove {
+		if !exists[name] {
+			return fmt.Errorf("%s cannot remove unknown rolling strategy: %s", source, name)
+		}
+		exists[name] = false
+	}
+	for name := range layer.Add {
+		if exists[name] {
+			return fmt.Errorf("%s cannot add existing rolling strategy: %s", source, name)
+		}
+		exists[name] = true
+	}
+	for name := range layer.Patch {
+		if !exists[name] {
+			return fmt.Errorf("%s cannot patch unknown rolling strategy: %s", source, name)
+		}
+	}
+
+	for _, name := range layer.Remove {
+		delete(c.Strategies, name)
+	}
+	for name, spec := range layer.Add {
+		c.Strategies[name] = spec
+	}
+	for name, spec := range layer.Patch {
+		c.Strategies[name] = spec
+	}
+	if layer.Default != "" {
+		c.Default = layer.Default
+	}
+
+	return nil
+}
+
+// LoadLayer applies a rolling strategy layer file.
+func LoadLayer(gameDir string, layerFile string, source string, config *Config) error {
+	layerYaml, err := util.GameFileData(gameDir, layerFile)
+	if err != nil {
+		log.Errorf("yamlFile.Get err %v ", err)
+		return err
+	}
+
+	err = schema.ValidateData(source+"/"+layerFile, LayerSchema(), layerYaml)
+	if err != nil {
+		log.Errorf("invalid rolling layer: %s", err)
+		return err
+	}
+
+	layer := Layer{}
+	err = yaml.UnmarshalStrict(layerYaml, &layer)
+	if err != nil {
+		log.Errorf("failed to parse rolling layer: %s", err)
+		return err
+	}
+
+	return config.Apply(source+"/"+layerFile, layer)
+}
@@ -0,0 +1,117 @@
+//------------------------------------------------------------------------------
+//    Copyright 2019 Jeff Sharpe (zeropointx.io)
+//
+//    Licensed under the Apache License, Version 2.0 (the "License");
+//    you may not use this file except in compliance with the License.
+//    You may obtain a copy of the License at
+//
+//        http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS,
+//    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//    See the License for the specific language governing permissions and
+//    limitations under the License.
+//------------------------------------------------------------------------------
+
+package rolling
+
+import (
+	"github.com/stretchr/testify/suite"
+	"github.com/zpxio/heromanager/internal/game/data/schema"
+	"testing"
+)
+
+type ConfigTestSuite struct {
+	suite.Suite
+}
+
+func TestConfigSuite(t *testing.T) {
+	suite.Run(t, new(ConfigTestSuite))
+}
+
+func (s *ConfigTestSuite) TestLoadRolling() {
+	c := NewConfig()
+	err := LoadRolling("testdata/game/data/rolling", "test_rolling_simple.yml", c)
+
+	s.Require().Nil(err)
+	s.Equal("dice", c.Default)
+	s.Len(c.Strategies, 4)
+
+	d, err := c.DefaultStrategy()
+	s.Require().Nil(err)
+	s.Equal(Dice{Count: 3, Sides: 6, Scale: 5}, d)
+
+	p, err := c.Strategy("points")
+	s.Require().Nil(err)
+	s.Equal(PointBuy{Budget: 250, Minimum: 20, Step: 5}, p)
+
+	r, err := c.Strategy("racial")
+	s.Require().Nil(err)
+	s.Require().IsType(Template{}, r)
+	s.Equal(d, r.(Template).Fallback)
+}
+
+func (s *ConfigTestSuite) TestLoadRolling_Cycle() {
+	c := NewConfig()
+	err := LoadRolling("testdata/game/data/rolling", "test_rolling_cycle.yml", c)
+
+	s.NotNil(err)
+}
+
+func (s *ConfigTestSuite) TestLoadRolling_Schema() {
+	c := NewConfig()
+	err := LoadRolling("testdata/game/data/rolling", "test_rolling_bad_schema.yml", c)
+
+	s.Require().NotNil(err)
+	invalid, ok := err.(*schema.ValidationError)
+	s.Require().True(ok)
+	s.Len(invalid.Problems, 2)
+	s.Empty(c.Strategies)
+}
+
+func (s *ConfigTestSuite) TestStrategy_Unknown() {
+	c := NewConfig()
+
+	_, err := c.Strategy("missing")
+	s.NotNil(err)
+
+	c.Strategies["odd"] = Spec{Type: "coin"}
+	_, err = c.Strategy("odd")
+	s.NotNil(err)
+
+	c.Strategies["nodice"] = Spec{Type: TypeDice}
+	_, err = c.Strategy("nodice")
+	s.NotNil(err)
+}
+
+func (s *ConfigTestSuite) TestApply() {
+	c := NewConfig()
+	s.Require().Nil(LoadRolling("testdata/game/data/rolling", "test_rolling_simple.yml", c))
+
+	err := c.Apply("pack", Layer{
+		Default: "coin",
+		Add:     map[string]Spec{"coin": {Type: TypeDice, Dice: 1, Sides: 2}},
+		Patch:   map[string]Spec{"normal": {Type: TypeNormal, Mean: 90, StdDev: 5}},
+		Remove:  []string{"points"},
+	})
+	s.Require().Nil(err)
+	s.Require().Nil(c.Validate())
+
+	s.Equal("coin", c.Default)
+	s.Len(c.Strategies, 4)
+	n, err := c.Strategy("normal")
+	s.Require().Nil(err)
+	s.Equal(Normal{Mean: 90, StdDev: 5}, n)
+}
+
+func (s *ConfigTestSuite) TestApply_Errors() {
+	c := NewConfig()
+	s.Require().Nil(LoadRolling("testdata/game/data/rolling", "test_rolling_simple.yml", c))
+
+	s.NotNil(c.Apply("pack", Layer{Add: map[string]Spec{"dice": {}}, Default: "normal"}))
+	s.NotNil(c.Apply("pack", Layer{Patch: map[string]Spec{"coin": {}}}))
+	s.NotNil(c.Apply("pack", Layer{Remove: []string{"coin"}}))
+	s.Equal("dice", c.Default)
+	s.Len(c.Strategies, 4)
+}
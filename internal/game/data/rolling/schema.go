@@ -0,0 +1,46 @@
+//------------------------------------------------------------------------------
+//    Copyright 2019 Jeff Sharpe (zeropointx.io)
+//
+//    Licensed under the Apache License, Version 2.0 (the "License");
+//    you may not use this file except in compliance with the License.
+//    You may obtain a copy of the License at
+//
+//        http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS,
+//    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//    See the License for the specific language governing permissions and
+//    limitations under the License.
+//------------------------------------------------------------------------------
+
+package rolling
+
+import (
+	"github.com/zpxio/heromanager/internal/game/data/attributes"
+	"github.com/zpxio/heromanager/internal/game/data/schema"
+)
+
+// specData has the layout of Spec without its schema method.
+type specData Spec
+
+// JSONSchema describes a strategy spec, limiting the type to the known
+// strategies and template values to attribute keys.
+func (s Spec) JSONSchema() *schema.Schema {
+	described := schema.Of(specData{})
+	described.Properties["type"] = schema.Enum(TypeDice, TypeNormal, TypePointBuy, TypeTemplate)
+	described.Properties["templates"] = schema.MapOf(attributes.NewAttributeModifier().JSONSchema())
+	described.Required = []string{"type"}
+
+	return described
+}
+
+// FileSchema describes a rolling strategy data file.
+func FileSchema() *schema.Schema {
+	return schema.Document("rolling", "Defines the strategies that roll starting attributes.", schema.Of(Config{}))
+}
+
+// LayerSchema describes a content pack layer file for rolling strategies.
+func LayerSchema() *schema.Schema {
+	return schema.Document("rolling layer", "Adds, patches and removes rolling strategies.", schema.Of(Layer{}))
+}
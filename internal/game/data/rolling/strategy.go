@@ -0,0 +1,159 @@
+//------------------------------------------------------------------------------
+//    Copyright 2019 Jeff Sharpe (zeropointx.io)
+//
+//    Licensed under the Apache License, Version 2.0 (the "License");
+//    you may not use this file except in compliance with the License.
+//    You may obtain a copy of the License at
+//
+//        http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS,
+//    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//    See the License for the specific language governing permissions and
+//    limitations under the License.
+//------------------------------------------------------------------------------
+
+package rolling
+
+import (
+	"github.com/zpxio/heromanager/internal/game/data/attributes"
+	"github.com/zpxio/heromanager/internal/game/data/table"
+	"math/rand"
+	"sort"
+)
+
+const (
+	TypeDice     = "dice"
+	TypeNormal   = "normal"
+	TypePointBuy = "pointbuy"
+	TypeTemplate = "template"
+)
+
+// Strategy decides the starting attributes of a freshly generated hero.
+type Strategy interface {
+	Roll(rng *rand.Rand, race string) table.Values
+}
+
+// attributeKeys returns the attribute keys in a fixed order so that seeded
+// rolls are reproducible.
+func attributeKeys() []string {
+	keys := append([]string{}, attributes.Policy().ValidKeys()...)
+	sort.Strings(keys)
+
+	return keys
+}
+
+// Dice rolls a number of dice per attribute and scales the sum.
+type Dice struct {
+	Count int
+	Sides int
+	Scale float64
+	Bonus float64
+}
+
+func (d Dice) Roll(rng *rand.Rand, race string) table.Values {
+	v := attributes.NewAttributeValues()
+
+	for _, k := range attributeKeys() {
+		sum := 0
+		for i := 0; i < d.Count; i++ {
+			sum += rng.Intn(d.Sides) + 1
+		}
+		v.Set(k, float64(sum)*d.Scale+d.Bonus)
+	}
+
+	return v
+}
+
+// Normal draws each attribute from a normal distribution.
+type Normal struct {
+	Mean   float64
+	StdDev float64
+}
+
+func (n Normal) Roll(rng *rand.Rand, race string) table.Values {
+	v := attributes.NewAttributeValues()
+
+	for _, k := range attributeKeys() {
+		v.Set(k, n.Mean+rng.NormFloat64()*n.StdDev)
+	}
+
+	return v
+}
+
+// PointBuy starts every attribute at a minimum and spends a fixed budget in
+// steps on randomly chosen attributes.
+type PointBuy struct {
+	Budget  float64
+	Minimum float64
+	Step    float64
+}
+
+func (p PointBuy) Roll(rng *rand.Rand, race string) table.Values {
+	v := attributes.NewAttributeValues()
+	keys := attributeKeys()
+
+	for _, k := range keys {
+		v.Set(k, p.Minimum)
+	}
+
+	step := p.Step
+	if step <= 0.0 {
+		step = 1.0
+	}
+
+	remaining := p.Budget
+	for remaining > 0.0 {
+		open := make([]string, 0, len(keys))
+		for _, k := range keys {
+			if v.Get(k) < attributes.MaxAttributeValue {
+				open = append(open, k)
+			}
+		}
+		if len(open) == 0 {
+			break
+		}
+
+		spend := step
+		if remaining < spend {
+			spend = remaining
+		}
+
+		k := open[rng.Intn(len(open))]
+		before := v.Get(k)
+		v.Set(k, before+spend)
+		remaining -= v.Get(k) - before
+	}
+
+	return v
+}
+
+// Template uses per-race base values with optional normal variance. Races
+// without a template are rolled by the fallback strategy.
+type Template struct {
+	Templates map[string]map[string]float64
+	Variance  float64
+	Fallback  Strategy
+}
+
+func (t Template) Roll(rng *rand.Rand, race string) table.Values {
+	base, found := t.Templates[race]
+	if !found {
+		if t.Fallback != nil {
+			return t.Fallback.Roll(rng, race)
+		}
+		return attributes.NewAttributeValues()
+	}
+
+	v := attributes.NewAttributeValues()
+	for _, k := range attributeKeys() {
+		value := base[k]
+		if t.Variance > 0.0 {
+			value += rng.NormFloat64() * t.Variance
+		}
+		v.Set(k, value)
+	}
+
+	return v
+}
@@ -0,0 +1,184 @@
+//------------------------------------------------------------------------------
+//    Copyright 2019 Jeff Sharpe (zeropointx.io)
+//
+//    Licensed under the Apache License, Version 2.0 (the "License");
+//    you may not use this file except in compliance with the License.
+//    You may obtain a copy of the License at
+//
+//        http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS,
+//    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//    See the License for the specific language governing permissions and
+//    limitations under the License.
+//------------------------------------------------------------------------------
+
+package rolling
+
+import (
+	"github.com/stretchr/testify/suite"
+	"github.com/zpxio/heromanager/internal/game/data/attributes"
+	"github.com/zpxio/heromanager/internal/game/data/table"
+	"math"
+	"math/rand"
+	"testing"
+)
+
+const sampleSize = 5000
+
+type StrategyTestSuite struct {
+	suite.Suite
+}
+
+func TestStrategySuite(t *testing.T) {
+	suite.Run(t, new(StrategyTestSuite))
+}
+
+// sample rolls the strategy repeatedly and returns the mean and standard
+// deviation of a single attribute.
+func sample(strategy Strategy, race string, key string) (float64, float64) {
+	rng := rand.New(rand.NewSource(42))
+
+	values := make([]float64, sampleSize)
+	sum := 0.0
+	for i := range values {
+		v := strategy.Roll(rng, race)
+		values[i] = v.Get(key)
+		sum += values[i]
+	}
+	mean := sum / sampleSize
+
+	variance := 0.0
+	for _, x := range values {
+		variance += (x - mean) * (x - mean)
+	}
+
+	return mean, math.Sqrt(variance / sampleSize)
+}
+
+func total(v table.Values) float64 {
+	sum := 0.0
+	for _, k := range attributes.Policy().ValidKeys() {
+		sum += v.Get(k)
+	}
+
+	return sum
+}
+
+func (s *StrategyTestSuite) TestDice_Distribution() {
+	d := Dice{Count: 3, Sides: 6, Scale: 5}
+
+	mean, stddev := sample(d, "", attributes.Brawn)
+
+	// 3d6 has mean 10.5 and standard deviation ~2.958
+	s.InDelta(52.5, mean, 0.5)
+	s.InDelta(2.958*5, stddev, 0.3)
+}
+
+func (s *StrategyTestSuite) TestDice_Bounds() {
+	d := Dice{Count: 2, Sides: 4, Scale: 1, Bonus: 10}
+	rng := rand.New(rand.NewSource(7))
+
+	for i := 0; i < 1000; i++ {
+		v := d.Roll(rng, "")
+		for _, k := range attributes.Policy().ValidKeys() {
+			s.True(v.Get(k) >= 12.0)
+			s.True(v.Get(k) <= 18.0)
+		}
+	}
+}
+
+func (s *StrategyTestSuite) TestNormal_Distribution() {
+	n := Normal{Mean: 80, StdDev: 15}
+
+	mean, stddev := sample(n, "", attributes.Insight)
+
+	s.InDelta(80.0, mean, 1.0)
+	s.InDelta(15.0, stddev, 0.75)
+}
+
+func (s *StrategyTestSuite) TestNormal_Clamped() {
+	n := Normal{Mean: 0, StdDev: 50}
+	rng := rand.New(rand.NewSource(7))
+
+	for i := 0; i < 1000; i++ {
+		v := n.Roll(rng, "")
+		s.True(v.Get(attributes.Allure) >= attributes.MinAttributeValue)
+	}
+}
+
+func (s *StrategyTestSuite) TestPointBuy_Budget() {
+	p := PointBuy{Budget: 250, Minimum: 20, Step: 5}
+	rng := rand.New(rand.NewSource(3))
+
+	for i := 0; i < 500; i++ {
+		v := p.Roll(rng, "")
+		s.InDelta(250.0+20.0*5, total(v), 0.0001)
+		for _, k := range attributes.Policy().ValidKeys() {
+			s.True(v.Get(k) >= 20.0)
+		}
+	}
+}
+
+func (s *StrategyTestSuite) TestPointBuy_Distribution() {
+	p := PointBuy{Budget: 250, Minimum: 20, Step: 5}
+
+	mean, _ := sample(p, "", attributes.Vigor)
+
+	// Spending is uniform across the five attributes
+	s.InDelta(70.0, mean, 1.0)
+}
+
+func (s *StrategyTestSuite) TestPointBuy_Saturation() {
+	p := PointBuy{Budget: 5000, Minimum: 0, Step: 7}
+	rng := rand.New(rand.NewSource(3))
+
+	v := p.Roll(rng, "")
+
+	for _, k := range attributes.Policy().ValidKeys() {
+		s.Equal(attributes.MaxAttributeValue, v.Get(k))
+	}
+}
+
+func (s *StrategyTestSuite) TestTemplate_Race() {
+	t := Template{
+		Templates: map[string]map[string]float64{"Dwarf": {attributes.Brawn: 70}},
+		Variance:  5,
+	}
+
+	mean, stddev := sample(t, "Dwarf", attributes.Brawn)
+
+	s.InDelta(70.0, mean, 0.5)
+	s.InDelta(5.0, stddev, 0.3)
+}
+
+func (s *StrategyTestSuite) TestTemplate_Exact() {
+	t := Template{Templates: map[string]map[string]float64{"Dwarf": {attributes.Brawn: 70}}}
+	rng := rand.New(rand.NewSource(1))
+
+	v := t.Roll(rng, "Dwarf")
+
+	s.Equal(70.0, v.Get(attributes.Brawn))
+	s.Equal(0.0, v.Get(attributes.Allure))
+}
+
+func (s *StrategyTestSuite) TestTemplate_Fallback() {
+	t := Template{Templates: map[string]map[string]float64{}, Fallback: Normal{Mean: 50}}
+	rng := rand.New(rand.NewSource(1))
+
+	v := t.Roll(rng, "Elf")
+
+	s.Equal(50.0, v.Get(attributes.Brawn))
+}
+
+func (s *StrategyTestSuite) TestSeeded_Reproducible() {
+	d := Dice{Count: 3, Sides: 6, Scale: 5}
+
+	a := d.Roll(rand.New(rand.NewSource(99)), "")
+	b := d.Roll(rand.New(rand.NewSource(99)), "")
+
+	for _, k := range attributes.Policy().ValidKeys() {
+		s.Equal(a.Get(k), b.Get(k))
+	}
+}
@@ -0,0 +1,54 @@
+//------------------------------------------------------------------------------
+//    Copyright 2019 Jeff Sharpe (zeropointx.io)
+//
+//    Licensed under the Apache License, Version 2.0 (the "License");
+//    you may not use this file except in compliance with the License.
+//    You may obtain a copy of the License at
+//
+//        http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS,
+//    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//    See the License for the specific language governing permissions and
+//    limitations under the License.
+//------------------------------------------------------------------------------
+
+package schema
+
+import (
+	"encoding/json"
+)
+
+// schemaData has the layout of Schema without its marshal method.
+type schemaData Schema
+
+func (s Schema) MarshalJSON() ([]byte, error) {
+	data, err := json.Marshal(schemaData(s))
+	if err != nil {
+		return nil, err
+	}
+
+	var additional interface{}
+	switch {
+	case s.AdditionalProperties != nil:
+		additional = s.AdditionalProperties
+	case s.Closed:
+		additional = false
+	default:
+		return data, nil
+	}
+
+	fields := map[string]json.RawMessage{}
+	err = json.Unmarshal(data, &fields)
+	if err != nil {
+		return nil, err
+	}
+
+	fields["additionalProperties"], err = json.Marshal(additional)
+	if err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(fields)
+}
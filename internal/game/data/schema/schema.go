@@ -0,0 +1,193 @@
+//------------------------------------------------------------------------------
+//    Copyright 2019 Jeff Sharpe (zeropointx.io)
+//
+//    Licensed under the Apache License, Version 2.0 (the "License");
+//    you may not use this file except in compliance with the License.
+//    You may obtain a copy of the License at
+//
+//        http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS,
+//    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//    See the License for the specific language governing permissions and
+//    limitations under the License.
+//------------------------------------------------------------------------------
+
+// Package schema generates JSON Schema documents describing game data files
+// from the Go types they load into, and validates data against them. Only the
+// subset of JSON Schema needed by the game data is supported. Editors with
+// YAML support, such as yaml-language-server, use the same documents for
+// YAML files.
+package schema
+
+import (
+	"reflect"
+	"sort"
+	"strings"
+)
+
+const Draft = "http://json-schema.org/draft-07/schema#"
+
+const (
+	TypeObject  = "object"
+	TypeArray   = "array"
+	TypeString  = "string"
+	TypeNumber  = "number"
+	TypeInteger = "integer"
+	TypeBoolean = "boolean"
+)
+
+type Schema struct {
+	Draft       string `json:"$schema,omitempty"`
+	Title       string `json:"title,omitempty"`
+	Description string `json:"description,omitempty"`
+	Type        string `json:"type,omitempty"`
+
+	Properties map[string]*Schema `json:"properties,omitempty"`
+	// AdditionalProperties describes object values not listed in
+	// Properties. When nil, they are forbidden if Closed is set and
+	// unconstrained otherwise.
+	AdditionalProperties *Schema  `json:"-"`
+	Closed               bool     `json:"-"`
+	PropertyNames        *Schema  `json:"propertyNames,omitempty"`
+	Required             []string `json:"required,omitempty"`
+
+	Items *Schema `json:"items,omitempty"`
+
+	Enum    []string `json:"enum,omitempty"`
+	Minimum *float64 `json:"minimum,omitempty"`
+}
+
+// Describer is implemented by types whose data form differs from their Go
+// layout, typically the same types that implement custom unmarshaling.
+type Describer interface {
+	JSONSchema() *Schema
+}
+
+var describerType = reflect.TypeOf((*Describer)(nil)).Elem()
+
+func Object(properties map[string]*Schema) *Schema {
+	return &Schema{Type: TypeObject, Properties: properties, Closed: true}
+}
+
+// MapOf describes an object whose keys are IDs and whose values all share a
+// schema.
+func MapOf(values *Schema) *Schema {
+	return &Schema{Type: TypeObject, AdditionalProperties: values}
+}
+
+func ArrayOf(items *Schema) *Schema {
+	return &Schema{Type: TypeArray, Items: items}
+}
+
+func String() *Schema {
+	return &Schema{Type: TypeString}
+}
+
+func Number() *Schema {
+	return &Schema{Type: TypeNumber}
+}
+
+// Enum describes a string restricted to the given values.
+func Enum(values ...string) *Schema {
+	sorted := append([]string{}, values...)
+	sort.Strings(sorted)
+
+	return &Schema{Type: TypeString, Enum: sorted}
+}
+
+// Document marks a schema as a standalone document.
+func Document(title string, description string, s *Schema) *Schema {
+	document := *s
+	document.Draft = Draft
+	document.Title = title
+	document.Description = description
+
+	return &document
+}
+
+// Of describes the data form of a value. Struct fields are named by their
+// yaml tags, so the value should be initialized the way loading initializes
+// it; values implementing Describer describe themselves.
+func Of(v interface{}) *Schema {
+	return of(reflect.ValueOf(v))
+}
+
+func of(v reflect.Value) *Schema {
+	if v.Type().Implements(describerType) {
+		if v.Kind() != reflect.Ptr || !v.IsNil() {
+			return v.Interface().(Describer).JSONSchema()
+		}
+	}
+	if reflect.PtrTo(v.Type()).Implements(describerType) {
+		p := reflect.New(v.Type())
+		p.Elem().Set(v)
+		return p.Interface().(Describer).JSONSchema()
+	}
+
+	switch v.Kind() {
+	case reflect.Ptr:
+		if v.IsNil() {
+			return of(reflect.Zero(v.Type().Elem()))
+		}
+		return of(v.Elem())
+	case reflect.Struct:
+		s := Object(make(map[string]*Schema))
+		addFields(s, v)
+		return s
+	case reflect.Map:
+		return MapOf(of(reflect.Zero(v.Type().Elem())))
+	case reflect.Slice, reflect.Array:
+		return ArrayOf(of(reflect.Zero(v.Type().Elem())))
+	case reflect.String:
+		return String()
+	case reflect.Bool:
+		return &Schema{Type: TypeBoolean}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return &Schema{Type: TypeInteger}
+	case reflect.Float32, reflect.Float64:
+		return Number()
+	}
+
+	return &Schema{}
+}
+
+// addFields adds the yaml-tagged fields of a struct, flattening inline ones.
+func addFields(s *Schema, v reflect.Value) {
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if field.PkgPath != "" && !field.Anonymous {
+			continue
+		}
+
+		name, inline := yamlName(field)
+		if name == "-" {
+			continue
+		}
+		if inline {
+			addFields(s, v.Field(i))
+			continue
+		}
+
+		s.Properties[name] = of(v.Field(i))
+	}
+}
+
+func yamlName(field reflect.StructField) (string, bool) {
+	tag := field.Tag.Get("yaml")
+	parts := strings.Split(tag, ",")
+	for _, option := range parts[1:] {
+		if option == "inline" {
+			return "", true
+		}
+	}
+
+	if parts[0] != "" {
+		return parts[0], false
+	}
+
+	return strings.ToLower(field.Name), false
+}
@@ -0,0 +1,96 @@
+//------------------------------------------------------------------------------
+//    Copyright 2019 Jeff Sharpe (zeropointx.io)
+//
+//    Licensed under the Apache License, Version 2.0 (the "License");
+//    you may not use this file except in compliance with the License.
+//    You may obtain a copy of the License at
+//
+//        http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS,
+//    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//    See the License for the specific language governing permissions and
+//    limitations under the License.
+//------------------------------------------------------------------------------
+
+package schema
+
+import (
+	"encoding/json"
+	"github.com/stretchr/testify/suite"
+	"testing"
+)
+
+type SchemaTestSuite struct {
+	suite.Suite
+}
+
+func TestSchemaSuite(t *testing.T) {
+	suite.Run(t, new(SchemaTestSuite))
+}
+
+type testColor string
+
+func (c testColor) JSONSchema() *Schema {
+	return Enum("red", "blue")
+}
+
+type testBase struct {
+	Name string `yaml:"name"`
+}
+
+type testEntry struct {
+	testBase `yaml:",inline"`
+	Id       string             `yaml:"-"`
+	Count    int                `yaml:"count"`
+	Weight   float32            `yaml:"weight"`
+	Enabled  bool               `yaml:"enabled"`
+	Color    testColor          `yaml:"color"`
+	Tags     []string           `yaml:"tags"`
+	Scores   map[string]float64 `yaml:"scores"`
+	Plain    string
+	hidden   string
+}
+
+func (s *SchemaTestSuite) TestOf() {
+	described := Of(testEntry{})
+
+	s.Equal(TypeObject, described.Type)
+	s.True(described.Closed)
+	s.ElementsMatch([]string{"name", "count", "weight", "enabled", "color", "tags", "scores", "plain"}, keys(described.Properties))
+	s.Equal(TypeInteger, described.Properties["count"].Type)
+	s.Equal(TypeNumber, described.Properties["weight"].Type)
+	s.Equal(TypeBoolean, described.Properties["enabled"].Type)
+	s.Equal([]string{"blue", "red"}, described.Properties["color"].Enum)
+	s.Equal(TypeString, described.Properties["tags"].Items.Type)
+	s.Equal(TypeNumber, described.Properties["scores"].AdditionalProperties.Type)
+}
+
+func (s *SchemaTestSuite) TestMarshalJSON() {
+	document := Document("entry", "A test entry.", MapOf(Object(map[string]*Schema{"name": String()})))
+
+	data, err := json.Marshal(document)
+	s.Require().Nil(err)
+
+	s.JSONEq(`{
+		"$schema": "http://json-schema.org/draft-07/schema#",
+		"title": "entry",
+		"description": "A test entry.",
+		"type": "object",
+		"additionalProperties": {
+			"type": "object",
+			"properties": {"name": {"type": "string"}},
+			"additionalProperties": false
+		}
+	}`, string(data))
+}
+
+func keys(properties map[string]*Schema) []string {
+	names := make([]string, 0, len(properties))
+	for name := range properties {
+		names = append(names, name)
+	}
+
+	return names
+}
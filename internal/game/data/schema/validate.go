@@ -0,0 +1,214 @@
+//------------------------------------------------------------------------------
+//    Copyright 2019 Jeff Sharpe (zeropointx.io)
+//
+//    Licensed under the Apache License, Version 2.0 (the "License");
+//    you may not use this file except in compliance with the License.
+//    You may obtain a copy of the License at
+//
+//        http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS,
+//    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//    See the License for the specific language governing permissions and
+//    limitations under the License.
+//------------------------------------------------------------------------------
+
+package schema
+
+import (
+	"fmt"
+	"gopkg.in/yaml.v2"
+	"math"
+	"sort"
+	"strings"
+)
+
+// ValidationError lists every way a data file fails to match its schema.
+type ValidationError struct {
+	Source   string
+	Problems []string
+}
+
+func (e *ValidationError) Error() string {
+	return fmt.Sprintf("%s does not match its schema: %s", e.Source, strings.Join(e.Problems, "; "))
+}
+
+// ValidateData checks YAML or JSON data against a schema, returning a
+// ValidationError naming the source when it does not match.
+func ValidateData(source string, s *Schema, data []byte) error {
+	var document interface{}
+	err := yaml.Unmarshal(data, &document)
+	if err != nil {
+		return err
+	}
+
+	problems := s.Validate(document)
+	if len(problems) > 0 {
+		return &ValidationError{Source: source, Problems: problems}
+	}
+
+	return nil
+}
+
+// Validate checks a decoded document against the schema, listing each
+// problem with the path to the offending value. Null values are accepted
+// anywhere, since loading leaves the field at its default.
+func (s *Schema) Validate(document interface{}) []string {
+	problems := make([]string, 0)
+	s.validate("", document, &problems)
+
+	return problems
+}
+
+func (s *Schema) validate(path string, value interface{}, problems *[]string) {
+	if value == nil {
+		return
+	}
+
+	report := func(format string, args ...interface{}) {
+		location := path
+		if location == "" {
+			location = "(root)"
+		}
+		*problems = append(*problems, location+": "+fmt.Sprintf(format, args...))
+	}
+
+	switch s.Type {
+	case TypeObject:
+		fields, ok := toObject(value)
+		if !ok {
+			report("expected %s, found %s", s.Type, describe(value))
+			return
+		}
+		s.validateObject(path, fields, report, problems)
+	case TypeArray:
+		items, ok := value.([]interface{})
+		if !ok {
+			report("expected %s, found %s", s.Type, describe(value))
+			return
+		}
+		if s.Items != nil {
+			for i, item := range items {
+				s.Items.validate(fmt.Sprintf("%s[%d]", path, i), item, problems)
+			}
+		}
+	case TypeString:
+		text, ok := value.(string)
+		if !ok {
+			report("expected %s, found %s", s.Type, describe(value))
+			return
+		}
+		if len(s.Enum) > 0 && !contains(s.Enum, text) {
+			report("%q is not one of: %s", text, strings.Join(s.Enum, ", "))
+		}
+	case TypeNumber, TypeInteger:
+		number, ok := toNumber(value)
+		if !ok || (s.Type == TypeInteger && number != math.Trunc(number)) {
+			report("expected %s, found %s", s.Type, describe(value))
+			return
+		}
+		if s.Minimum != nil && number < *s.Minimum {
+			report("%v is less than the minimum of %v", number, *s.Minimum)
+		}
+	case TypeBoolean:
+		if _, ok := value.(bool); !ok {
+			report("expected %s, found %s", s.Type, describe(value))
+		}
+	}
+}
+
+func (s *Schema) validateObject(path string, fields map[string]interface{}, report func(string, ...interface{}), problems *[]string) {
+	for _, name := range s.Required {
+		if _, found := fields[name]; !found {
+			report("missing required key: %s", name)
+		}
+	}
+
+	names := make([]string, 0, len(fields))
+	for name := range fields {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		child := name
+		if path != "" {
+			child = path + "." + name
+		}
+
+		if s.PropertyNames != nil && len(s.PropertyNames.Enum) > 0 && !contains(s.PropertyNames.Enum, name) {
+			report("unknown key %s, expected one of: %s", name, strings.Join(s.PropertyNames.Enum, ", "))
+			continue
+		}
+
+		if property, found := s.Properties[name]; found {
+			property.validate(child, fields[name], problems)
+		} else if s.AdditionalProperties != nil {
+			s.AdditionalProperties.validate(child, fields[name], problems)
+		} else if s.Closed {
+			report("unknown key: %s", name)
+		}
+	}
+}
+
+// toObject normalizes a decoded mapping, which YAML may key by any scalar.
+func toObject(value interface{}) (map[string]interface{}, bool) {
+	switch mapping := value.(type) {
+	case map[string]interface{}:
+		return mapping, true
+	case map[interface{}]interface{}:
+		fields := make(map[string]interface{}, len(mapping))
+		for k, v := range mapping {
+			fields[fmt.Sprint(k)] = v
+		}
+		return fields, true
+	}
+
+	return nil, false
+}
+
+func toNumber(value interface{}) (float64, bool) {
+	switch n := value.(type) {
+	case int:
+		return float64(n), true
+	case int64:
+		return float64(n), true
+	case uint64:
+		return float64(n), true
+	case float64:
+		return n, true
+	}
+
+	return 0.0, false
+}
+
+func describe(value interface{}) string {
+	if _, ok := toObject(value); ok {
+		return TypeObject
+	}
+	if _, ok := toNumber(value); ok {
+		return fmt.Sprintf("%s %v", TypeNumber, value)
+	}
+
+	switch v := value.(type) {
+	case []interface{}:
+		return TypeArray
+	case string:
+		return fmt.Sprintf("%s %q", TypeString, v)
+	case bool:
+		return fmt.Sprintf("%s %v", TypeBoolean, v)
+	}
+
+	return fmt.Sprintf("%T", value)
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,96 @@
+//------------------------------------------------------------------------------
+//    Copyright 2019 Jeff Sharpe (zeropointx.io)
+//
+//    Licensed under the Apache License, Version 2.0 (the "License");
+//    you may not use this file except in compliance with the License.
+//    You may obtain a copy of the License at
+//
+//        http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS,
+//    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//    See the License for the specific language governing permissions and
+//    limitations under the License.
+//------------------------------------------------------------------------------
+
+package schema
+
+import (
+	"github.com/stretchr/testify/suite"
+	"testing"
+)
+
+type ValidateTestSuite struct {
+	suite.Suite
+}
+
+func TestValidateSuite(t *testing.T) {
+	suite.Run(t, new(ValidateTestSuite))
+}
+
+func (s *ValidateTestSuite) TestValidateData() {
+	err := ValidateData("entries.yml", MapOf(Of(testEntry{})), []byte(`
+First:
+  name: First
+  count: 3
+  weight: 0.5
+  color: red
+  tags: [a, b]
+  scores:
+    x: 1
+Second:
+  name:
+`))
+
+	s.Nil(err)
+}
+
+func (s *ValidateTestSuite) TestValidateData_JSON() {
+	err := ValidateData("entries.json", MapOf(Of(testEntry{})), []byte(`{"First": {"count": 2, "tags": ["a"]}}`))
+
+	s.Nil(err)
+}
+
+func (s *ValidateTestSuite) TestValidateData_Problems() {
+	err := ValidateData("entries.yml", MapOf(Of(testEntry{})), []byte(`
+First:
+  count: 1.5
+  color: green
+  size: 3
+  tags: a
+Second: 6.4492
+`))
+
+	s.Require().NotNil(err)
+	invalid, ok := err.(*ValidationError)
+	s.Require().True(ok)
+	s.Equal("entries.yml", invalid.Source)
+	s.Equal([]string{
+		`First.color: "green" is not one of: blue, red`,
+		`First.count: expected integer, found number 1.5`,
+		`First: unknown key: size`,
+		`First.tags: expected array, found string "a"`,
+		`Second: expected object, found number 6.4492`,
+	}, invalid.Problems)
+}
+
+func (s *ValidateTestSuite) TestValidate_Keys() {
+	table := MapOf(Number())
+	table.PropertyNames = Enum("Brawn", "Vigor")
+	minimum := 0.0
+	table.AdditionalProperties.Minimum = &minimum
+
+	s.Empty(table.Validate(map[interface{}]interface{}{"Brawn": 0.5}))
+	s.Equal([]string{
+		"(root): unknown key BRN, expected one of: Brawn, Vigor",
+		"Vigor: -1 is less than the minimum of 0",
+	}, table.Validate(map[interface{}]interface{}{"BRN": 2, "Vigor": -1}))
+}
+
+func (s *ValidateTestSuite) TestValidate_Required() {
+	entry := Object(map[string]*Schema{"formula": String()})
+	entry.Required = []string{"formula"}
+
+	s.Equal([]string{"(root): missing required key: formula"}, entry.Validate(map[interface{}]interface{}{}))
+}
@@ -0,0 +1,74 @@
+//------------------------------------------------------------------------------
+//    Copyright 2019 Jeff Sharpe (zeropointx.io)
+//
+//    Licensed under the Apache License, Version 2.0 (the "License");
+//    you may not use this file except in compliance with the License.
+//    You may obtain a copy of the License at
+//
+//        http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS,
+//    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//    See the License for the specific language governing permissions and
+//    limitations under the License.
+//------------------------------------------------------------------------------
+
+package skills
+
+import (
+	"github.com/zpxio/heromanager/internal/game/data/table"
+	"math/rand"
+)
+
+const (
+	// CheckDie is the size of the random roll added to a rating.
+	CheckDie = 100.0
+	// AttributeShare is the part of the governing attributes' average that
+	// counts towards a rating.
+	AttributeShare = 0.5
+)
+
+// Result records the outcome of a skill check.
+type Result struct {
+	Skill      string  `json:"skill"`
+	Rating     float64 `json:"rating"`
+	Roll       float64 `json:"roll"`
+	Difficulty float64 `json:"difficulty"`
+	Success    bool    `json:"success"`
+}
+
+// Margin is how far the check beat, or fell short of, its difficulty.
+func (r Result) Margin() float64 {
+	return r.Rating + r.Roll - r.Difficulty
+}
+
+// Rating combines a skill value with a share of the average of its governing
+// attributes.
+func (s Skill) Rating(attributes table.Values, value float64) float64 {
+	if len(s.Attributes) == 0 {
+		return value
+	}
+
+	sum := 0.0
+	for _, name := range s.Attributes {
+		sum += attributes.Get(name)
+	}
+
+	return value + AttributeShare*sum/float64(len(s.Attributes))
+}
+
+// Check rolls against a difficulty, succeeding when the rating plus a roll
+// of up to CheckDie meets it. A rating of 50 succeeds half the time against
+// a difficulty of 100.
+func (s Skill) Check(id string, attributes table.Values, value float64, difficulty float64, rng *rand.Rand) Result {
+	r := Result{
+		Skill:      id,
+		Rating:     s.Rating(attributes, value),
+		Roll:       rng.Float64() * CheckDie,
+		Difficulty: difficulty,
+	}
+	r.Success = r.Margin() >= 0.0
+
+	return r
+}
@@ -0,0 +1,68 @@
+//------------------------------------------------------------------------------
+//    Copyright 2019 Jeff Sharpe (zeropointx.io)
+//
+//    Licensed under the Apache License, Version 2.0 (the "License");
+//    you may not use this file except in compliance with the License.
+//    You may obtain a copy of the License at
+//
+//        http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS,
+//    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//    See the License for the specific language governing permissions and
+//    limitations under the License.
+//------------------------------------------------------------------------------
+
+package skills
+
+import (
+	"github.com/stretchr/testify/suite"
+	"github.com/zpxio/heromanager/internal/game/data/attributes"
+	"math/rand"
+	"testing"
+)
+
+type CheckTestSuite struct {
+	suite.Suite
+}
+
+func TestCheckSuite(t *testing.T) {
+	suite.Run(t, new(CheckTestSuite))
+}
+
+func (s *CheckTestSuite) TestRating() {
+	smithing := Skill{Name: "Smithing", Attributes: []string{attributes.Brawn, attributes.Insight}}
+	values := attributes.NewAttributeValues()
+	values.Set(attributes.Brawn, 80.0)
+	values.Set(attributes.Insight, 40.0)
+
+	s.InDelta(30.0+AttributeShare*60.0, smithing.Rating(values, 30.0), 0.0001)
+}
+
+func (s *CheckTestSuite) TestCheck() {
+	lore := Skill{Name: "Lore", Attributes: []string{attributes.Insight}}
+	values := attributes.NewAttributeValues()
+	values.Set(attributes.Insight, 60.0)
+
+	rng := rand.New(rand.NewSource(1))
+	roll := rand.New(rand.NewSource(1)).Float64() * CheckDie
+
+	r := lore.Check("Lore", values, 20.0, 50.0+roll, rng)
+	s.Equal("Lore", r.Skill)
+	s.InDelta(50.0, r.Rating, 0.0001)
+	s.InDelta(roll, r.Roll, 0.0001)
+	s.InDelta(0.0, r.Margin(), 0.0001)
+	s.True(r.Success)
+}
+
+func (s *CheckTestSuite) TestCheck_Bounds() {
+	lore := Skill{Name: "Lore", Attributes: []string{attributes.Insight}}
+	values := attributes.NewAttributeValues()
+	rng := rand.New(rand.NewSource(1))
+
+	for i := 0; i < 100; i++ {
+		s.True(lore.Check("Lore", values, 0.0, 0.0, rng).Success)
+		s.False(lore.Check("Lore", values, 0.0, CheckDie+1.0, rng).Success)
+	}
+}
@@ -0,0 +1,157 @@
+//------------------------------------------------------------------------------
+//    Copyright 2019 Jeff Sharpe (zeropointx.io)
+//
+//    Licensed under the Apache License, Version 2.0 (the "License");
+//    you may not use this file except in compliance with the License.
+//    You may obtain a copy of the License at
+//
+//        http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS,
+//    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//    See the License for the specific language governing permissions and
+//    limitations under the License.
+//------------------------------------------------------------------------------
+
+package skills
+
+import (
+	"fmt"
+	log "github.com/sirupsen/logrus"
+	"github.com/zpxio/heromanager/internal/game/data/schema"
+	"github.com/zpxio/heromanager/internal/game/data/table"
+	"github.com/zpxio/heromanager/internal/game/util"
+	"gopkg.in/yaml.v2"
+	"sort"
+)
+
+const (
+	MinSkillValue float64 = 0.0
+	MaxSkillValue float64 = 100.0
+	DefaultValue  float64 = 0.0
+)
+
+// Skill is a learned ability, such as Smithing or Tracking, governed by one
+// or more attributes.
+type Skill struct {
+	Name       string   `yaml:"name" json:"name"`
+	Attributes []string `yaml:"attributes" json:"attributes"`
+}
+
+// Definitions holds every skill in the game data. The skill IDs form the
+// keys of a policy, so hero skills are a table of values like attributes.
+type Definitions struct {
+	skills   map[string]Skill
+	keys     []string
+	policy   *table.Policy
+	revision uint64
+}
+
+func NewDefinitions() *Definitions {
+	return &Definitions{
+		skills: make(map[string]Skill),
+		keys:   make([]string, 0),
+	}
+}
+
+func (d *Definitions) Register(id string, s Skill) {
+	log.Infof("Registering Skill: %s", id)
+	d.skills[id] = s
+	d.keys = nil
+	d.policy = nil
+	d.revision++
+}
+
+func (d *Definitions) Resolve(id string) (*Skill, bool) {
+	s, found := d.skills[id]
+
+	if found {
+		return &s, true
+	} else {
+		return nil, false
+	}
+}
+
+func (d *Definitions) All() []string {
+	if d.keys == nil {
+		d.keys = make([]string, 0, len(d.skills))
+		for id := range d.skills {
+			d.keys = append(d.keys, id)
+		}
+		sort.Strings(d.keys)
+	}
+
+	return d.keys
+}
+
+func (d *Definitions) Revision() uint64 {
+	return d.revision
+}
+
+// Policy returns the policy of a skill table, keyed by skill ID.
+func (d *Definitions) Policy() *table.Policy {
+	if d.policy == nil {
+		d.policy = table.NewPolicy(MinSkillValue, MaxSkillValue, DefaultValue, d.All())
+	}
+
+	return d.policy
+}
+
+func (d *Definitions) NewValues() table.Values {
+	return table.NewValues(d.Policy())
+}
+
+// Validate ensures every skill is governed by attributes valid for the
+// policy.
+func (d *Definitions) Validate(attributes *table.Policy) error {
+	for _, id := range d.All() {
+		s := d.skills[id]
+		if len(s.Attributes) == 0 {
+			return fmt.Errorf("skill %s has no governing attributes", id)
+		}
+		for _, name := range s.Attributes {
+			if !attributes.ValidKey(name) {
+				return fmt.Errorf("skill %s is governed by unknown attribute: %s", id, name)
+			}
+		}
+	}
+
+	return nil
+}
+
+func LoadSkills(gameDir string, skillsFile string, definitions *Definitions) error {
+	skillsYaml, err := util.GameFileData(gameDir, skillsFile)
+	if err != nil {
+		log.Errorf("yamlFile.Get err %v ", err)
+		return err
+	}
+
+	err = schema.ValidateData(skillsFile, FileSchema(), skillsYaml)
+	if err != nil {
+		log.Errorf("invalid skill data: %s", err)
+		return err
+	}
+
+	loaded := make(map[string]Skill)
+	err = yaml.Unmarshal(skillsYaml, &loaded)
+	if err != nil {
+		log.Errorf("failed to parse skill data: %s", err)
+		return err
+	}
+
+	// Register the skills
+	for id, s := range loaded {
+		definitions.Register(id, s)
+	}
+
+	return nil
+}
+
+// FileSchema describes a skill data file, keyed by skill ID.
+func FileSchema() *schema.Schema {
+	skill := schema.Of(Skill{})
+	skill.Required = []string{"attributes"}
+
+	return schema.Document("skills", "Defines skills and the attributes governing them, keyed by ID.", schema.MapOf(skill))
+}
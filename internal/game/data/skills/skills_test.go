@@ -0,0 +1,73 @@
+//------------------------------------------------------------------------------
+//    Copyright 2019 Jeff Sharpe (zeropointx.io)
+//
+//    Licensed under the Apache License, Version 2.0 (the "License");
+//    you may not use this file except in compliance with the License.
+//    You may obtain a copy of the License at
+//
+//        http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS,
+//    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//    See the License for the specific language governing permissions and
+//    limitations under the License.
+//------------------------------------------------------------------------------
+
+package skills
+
+import (
+	"github.com/stretchr/testify/suite"
+	"github.com/zpxio/heromanager/internal/game/data/attributes"
+	"testing"
+)
+
+type SkillsTestSuite struct {
+	suite.Suite
+}
+
+func TestSkillsSuite(t *testing.T) {
+	suite.Run(t, new(SkillsTestSuite))
+}
+
+func (s *SkillsTestSuite) TestLoadSkills() {
+	d := NewDefinitions()
+	err := LoadSkills("testdata/game/data/skills", "test_skills_simple.yml", d)
+
+	s.Require().Nil(err)
+	s.Equal([]string{"Lore", "Smithing"}, d.All())
+	s.Nil(d.Validate(attributes.Policy()))
+
+	smithing, found := d.Resolve("Smithing")
+	s.Require().True(found)
+	s.Equal([]string{attributes.Brawn, attributes.Insight}, smithing.Attributes)
+}
+
+func (s *SkillsTestSuite) TestValidate_UnknownAttribute() {
+	d := NewDefinitions()
+	s.Require().Nil(LoadSkills("testdata/game/data/skills", "test_skills_unknown.yml", d))
+
+	s.NotNil(d.Validate(attributes.Policy()))
+}
+
+func (s *SkillsTestSuite) TestValidate_NoAttributes() {
+	d := NewDefinitions()
+	d.Register("Luck", Skill{Name: "Luck"})
+
+	s.NotNil(d.Validate(attributes.Policy()))
+}
+
+func (s *SkillsTestSuite) TestPolicy() {
+	d := NewDefinitions()
+	d.Register("Lore", Skill{Name: "Lore", Attributes: []string{attributes.Insight}})
+
+	values := d.NewValues()
+	values.Set("Lore", 150.0)
+	values.Set("Smithing", 10.0)
+	s.Equal(MaxSkillValue, values.Get("Lore"))
+	s.False(d.Policy().ValidKey("Smithing"))
+
+	// Registering a skill extends the policy of new tables
+	d.Register("Smithing", Skill{Name: "Smithing", Attributes: []string{attributes.Brawn}})
+	s.True(d.Policy().ValidKey("Smithing"))
+}
@@ -16,6 +16,10 @@
 
 package table
 
+import (
+	"sort"
+)
+
 type KeySet struct {
 	keys     map[string]bool
 	allCache []string
@@ -41,6 +45,7 @@ func (s *KeySet) Contains(key string) bool {
 	return ok
 }
 
+// All lists the keys in sorted order.
 func (s *KeySet) All() []string {
 	if s.allCache == nil {
 		s.allCache = make([]string, len(s.keys), len(s.keys))
@@ -51,6 +56,7 @@ func (s *KeySet) All() []string {
 			s.allCache[index] = k
 			index++
 		}
+		sort.Strings(s.allCache)
 	}
 
 	return s.allCache
@@ -51,6 +51,14 @@ func (s *KeyTestSuite) TestAll() {
 	s.Require().Len(set.All(), len(testKeys))
 }
 
+func (s *KeyTestSuite) TestAll_Sorted() {
+	set := NewKeySet("Vigor", "Brawn", "Insight")
+	s.Equal([]string{"Brawn", "Insight", "Vigor"}, set.All())
+
+	set.Add("Allure")
+	s.Equal([]string{"Allure", "Brawn", "Insight", "Vigor"}, set.All())
+}
+
 func (s *KeyTestSuite) TestAll_Mutability() {
 	testKeys := []string{"A", "B", "C"}
 	addKey := "D"
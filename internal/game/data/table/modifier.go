@@ -18,15 +18,17 @@ package table
 
 import (
 	"encoding/json"
+	"github.com/zpxio/heromanager/internal/game/data/schema"
 )
 
 type Modifier struct {
 	adjustments map[string]float64
+	declared    map[string]bool
 	policy      *Policy
 }
 
 func NewModifier(policy *Policy) Modifier {
-	m := Modifier{adjustments: make(map[string]float64, len(policy.ValidKeys()))}
+	m := Modifier{adjustments: make(map[string]float64, len(policy.ValidKeys())), declared: make(map[string]bool)}
 
 	m.policy = policy
 	for _, k := range policy.ValidKeys() {
@@ -36,9 +38,29 @@ func NewModifier(policy *Policy) Modifier {
 	return m
 }
 
+// Policy returns the policy limiting the keys of the modifier, which is nil
+// for a modifier that was never initialized.
+func (m Modifier) Policy() *Policy {
+	return m.policy
+}
+
 func (m *Modifier) Load(adjustments map[string]float64) {
 	for k, v := range adjustments {
 		m.set(k, v)
+		if m.policy.ValidKey(k) {
+			m.declared[k] = true
+		}
+	}
+}
+
+// Inherit takes the parent's adjustment for every key that was not explicitly
+// loaded into this modifier.
+func (m *Modifier) Inherit(parent Modifier) {
+	for _, k := range m.policy.ValidKeys() {
+		if !m.declared[k] && parent.declared[k] {
+			m.adjustments[k] = parent.adjustments[k]
+			m.declared[k] = true
+		}
 	}
 }
 
@@ -67,6 +89,37 @@ func (m *Modifier) Apply(key string, value float64) float64 {
 	return value * m.Factor(key)
 }
 
+// adjustmentTable lists the adjustments that were loaded or are non-zero.
+func (m Modifier) adjustmentTable() map[string]float64 {
+	adjustments := make(map[string]float64)
+	for k, v := range m.adjustments {
+		if m.declared[k] || v != 0.0 {
+			adjustments[k] = v
+		}
+	}
+
+	return adjustments
+}
+
+func (m Modifier) MarshalJSON() ([]byte, error) {
+	return json.Marshal(m.adjustmentTable())
+}
+
+func (m Modifier) MarshalYAML() (interface{}, error) {
+	return m.adjustmentTable(), nil
+}
+
+// JSONSchema describes the modifier as a table of adjustments keyed by the
+// keys valid for its policy.
+func (m Modifier) JSONSchema() *schema.Schema {
+	s := schema.MapOf(schema.Number())
+	if m.policy != nil {
+		s.PropertyNames = schema.Enum(m.policy.ValidKeys()...)
+	}
+
+	return s
+}
+
 func (m *Modifier) UnmarshalJSON(data []byte) error {
 	valueTable := map[string]float64{}
 	err := json.Unmarshal(data, &valueTable)
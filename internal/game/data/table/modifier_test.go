@@ -232,3 +232,38 @@ func TestAdjustmentSuite(t *testing.T) {
 	s.policy = NewPolicy(0, 200, 0, s.keys)
 	suite.Run(t, s)
 }
+
+func (s AdjustmentTestSuite) TestInherit() {
+	parent := NewModifier(s.policy)
+	parent.Load(map[string]float64{s.keys[0]: 0.5, s.keys[1]: 0.2})
+
+	child := NewModifier(s.policy)
+	child.Load(map[string]float64{s.keys[1]: -0.1, s.keys[2]: 0.0})
+
+	child.Inherit(parent)
+
+	s.Equal(0.5, child.adjustments[s.keys[0]])
+	s.Equal(-0.1, child.adjustments[s.keys[1]])
+	s.Equal(0.0, child.adjustments[s.keys[2]])
+	s.Equal(0.0, child.adjustments[s.keys[3]])
+
+	// Inherited keys are passed on to further descendants
+	grandchild := NewModifier(s.policy)
+	grandchild.Inherit(child)
+	s.Equal(0.5, grandchild.adjustments[s.keys[0]])
+	s.Equal(-0.1, grandchild.adjustments[s.keys[1]])
+}
+
+func (s AdjustmentTestSuite) TestMarshalJSON_RoundTrip() {
+	m := NewModifier(s.policy)
+	m.Load(map[string]float64{s.keys[0]: 0.5, s.keys[1]: 0.0})
+
+	data, err := json.Marshal(m)
+	s.Require().Nil(err)
+	s.JSONEq(`{"A": 0.5, "B": 0.0}`, string(data))
+
+	loaded := NewModifier(s.policy)
+	s.Require().Nil(json.Unmarshal(data, &loaded))
+	s.Equal(m.adjustments, loaded.adjustments)
+	s.Equal(m.declared, loaded.declared)
+}
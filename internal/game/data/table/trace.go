@@ -0,0 +1,78 @@
+//------------------------------------------------------------------------------
+//    Copyright 2019 Jeff Sharpe (zeropointx.io)
+//
+//    Licensed under the Apache License, Version 2.0 (the "License");
+//    you may not use this file except in compliance with the License.
+//    You may obtain a copy of the License at
+//
+//        http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS,
+//    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//    See the License for the specific language governing permissions and
+//    limitations under the License.
+//------------------------------------------------------------------------------
+
+package table
+
+// SourcedModifier pairs a Modifier with a description of where it came from,
+// such as "race:DWRF" or "profession:Hunter".
+type SourcedModifier struct {
+	Source   string
+	Modifier Modifier
+}
+
+// Contribution records the effect of a single sourced modifier on one value.
+type Contribution struct {
+	Source string  `json:"source"`
+	Factor float64 `json:"factor"`
+	Value  float64 `json:"value"`
+}
+
+// Derivation describes how a single value was produced from its base value.
+type Derivation struct {
+	Base          float64        `json:"base"`
+	Contributions []Contribution `json:"contributions"`
+	Final         float64        `json:"final"`
+}
+
+// Breakdown holds the derivation for every key of a traced table.
+type Breakdown map[string]Derivation
+
+func NewSourcedModifier(source string, modifier Modifier) SourcedModifier {
+	return SourcedModifier{Source: source, Modifier: modifier}
+}
+
+// Trace applies each modifier in order, exactly as successive calls to Adjust
+// would, and records the intermediate value after every step.
+func (t *Values) Trace(modifiers ...SourcedModifier) (Values, Breakdown) {
+	adjusted := t.Copy()
+	breakdown := make(Breakdown, len(t.policy.ValidKeys()))
+
+	for _, key := range t.policy.ValidKeys() {
+		breakdown[key] = Derivation{Base: adjusted.Get(key), Contributions: make([]Contribution, 0, len(modifiers))}
+	}
+
+	for _, sm := range modifiers {
+		adjusted = adjusted.Adjust(sm.Modifier)
+
+		for _, key := range t.policy.ValidKeys() {
+			d := breakdown[key]
+			d.Contributions = append(d.Contributions, Contribution{
+				Source: sm.Source,
+				Factor: sm.Modifier.Factor(key),
+				Value:  adjusted.Get(key),
+			})
+			breakdown[key] = d
+		}
+	}
+
+	for _, key := range t.policy.ValidKeys() {
+		d := breakdown[key]
+		d.Final = adjusted.Get(key)
+		breakdown[key] = d
+	}
+
+	return adjusted, breakdown
+}
@@ -0,0 +1,95 @@
+//------------------------------------------------------------------------------
+//    Copyright 2019 Jeff Sharpe (zeropointx.io)
+//
+//    Licensed under the Apache License, Version 2.0 (the "License");
+//    you may not use this file except in compliance with the License.
+//    You may obtain a copy of the License at
+//
+//        http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS,
+//    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//    See the License for the specific language governing permissions and
+//    limitations under the License.
+//------------------------------------------------------------------------------
+
+package table
+
+import (
+	"encoding/json"
+	"github.com/stretchr/testify/suite"
+	"testing"
+)
+
+type TraceTestSuite struct {
+	suite.Suite
+}
+
+func TestTraceSuite(t *testing.T) {
+	suite.Run(t, new(TraceTestSuite))
+}
+
+func (s *TraceTestSuite) TestTrace_NoModifiers() {
+	v := NewValues(testPolicy)
+	v.Set("A", 6.0)
+
+	result, breakdown := v.Trace()
+
+	s.Equal(6.0, result.Get("A"))
+	s.Len(breakdown, len(testPolicy.ValidKeys()))
+	s.Equal(6.0, breakdown["A"].Base)
+	s.Equal(6.0, breakdown["A"].Final)
+	s.Empty(breakdown["A"].Contributions)
+}
+
+func (s *TraceTestSuite) TestTrace_Ordered() {
+	v := NewValues(testPolicy)
+	v.Set("A", 6.0)
+	v.Set("B", 8.0)
+
+	m1 := NewModifier(testPolicy)
+	m1.set("A", 0.5)
+
+	m2 := NewModifier(testPolicy)
+	m2.set("A", -0.5)
+	m2.set("B", 0.5)
+
+	result, breakdown := v.Trace(NewSourcedModifier("race:TEST", m1), NewSourcedModifier("caste:TEST", m2))
+
+	// Matches successive adjustments
+	step := v.Adjust(m1)
+	expected := step.Adjust(m2)
+	for _, k := range testPolicy.ValidKeys() {
+		s.Equal(expected.Get(k), result.Get(k))
+		s.Equal(expected.Get(k), breakdown[k].Final)
+	}
+
+	a := breakdown["A"]
+	s.Equal(6.0, a.Base)
+	s.Require().Len(a.Contributions, 2)
+	s.Equal("race:TEST", a.Contributions[0].Source)
+	s.Equal(1.5, a.Contributions[0].Factor)
+	s.InDelta(9.0, a.Contributions[0].Value, 0.0001)
+	s.Equal("caste:TEST", a.Contributions[1].Source)
+	s.Equal(0.5, a.Contributions[1].Factor)
+	s.InDelta(4.5, a.Contributions[1].Value, 0.0001)
+
+	// Clamping is visible in the intermediate values
+	b := breakdown["B"]
+	s.Equal(8.0, b.Contributions[0].Value)
+	s.Equal(testPolicy.MaxValue(), b.Contributions[1].Value)
+}
+
+func (s *TraceTestSuite) TestTrace_Marshal() {
+	v := NewValues(testPolicy)
+
+	m := NewModifier(testPolicy)
+	m.set("A", 0.2)
+
+	_, breakdown := v.Trace(NewSourcedModifier("effect:Blessed", m))
+
+	data, err := json.Marshal(breakdown)
+	s.Require().Nil(err)
+	s.Contains(string(data), "effect:Blessed")
+}
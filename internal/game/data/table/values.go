@@ -18,15 +18,25 @@ package table
 
 import (
 	"encoding/json"
+	"sync/atomic"
 )
 
+// lastRevision is shared by every table in the process, so a revision never
+// repeats even when a table is replaced by a fresh copy.
+var lastRevision uint64
+
+func nextRevision() uint64 {
+	return atomic.AddUint64(&lastRevision, 1)
+}
+
 type Values struct {
-	values map[string]float64
-	policy *Policy
+	values   map[string]float64
+	policy   *Policy
+	revision uint64
 }
 
 func NewValues(policy *Policy) Values {
-	at := Values{values: make(map[string]float64), policy: policy}
+	at := Values{values: make(map[string]float64), policy: policy, revision: nextRevision()}
 
 	for _, attr := range policy.ValidKeys() {
 		at.values[attr] = policy.defaultValue
@@ -66,9 +76,23 @@ func (t *Values) Load(values map[string]float64) {
 func (t *Values) Set(key string, value float64) {
 	if t.policy.ValidKey(key) {
 		t.values[key] = t.policy.Clamp(value)
+		t.revision = nextRevision()
 	}
 }
 
+// Revision changes every time a value is set, allowing dependent caches to
+// detect changes. Revisions are unique across all tables in the process, so a
+// table overwritten with a new one is never mistaken for the old one.
+func (t *Values) Revision() uint64 {
+	return t.revision
+}
+
+// Policy returns the policy of the table, or nil for a table that was never
+// created with one.
+func (t *Values) Policy() *Policy {
+	return t.policy
+}
+
 func (t *Values) Get(key string) float64 {
 	if value, ok := t.values[key]; ok {
 		return value
@@ -77,6 +101,14 @@ func (t *Values) Get(key string) float64 {
 	}
 }
 
+func (t Values) MarshalJSON() ([]byte, error) {
+	return json.Marshal(t.values)
+}
+
+func (t Values) MarshalYAML() (interface{}, error) {
+	return t.values, nil
+}
+
 func (t *Values) UnmarshalJSON(data []byte) error {
 	valueTable := map[string]float64{}
 	json.Unmarshal(data, &valueTable)
@@ -271,3 +271,30 @@ type composedExample struct {
 func TestValuesSuite(t *testing.T) {
 	suite.Run(t, new(ValuesTestSuite))
 }
+
+func (t *ValuesTestSuite) TestMarshalJSON_RoundTrip() {
+	v := NewValues(testPolicy)
+	v.Set("A", 6.5)
+
+	data, err := json.Marshal(v)
+	t.Require().Nil(err)
+
+	r := NewValues(testPolicy)
+	err = json.Unmarshal(data, &r)
+	t.Require().Nil(err)
+
+	for _, k := range testPolicy.ValidKeys() {
+		t.Equal(v.Get(k), r.Get(k))
+	}
+}
+
+func (t *ValuesTestSuite) TestRevision() {
+	v := NewValues(testPolicy)
+	r := v.Revision()
+
+	v.Set("E", 6.0)
+	t.Equal(r, v.Revision())
+
+	v.Set("A", 6.0)
+	t.True(v.Revision() > r)
+}
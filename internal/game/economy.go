@@ -0,0 +1,106 @@
+//------------------------------------------------------------------------------
+//    Copyright 2019 Jeff Sharpe (zeropointx.io)
+//
+//    Licensed under the Apache License, Version 2.0 (the "License");
+//    you may not use this file except in compliance with the License.
+//    You may obtain a copy of the License at
+//
+//        http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS,
+//    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//    See the License for the specific language governing permissions and
+//    limitations under the License.
+//------------------------------------------------------------------------------
+
+package game
+
+import (
+	"github.com/zpxio/heromanager/internal/game/data/economy"
+	"github.com/zpxio/heromanager/internal/game/state"
+	"log"
+)
+
+func (world *World) Economy() *economy.Config {
+	return world.content().Economy
+}
+
+// Treasury returns the money held by the guild.
+func (world *World) Treasury() int {
+	world.stateLock.Lock()
+	defer world.stateLock.Unlock()
+
+	return world.state.Treasury
+}
+
+// Ledger lists the transactions matching a filter, oldest first.
+func (world *World) Ledger(f state.LedgerFilter) []state.Transaction {
+	world.stateLock.Lock()
+	defer world.stateLock.Unlock()
+
+	return world.state.Transactions(f)
+}
+
+// Wages returns the wage each hero is paid every payday, keyed by hero.
+func (world *World) Wages() map[string]int {
+	world.stateLock.Lock()
+	defer world.stateLock.Unlock()
+
+	data := world.content()
+	wages := make(map[string]int, len(world.state.Heroes))
+	for _, h := range world.state.Heroes {
+		wages[h.Id] = h.Wage(data.Economy.Wage, data.Classifiers)
+	}
+
+	return wages
+}
+
+// Payroll is a TickListener paying the guild's wages and upkeep out of the
+// treasury every payday.
+type Payroll struct {
+	world *World
+}
+
+func (p *Payroll) OnTick(id uint64) {
+	p.world.stateLock.Lock()
+	defer p.world.stateLock.Unlock()
+
+	p.world.payday(id)
+}
+
+// payday funds the treasury once the economy is defined, then pays every
+// hero their wage and the guild upkeep when a tick is a payday. The treasury
+// may run into debt. It must be called with the state lock held.
+func (world *World) payday(tick uint64) {
+	data := world.content()
+	config := data.Economy
+	if config.Empty() {
+		return
+	}
+
+	if !world.state.Funded {
+		world.state.Funded = true
+		if config.Starting > 0 {
+			world.state.Record(state.Transaction{Tick: tick, Kind: state.TransactionOpening, Amount: config.Starting, Memo: "starting funds"})
+		}
+	}
+
+	if !config.Payday(tick) {
+		return
+	}
+
+	for _, h := range world.state.Heroes {
+		if wage := h.Wage(config.Wage, data.Classifiers); wage > 0 {
+			world.state.Record(state.Transaction{Tick: tick, Kind: state.TransactionWage, Amount: -wage, Hero: h.Id, Memo: "wages for " + h.Name})
+		}
+	}
+	if config.Upkeep > 0 {
+		world.state.Record(state.Transaction{Tick: tick, Kind: state.TransactionUpkeep, Amount: -config.Upkeep, Memo: "guild upkeep"})
+	}
+
+	log.Printf("Payday at T+%d: treasury holds %d %s", tick, world.state.Treasury, config.Currency)
+	if world.state.Treasury < 0 {
+		log.Printf("WARNING: The guild is in debt: %d %s", world.state.Treasury, config.Currency)
+	}
+}
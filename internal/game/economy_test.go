@@ -0,0 +1,96 @@
+//------------------------------------------------------------------------------
+//    Copyright 2019 Jeff Sharpe (zeropointx.io)
+//
+//    Licensed under the Apache License, Version 2.0 (the "License");
+//    you may not use this file except in compliance with the License.
+//    You may obtain a copy of the License at
+//
+//        http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS,
+//    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//    See the License for the specific language governing permissions and
+//    limitations under the License.
+//------------------------------------------------------------------------------
+
+package game
+
+import (
+	"github.com/zpxio/heromanager/internal/game/state"
+	"github.com/zpxio/heromanager/internal/game/state/hero"
+)
+
+func (s *WorldTestSuite) economyWorld() *World {
+	s.writeData("castes/core.yml", "Noble:\n  name: Noble\n  wage: 20\nSerf:\n  name: Serf\n")
+	s.writeData("economy.yml", "currency: crowns\nstarting: 100\ninterval: 5\nupkeep: 10\nwage: 3\n")
+
+	w := s.loadWorld()
+	w.state.Heroes = append(w.state.Heroes,
+		hero.Hero{Id: "H1", Name: "Hilda", Race: "Dwarf", Caste: "Noble"},
+		hero.Hero{Id: "H2", Name: "Hob", Race: "Elf", Caste: "Serf"},
+	)
+
+	return w
+}
+
+func (s *WorldTestSuite) TestPayroll() {
+	w := s.economyWorld()
+	payroll := &Payroll{world: w}
+	s.Equal(map[string]int{"H1": 23, "H2": 3}, w.Wages())
+
+	payroll.OnTick(1)
+	s.Equal(100, w.Treasury())
+	s.True(w.state.Funded)
+
+	payroll.OnTick(4)
+	s.Len(w.Ledger(state.LedgerFilter{}), 1)
+
+	payroll.OnTick(5)
+	s.Equal(64, w.Treasury())
+	s.Equal([]state.Transaction{
+		{Tick: 1, Kind: state.TransactionOpening, Amount: 100, Balance: 100, Memo: "starting funds"},
+		{Tick: 5, Kind: state.TransactionWage, Amount: -23, Balance: 77, Hero: "H1", Memo: "wages for Hilda"},
+		{Tick: 5, Kind: state.TransactionWage, Amount: -3, Balance: 74, Hero: "H2", Memo: "wages for Hob"},
+		{Tick: 5, Kind: state.TransactionUpkeep, Amount: -10, Balance: 64, Memo: "guild upkeep"},
+	}, w.Ledger(state.LedgerFilter{}))
+}
+
+func (s *WorldTestSuite) TestPayroll_Debt() {
+	w := s.economyWorld()
+	payroll := &Payroll{world: w}
+
+	for _, tick := range []uint64{5, 10, 15} {
+		payroll.OnTick(tick)
+	}
+
+	s.Equal(-8, w.Treasury())
+	s.Len(w.Ledger(state.LedgerFilter{Kind: state.TransactionOpening}), 1)
+}
+
+func (s *WorldTestSuite) TestPayroll_NoEconomy() {
+	w := s.loadWorld()
+	w.state.Heroes = append(w.state.Heroes, hero.Hero{Id: "H1", Race: "Dwarf"})
+
+	(&Payroll{world: w}).OnTick(5)
+
+	s.Equal(0, w.Treasury())
+	s.Empty(w.Ledger(state.LedgerFilter{}))
+	s.False(w.state.Funded)
+}
+
+func (s *WorldTestSuite) TestLedger() {
+	w := s.economyWorld()
+	payroll := &Payroll{world: w}
+	payroll.OnTick(5)
+	payroll.OnTick(10)
+
+	s.Len(w.Ledger(state.LedgerFilter{Kind: state.TransactionWage}), 4)
+	s.Len(w.Ledger(state.LedgerFilter{Hero: "H1"}), 2)
+	s.Len(w.Ledger(state.LedgerFilter{Since: 10}), 3)
+
+	latest := w.Ledger(state.LedgerFilter{Limit: 1})
+	s.Require().Len(latest, 1)
+	s.Equal(state.TransactionUpkeep, latest[0].Kind)
+	s.Equal(28, latest[0].Balance)
+}
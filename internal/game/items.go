@@ -0,0 +1,178 @@
+//------------------------------------------------------------------------------
+//    Copyright 2019 Jeff Sharpe (zeropointx.io)
+//
+//    Licensed under the Apache License, Version 2.0 (the "License");
+//    you may not use this file except in compliance with the License.
+//    You may obtain a copy of the License at
+//
+//        http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS,
+//    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//    See the License for the specific language governing permissions and
+//    limitations under the License.
+//------------------------------------------------------------------------------
+
+package game
+
+import (
+	"fmt"
+	"github.com/zpxio/heromanager/internal/game/data/content"
+	"github.com/zpxio/heromanager/internal/game/data/derived"
+	"github.com/zpxio/heromanager/internal/game/data/item"
+	"github.com/zpxio/heromanager/internal/game/data/table"
+	"github.com/zpxio/heromanager/internal/game/state/hero"
+	"log"
+)
+
+// CarryCapacityStat names the derived stat limiting the weight a hero can
+// carry. Heroes carry without limit when it is not defined.
+const CarryCapacityStat = "CarryCapacity"
+
+func (world *World) Items() *item.Definitions {
+	return world.content().Items
+}
+
+// effective returns the attributes of a hero with its classifiers and
+// equipment applied.
+func effective(h *hero.Hero, data *content.Set) table.Values {
+	return h.EffectiveAttributes(data.Classifiers, h.EquipmentModifiers(data.Items)...)
+}
+
+// Stash counts the items held by the guild, keyed by item ID.
+func (world *World) Stash() map[string]int {
+	world.stateLock.Lock()
+	defer world.stateLock.Unlock()
+
+	stash := make(map[string]int, len(world.state.Stash))
+	for id, count := range world.state.Stash {
+		stash[id] = count
+	}
+
+	return stash
+}
+
+// StockStash adds items to the guild stash.
+func (world *World) StockStash(id string, count int) error {
+	if _, found := world.Items().Resolve(id); !found {
+		return fmt.Errorf("unknown item: %s", id)
+	}
+	if count < 1 {
+		return fmt.Errorf("cannot stock %d of %s", count, id)
+	}
+
+	world.stateLock.Lock()
+	defer world.stateLock.Unlock()
+
+	world.stockStash(id, count)
+
+	return nil
+}
+
+// stockStash adds items to the guild stash. It must be called with the state
+// lock held.
+func (world *World) stockStash(id string, count int) {
+	if world.state.Stash == nil {
+		world.state.Stash = make(map[string]int)
+	}
+	world.state.Stash[id] += count
+}
+
+// availableHero finds a hero that is not away on an expedition. It must be
+// called with the state lock held.
+func (world *World) availableHero(id string) (*hero.Hero, error) {
+	h, found := world.FindHero(id)
+	if !found {
+		return nil, fmt.Errorf("unknown hero: %s", id)
+	}
+	if world.state.Busy(id) {
+		return nil, fmt.Errorf("hero is on an expedition: %s", id)
+	}
+
+	return h, nil
+}
+
+// GiveItem moves an item from the guild stash to a hero, as long as the
+// hero can carry its weight.
+func (world *World) GiveItem(heroId string, itemId string) error {
+	data := world.content()
+	i, found := data.Items.Resolve(itemId)
+	if !found {
+		return fmt.Errorf("unknown item: %s", itemId)
+	}
+
+	world.stateLock.Lock()
+	defer world.stateLock.Unlock()
+
+	h, err := world.availableHero(heroId)
+	if err != nil {
+		return err
+	}
+	if world.state.Stash[itemId] < 1 {
+		return fmt.Errorf("the stash holds no %s", itemId)
+	}
+	if _, limited := data.Derived.Resolve(CarryCapacityStat); limited {
+		values := effective(h, data)
+		capacity := derived.NewStats(data.Derived, &values).Get(CarryCapacityStat)
+		if h.Load(data.Items)+i.Weight > capacity {
+			return fmt.Errorf("hero %s cannot carry %s: capacity %v", heroId, itemId, capacity)
+		}
+	}
+
+	world.state.Stash[itemId]--
+	if world.state.Stash[itemId] == 0 {
+		delete(world.state.Stash, itemId)
+	}
+	h.Give(itemId, 1)
+	log.Printf("Hero %s takes %s from the stash", heroId, itemId)
+
+	return nil
+}
+
+// StoreItem moves an item a hero carries into the guild stash.
+func (world *World) StoreItem(heroId string, itemId string) error {
+	world.stateLock.Lock()
+	defer world.stateLock.Unlock()
+
+	h, err := world.availableHero(heroId)
+	if err != nil {
+		return err
+	}
+	if !h.Take(itemId, 1) {
+		return fmt.Errorf("hero %s does not carry %s", heroId, itemId)
+	}
+	world.stockStash(itemId, 1)
+	log.Printf("Hero %s stores %s in the stash", heroId, itemId)
+
+	return nil
+}
+
+// EquipItem equips an item a hero carries, returning the ID of any item it
+// replaced.
+func (world *World) EquipItem(heroId string, itemId string) (string, error) {
+	data := world.content()
+
+	world.stateLock.Lock()
+	defer world.stateLock.Unlock()
+
+	h, err := world.availableHero(heroId)
+	if err != nil {
+		return "", err
+	}
+
+	return h.Equip(itemId, data.Items, data.Classifiers)
+}
+
+// UnequipItem returns the item in a slot to the hero's inventory.
+func (world *World) UnequipItem(heroId string, slot string) (string, error) {
+	world.stateLock.Lock()
+	defer world.stateLock.Unlock()
+
+	h, err := world.availableHero(heroId)
+	if err != nil {
+		return "", err
+	}
+
+	return h.Unequip(slot)
+}
@@ -0,0 +1,135 @@
+//------------------------------------------------------------------------------
+//    Copyright 2019 Jeff Sharpe (zeropointx.io)
+//
+//    Licensed under the Apache License, Version 2.0 (the "License");
+//    you may not use this file except in compliance with the License.
+//    You may obtain a copy of the License at
+//
+//        http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS,
+//    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//    See the License for the specific language governing permissions and
+//    limitations under the License.
+//------------------------------------------------------------------------------
+
+package game
+
+import (
+	"github.com/zpxio/heromanager/internal/game/data/attributes"
+	"github.com/zpxio/heromanager/internal/game/data/item"
+	"github.com/zpxio/heromanager/internal/game/state"
+	"github.com/zpxio/heromanager/internal/game/state/hero"
+)
+
+const testItems = `
+Anvil:
+  name: Anvil
+  weight: 40
+Hammer:
+  name: Hammer
+  slot: weapon
+  weight: 5
+  attributes: {Brawn: 0.5}
+Tiara:
+  name: Tiara
+  slot: head
+  requires:
+    all:
+      castes: [Noble]
+`
+
+func (s *WorldTestSuite) itemWorld() *World {
+	s.writeData("items.yml", testItems)
+	s.writeData("derived.yml", `
+Health:
+  name: Health
+  formula: "Vigor * 5 + Brawn"
+Initiative:
+  name: Initiative
+  formula: "Finesse * 2"
+CarryCapacity:
+  name: Carry Capacity
+  formula: "Brawn"
+`)
+
+	w := s.loadWorld()
+	h := hero.Hero{Id: "H1", Race: "Elf", Caste: "Serf", Attributes: attributes.NewAttributeValues()}
+	h.Attributes.Set(attributes.Brawn, 30.0)
+	w.state.Heroes = append(w.state.Heroes, h)
+
+	return w
+}
+
+func (s *WorldTestSuite) TestStash() {
+	w := s.itemWorld()
+
+	s.Require().Nil(w.StockStash("Hammer", 2))
+	s.NotNil(w.StockStash("Sceptre", 1))
+	s.NotNil(w.StockStash("Hammer", 0))
+
+	s.Require().Nil(w.GiveItem("H1", "Hammer"))
+	s.Equal(map[string]int{"Hammer": 1}, w.Stash())
+	h, _ := w.FindHero("H1")
+	s.Equal(map[string]int{"Hammer": 1}, h.Inventory)
+
+	s.Require().Nil(w.StoreItem("H1", "Hammer"))
+	s.Equal(map[string]int{"Hammer": 2}, w.Stash())
+	s.Empty(h.Inventory)
+	s.NotNil(w.StoreItem("H1", "Hammer"))
+	s.NotNil(w.GiveItem("H1", "Tiara"))
+	s.NotNil(w.GiveItem("H9", "Hammer"))
+}
+
+func (s *WorldTestSuite) TestGiveItem_Capacity() {
+	w := s.itemWorld()
+	s.Require().Nil(w.StockStash("Anvil", 1))
+
+	err := w.GiveItem("H1", "Anvil")
+	s.Require().NotNil(err)
+	s.Contains(err.Error(), "cannot carry Anvil")
+	s.Equal(map[string]int{"Anvil": 1}, w.Stash())
+}
+
+func (s *WorldTestSuite) TestEquipItem() {
+	w := s.itemWorld()
+	s.Require().Nil(w.StockStash("Hammer", 1))
+	s.Require().Nil(w.StockStash("Tiara", 1))
+	s.Require().Nil(w.GiveItem("H1", "Hammer"))
+	s.Require().Nil(w.GiveItem("H1", "Tiara"))
+
+	_, err := w.EquipItem("H1", "Hammer")
+	s.Require().Nil(err)
+	_, err = w.EquipItem("H1", "Tiara")
+	s.NotNil(err)
+
+	h, _ := w.FindHero("H1")
+	values := effective(h, w.content())
+	s.Equal(45.0, values.Get(attributes.Brawn))
+
+	w.state.Expeditions = append(w.state.Expeditions, state.Expedition{Id: "E1", Heroes: []string{"H1"}})
+	_, err = w.UnequipItem("H1", item.SlotWeapon)
+	s.NotNil(err)
+	w.state.Expeditions = nil
+
+	removed, err := w.UnequipItem("H1", item.SlotWeapon)
+	s.Require().Nil(err)
+	s.Equal("Hammer", removed)
+}
+
+func (s *WorldTestSuite) TestReload_OrphanedItems() {
+	w := s.itemWorld()
+	s.Require().Nil(w.StockStash("Hammer", 1))
+	s.Require().Nil(w.StockStash("Anvil", 1))
+	s.Require().Nil(w.GiveItem("H1", "Hammer"))
+	_, err := w.EquipItem("H1", "Hammer")
+	s.Require().Nil(err)
+	s.writeData("items.yml", "Tiara:\n  name: Tiara\n  slot: head\n")
+
+	_, err = w.Reload()
+	s.Require().NotNil(err)
+	orphans, ok := err.(*OrphanError)
+	s.Require().True(ok)
+	s.Equal([]string{"hero H1 equips missing item: Hammer", "stash holds missing item: Anvil"}, orphans.Orphans)
+}
@@ -0,0 +1,178 @@
+//------------------------------------------------------------------------------
+//    Copyright 2019 Jeff Sharpe (zeropointx.io)
+//
+//    Licensed under the Apache License, Version 2.0 (the "License");
+//    you may not use this file except in compliance with the License.
+//    You may obtain a copy of the License at
+//
+//        http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS,
+//    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//    See the License for the specific language governing permissions and
+//    limitations under the License.
+//------------------------------------------------------------------------------
+
+package game
+
+import (
+	"fmt"
+	"github.com/zpxio/heromanager/internal/game/data/classifier"
+	"github.com/zpxio/heromanager/internal/game/state"
+	"github.com/zpxio/heromanager/internal/game/state/hero"
+	"log"
+	"strings"
+)
+
+// MaxPartySize limits how many heroes may travel together.
+const MaxPartySize = 6
+
+// CompositionError rejects a party that breaks the composition rules.
+type CompositionError struct {
+	Party   string
+	Reasons []string
+}
+
+func (e *CompositionError) Error() string {
+	return fmt.Sprintf("invalid party %s: %s", e.Party, strings.Join(e.Reasons, "; "))
+}
+
+// Parties lists every party.
+func (world *World) Parties() []state.Party {
+	world.stateLock.Lock()
+	defer world.stateLock.Unlock()
+
+	return append([]state.Party{}, world.state.Parties...)
+}
+
+func (world *World) FindParty(id string) (state.Party, bool) {
+	world.stateLock.Lock()
+	defer world.stateLock.Unlock()
+
+	for _, p := range world.state.Parties {
+		if p.Id == id {
+			return p, true
+		}
+	}
+
+	return state.Party{}, false
+}
+
+// CreateParty forms a party from heroes not already in one. Members without
+// a role take the middle of the formation, and the first member leads when
+// no leader is given. Heroes whose classifiers are rivals refuse to join the
+// same party.
+func (world *World) CreateParty(name string, leader string, members []state.Member) (*state.Party, error) {
+	manifest := world.Manifest()
+
+	world.stateLock.Lock()
+	defer world.stateLock.Unlock()
+
+	p := state.Party{Name: name, Leader: leader, Members: make([]state.Member, 0, len(members))}
+	for _, m := range members {
+		if m.Role == "" {
+			m.Role = state.RoleMiddle
+		}
+		p.Members = append(p.Members, m)
+	}
+	if p.Leader == "" && len(p.Members) > 0 {
+		p.Leader = p.Members[0].Hero
+	}
+
+	reasons := world.composition(&p, manifest)
+	if len(reasons) > 0 {
+		return nil, &CompositionError{Party: name, Reasons: reasons}
+	}
+
+	world.state.NextParty++
+	p.Id = fmt.Sprintf("P%d", world.state.NextParty)
+	world.state.Parties = append(world.state.Parties, p)
+	log.Printf("Party %s formed: %s", p.Id, strings.Join(p.Heroes(), ", "))
+
+	return &p, nil
+}
+
+// composition lists the rules a party breaks. It must be called with the
+// state lock held.
+func (world *World) composition(p *state.Party, manifest *classifier.ClassifierManifest) []string {
+	reasons := make([]string, 0)
+	if len(p.Members) < 1 || len(p.Members) > MaxPartySize {
+		reasons = append(reasons, fmt.Sprintf("needs 1-%d heroes, found %d", MaxPartySize, len(p.Members)))
+	}
+
+	listed := make(map[string]bool, len(p.Members))
+	for _, m := range p.Members {
+		_, found := world.FindHero(m.Hero)
+		switch {
+		case !found:
+			reasons = append(reasons, "unknown hero: "+m.Hero)
+		case listed[m.Hero]:
+			reasons = append(reasons, "hero listed twice: "+m.Hero)
+		default:
+			if other, found := world.state.PartyOf(m.Hero); found {
+				reasons = append(reasons, fmt.Sprintf("hero %s is already in party %s", m.Hero, other.Id))
+			}
+		}
+		if !state.ValidRole(m.Role) {
+			reasons = append(reasons, fmt.Sprintf("hero %s has unknown role: %s", m.Hero, m.Role))
+		}
+		listed[m.Hero] = true
+	}
+
+	if p.Leader != "" && !p.Has(p.Leader) {
+		reasons = append(reasons, "leader is not a member: "+p.Leader)
+	}
+
+	heroes := make([]*hero.Hero, 0, len(p.Members))
+	for _, id := range p.Heroes() {
+		if h, found := world.FindHero(id); found && !containsHero(heroes, id) {
+			heroes = append(heroes, h)
+		}
+	}
+	for i, h := range heroes {
+		for _, other := range heroes[i+1:] {
+			if rivalries := h.Rivalries(other, manifest); len(rivalries) > 0 {
+				reasons = append(reasons, fmt.Sprintf("heroes %s and %s are rivals: %s", h.Id, other.Id, strings.Join(rivalries, ", ")))
+			}
+		}
+	}
+
+	return reasons
+}
+
+func containsHero(heroes []*hero.Hero, id string) bool {
+	for _, h := range heroes {
+		if h.Id == id {
+			return true
+		}
+	}
+
+	return false
+}
+
+// DisbandParty breaks up a party, leaving its heroes free to join another.
+func (world *World) DisbandParty(id string) error {
+	world.stateLock.Lock()
+	defer world.stateLock.Unlock()
+
+	for i, p := range world.state.Parties {
+		if p.Id == id {
+			world.state.Parties = append(world.state.Parties[:i], world.state.Parties[i+1:]...)
+			log.Printf("Party %s disbanded", id)
+			return nil
+		}
+	}
+
+	return fmt.Errorf("unknown party: %s", id)
+}
+
+// AssignParty sends every member of a party on a quest.
+func (world *World) AssignParty(questId string, partyId string) (*state.Expedition, error) {
+	p, found := world.FindParty(partyId)
+	if !found {
+		return nil, fmt.Errorf("unknown party: %s", partyId)
+	}
+
+	return world.AssignQuest(questId, p.Heroes())
+}
@@ -0,0 +1,104 @@
+//------------------------------------------------------------------------------
+//    Copyright 2019 Jeff Sharpe (zeropointx.io)
+//
+//    Licensed under the Apache License, Version 2.0 (the "License");
+//    you may not use this file except in compliance with the License.
+//    You may obtain a copy of the License at
+//
+//        http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS,
+//    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//    See the License for the specific language governing permissions and
+//    limitations under the License.
+//------------------------------------------------------------------------------
+
+package game
+
+import (
+	"github.com/zpxio/heromanager/internal/game/state"
+	"github.com/zpxio/heromanager/internal/game/state/hero"
+)
+
+func (s *WorldTestSuite) partyWorld() *World {
+	s.writeData("castes/core.yml", "Noble:\n  name: Noble\n  rivals:\n    castes: [Serf]\nSerf:\n  name: Serf\n")
+
+	w := s.loadWorld()
+	w.state.Heroes = append(w.state.Heroes,
+		hero.Hero{Id: "H1", Race: "Dwarf", Caste: "Noble"},
+		hero.Hero{Id: "H2", Race: "Elf", Caste: "Serf"},
+		hero.Hero{Id: "H3", Race: "Dwarf", Caste: "Elder"},
+	)
+
+	return w
+}
+
+func (s *WorldTestSuite) TestCreateParty() {
+	w := s.partyWorld()
+
+	p, err := w.CreateParty("Vanguard", "", []state.Member{{Hero: "H1", Role: state.RoleFront}, {Hero: "H3"}})
+	s.Require().Nil(err)
+	s.Equal("P1", p.Id)
+	s.Equal("H1", p.Leader)
+	s.Equal(state.RoleMiddle, p.Members[1].Role)
+
+	found, ok := w.FindParty("P1")
+	s.True(ok)
+	s.Equal([]string{"H1", "H3"}, found.Heroes())
+	s.Len(w.Parties(), 1)
+}
+
+func (s *WorldTestSuite) TestCreateParty_Rejected() {
+	w := s.partyWorld()
+	_, err := w.CreateParty("Vanguard", "H3", []state.Member{{Hero: "H3"}})
+	s.Require().Nil(err)
+
+	_, err = w.CreateParty("Rabble", "H9", []state.Member{
+		{Hero: "H1"},
+		{Hero: "H2", Role: "flank"},
+		{Hero: "H3"},
+		{Hero: "H1"},
+	})
+	s.Require().NotNil(err)
+	rejected, ok := err.(*CompositionError)
+	s.Require().True(ok)
+	s.Equal([]string{
+		"hero H2 has unknown role: flank",
+		"hero H3 is already in party P1",
+		"hero listed twice: H1",
+		"leader is not a member: H9",
+		"heroes H1 and H2 are rivals: caste Noble and caste Serf",
+	}, rejected.Reasons)
+
+	_, err = w.CreateParty("Empty", "", []state.Member{})
+	s.NotNil(err)
+	s.Len(w.Parties(), 1)
+}
+
+func (s *WorldTestSuite) TestDisbandParty() {
+	w := s.partyWorld()
+	_, err := w.CreateParty("Vanguard", "", []state.Member{{Hero: "H1"}})
+	s.Require().Nil(err)
+
+	s.Require().Nil(w.DisbandParty("P1"))
+	s.Empty(w.Parties())
+	s.NotNil(w.DisbandParty("P1"))
+
+	p, err := w.CreateParty("Second", "", []state.Member{{Hero: "H1"}})
+	s.Require().Nil(err)
+	s.Equal("P2", p.Id)
+}
+
+func (s *WorldTestSuite) TestAssignParty() {
+	w := s.questWorld()
+	_, err := w.CreateParty("Diggers", "", []state.Member{{Hero: "H1"}, {Hero: "H2"}})
+	s.Require().Nil(err)
+
+	e, err := w.AssignParty("Cellar", "P1")
+	s.Require().Nil(err)
+	s.Equal([]string{"H1", "H2"}, e.Heroes)
+
+	_, err = w.AssignParty("Cellar", "P9")
+	s.NotNil(err)
+}
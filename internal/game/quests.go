@@ -0,0 +1,352 @@
+//------------------------------------------------------------------------------
+//    Copyright 2019 Jeff Sharpe (zeropointx.io)
+//
+//    Licensed under the Apache License, Version 2.0 (the "License");
+//    you may not use this file except in compliance with the License.
+//    You may obtain a copy of the License at
+//
+//        http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS,
+//    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//    See the License for the specific language governing permissions and
+//    limitations under the License.
+//------------------------------------------------------------------------------
+
+package game
+
+import (
+	"fmt"
+	"github.com/zpxio/heromanager/internal/game/combat"
+	"github.com/zpxio/heromanager/internal/game/data/attributes"
+	"github.com/zpxio/heromanager/internal/game/data/content"
+	"github.com/zpxio/heromanager/internal/game/data/damage"
+	"github.com/zpxio/heromanager/internal/game/data/loot"
+	"github.com/zpxio/heromanager/internal/game/data/quest"
+	"github.com/zpxio/heromanager/internal/game/state"
+	"github.com/zpxio/heromanager/internal/game/state/hero"
+	"log"
+	"sort"
+	"strings"
+)
+
+// HazardSuccessFactor scales hazard damage when a quest succeeds.
+const HazardSuccessFactor = 0.5
+
+func (world *World) Quests() *quest.Definitions {
+	return world.content().Quests
+}
+
+// AssignmentError rejects sending a party on a quest.
+type AssignmentError struct {
+	Quest   string
+	Reasons []string
+}
+
+func (e *AssignmentError) Error() string {
+	return fmt.Sprintf("cannot assign quest %s: %s", e.Quest, strings.Join(e.Reasons, "; "))
+}
+
+// AssignQuest sends a party of heroes on a quest, due to resolve once the
+// quest's duration has passed.
+func (world *World) AssignQuest(questId string, heroIds []string) (*state.Expedition, error) {
+	data := world.content()
+
+	q, found := data.Quests.Resolve(questId)
+	if !found {
+		return nil, fmt.Errorf("unknown quest: %s", questId)
+	}
+
+	world.stateLock.Lock()
+	defer world.stateLock.Unlock()
+
+	reasons := make([]string, 0)
+	if len(heroIds) < q.Party.Min || len(heroIds) > q.Party.Max {
+		reasons = append(reasons, fmt.Sprintf("needs %d-%d heroes, found %d", q.Party.Min, q.Party.Max, len(heroIds)))
+	}
+
+	assigned := make(map[string]bool, len(heroIds))
+	for _, id := range heroIds {
+		h, found := world.FindHero(id)
+		switch {
+		case !found:
+			reasons = append(reasons, "unknown hero: "+id)
+		case assigned[id]:
+			reasons = append(reasons, "hero listed twice: "+id)
+		case world.state.Busy(id):
+			reasons = append(reasons, "hero is on another expedition: "+id)
+		default:
+			reasons = append(reasons, unmetRequirements(h, q, data)...)
+		}
+		assigned[id] = true
+	}
+	if len(reasons) > 0 {
+		return nil, &AssignmentError{Quest: questId, Reasons: reasons}
+	}
+
+	world.state.NextExpedition++
+	e := state.Expedition{
+		Id:      fmt.Sprintf("E%d", world.state.NextExpedition),
+		Quest:   questId,
+		Heroes:  append([]string{}, heroIds...),
+		Started: world.Tick(),
+		Due:     world.Tick() + q.Duration,
+	}
+	world.state.Expeditions = append(world.state.Expeditions, e)
+	log.Printf("Expedition %s sent on quest %s: %s", e.Id, questId, strings.Join(heroIds, ", "))
+
+	return &e, nil
+}
+
+func unmetRequirements(h *hero.Hero, q *quest.Quest, data *content.Set) []string {
+	unmet := make([]string, 0)
+
+	values := effective(h, data)
+	names := make([]string, 0, len(q.Requires))
+	for name := range q.Requires {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		value := h.Skill(name)
+		if attributes.Policy().ValidKey(name) {
+			value = values.Get(name)
+		}
+		if value < q.Requires[name] {
+			unmet = append(unmet, fmt.Sprintf("hero %s needs %s %v, has %v", h.Id, name, q.Requires[name], value))
+		}
+	}
+
+	return unmet
+}
+
+// Expeditions lists the quests in progress.
+func (world *World) Expeditions() []state.Expedition {
+	world.stateLock.Lock()
+	defer world.stateLock.Unlock()
+
+	return append([]state.Expedition{}, world.state.Expeditions...)
+}
+
+// Results lists the resolved quests, oldest first.
+func (world *World) Results() []state.Result {
+	world.stateLock.Lock()
+	defer world.stateLock.Unlock()
+
+	return append([]state.Result{}, world.state.Results...)
+}
+
+// progressExpeditions resolves every expedition due by a tick. It must be
+// called with the state lock held.
+func (world *World) progressExpeditions(tick uint64) {
+	remaining := make([]state.Expedition, 0, len(world.state.Expeditions))
+
+	for _, e := range world.state.Expeditions {
+		if e.Due > tick {
+			remaining = append(remaining, e)
+			continue
+		}
+
+		result := world.resolveExpedition(e, tick)
+		log.Printf("Expedition %s resolved quest %s: success=%t", e.Id, e.Quest, result.Success)
+		world.state.Results = append(world.state.Results, result)
+	}
+
+	world.state.Expeditions = remaining
+}
+
+// recoverWounds heals every hero not away on an expedition by the recovery
+// rate of the game data. It must be called with the state lock held.
+func (world *World) recoverWounds() {
+	rate := world.content().Progression.Recovery.Rate
+	if rate <= 0.0 {
+		return
+	}
+
+	for i := range world.state.Heroes {
+		h := &world.state.Heroes[i]
+		if h.Wounds > 0.0 && !world.state.Busy(h.Id) {
+			h.Recover(rate)
+		}
+	}
+}
+
+// resolveExpedition fights any enemies the party meets, rolls each quest
+// check with the party member best at it, then applies hazards, reduced by
+// each hero's resistances, and rewards to the party. Losing the fight fails
+// the quest without rolling checks. Defeated enemies drop their loot, and a
+// successful quest its reward loot, into the guild stash and its reward gold
+// into the treasury.
+func (world *World) resolveExpedition(e state.Expedition, tick uint64) state.Result {
+	data := world.content()
+	result := state.Result{Expedition: e, Resolved: tick, Checks: make([]state.CheckOutcome, 0)}
+
+	q, found := data.Quests.Resolve(e.Quest)
+	if !found {
+		log.Printf("ERROR: Expedition %s is on missing quest: %s", e.Id, e.Quest)
+		return result
+	}
+
+	party := make([]*hero.Hero, 0, len(e.Heroes))
+	for _, id := range e.Heroes {
+		if h, found := world.FindHero(id); found {
+			party = append(party, h)
+		}
+	}
+	if len(party) == 0 {
+		return result
+	}
+
+	drops := make(loot.Drops)
+	ctx := lootContext(q, party)
+
+	result.Success = true
+	if table, enemies := world.encounter(q, data); len(enemies) > 0 {
+		fight := world.fight(party, enemies, data)
+		result.Encounter = table
+		result.Combat = &fight
+		result.Success = fight.Winner == combat.SideHeroes
+
+		if result.Success {
+			for _, id := range enemies {
+				if enemy, found := data.Enemies.Resolve(id); found && enemy.Loot != "" {
+					drops.Add(data.Loot.Roll(enemy.Loot, ctx, world.rng))
+				}
+			}
+		}
+	}
+
+	for _, c := range q.Checks {
+		if !result.Success {
+			break
+		}
+
+		skill, err := c.Rated(data.Skills)
+		if err != nil {
+			log.Printf("ERROR: Expedition %s cannot check %s: %s", e.Id, c.Stat(), err)
+			result.Success = false
+			continue
+		}
+
+		best := party[0]
+		bestRating := 0.0
+		for i, h := range party {
+			rating := skill.Rating(effective(h, data), h.Skill(c.Skill))
+			if i == 0 || rating > bestRating {
+				best, bestRating = h, rating
+			}
+		}
+
+		roll := skill.Check(c.Stat(), effective(best, data), best.Skill(c.Skill), c.Difficulty, world.rng)
+		result.Checks = append(result.Checks, state.CheckOutcome{Hero: best.Id, Result: roll})
+		result.Success = result.Success && roll.Success
+	}
+
+	if len(q.Hazards) > 0 {
+		result.Damage = make(map[string]map[string]float64, len(party))
+		for _, h := range party {
+			resistances := h.Resistances(data.Classifiers, data.Items)
+			taken := make(map[string]float64, len(q.Hazards))
+			for _, hazard := range q.Hazards {
+				amount := damage.Apply(hazard.Damage, hazard.Type, resistances)
+				if result.Success {
+					amount *= HazardSuccessFactor
+				}
+				taken[hazard.Type] += amount
+				h.Wounds += amount
+			}
+			result.Damage[h.Id] = taken
+		}
+	}
+
+	if result.Success && q.Rewards.Experience > 0 {
+		result.Rewards = make(map[string]hero.Advancement, len(party))
+		for _, h := range party {
+			advancement, err := h.GainExperience(q.Rewards.Experience, data.Progression, data.Classifiers)
+			if err != nil {
+				log.Printf("ERROR: Hero %s cannot gain experience: %s", h.Id, err)
+				continue
+			}
+			result.Rewards[h.Id] = *advancement
+		}
+	}
+
+	if result.Success && q.Rewards.Gold > 0 {
+		result.Gold = q.Rewards.Gold
+		world.state.Record(state.Transaction{Tick: tick, Kind: state.TransactionQuest, Amount: q.Rewards.Gold, Memo: "rewards for quest " + e.Quest})
+	}
+	if result.Success && q.Rewards.Loot != "" {
+		drops.Add(data.Loot.Roll(q.Rewards.Loot, ctx, world.rng))
+	}
+	if len(drops) > 0 {
+		result.Loot = drops
+		for id, count := range drops {
+			world.stockStash(id, count)
+		}
+	}
+
+	return result
+}
+
+// lootContext describes a party on a quest for rolling loot.
+func lootContext(q *quest.Quest, party []*hero.Hero) loot.Context {
+	ctx := loot.Context{Tier: q.Tier, Professions: make([]string, 0, len(party))}
+	for _, h := range party {
+		ctx.Professions = append(ctx.Professions, h.Profession)
+	}
+
+	return ctx
+}
+
+// encounter rolls the enemies a quest meets, from the table it names or else
+// one covering its difficulty. Quests with neither meet no enemies.
+func (world *World) encounter(q *quest.Quest, data *content.Set) (string, []string) {
+	id := q.Encounter
+	if id == "" {
+		if q.Difficulty <= 0 {
+			return "", nil
+		}
+
+		selected, found := data.Encounters.Select(q.Difficulty, world.rng)
+		if !found {
+			log.Printf("WARNING: No encounter table covers difficulty %v", q.Difficulty)
+			return "", nil
+		}
+		id = selected
+	}
+
+	table, found := data.Encounters.Resolve(id)
+	if !found {
+		log.Printf("ERROR: Missing encounter table: %s", id)
+		return "", nil
+	}
+
+	return id, table.Roll(data.Enemies, world.rng)
+}
+
+// fight resolves combat between a party and rolled enemies, wounding the
+// heroes by the damage they took. Heroes fight in their party formation.
+func (world *World) fight(party []*hero.Hero, enemies []string, data *content.Set) combat.Result {
+	combatants := make([]*combat.Combatant, 0, len(party)+len(enemies))
+	for _, h := range party {
+		role := state.RoleMiddle
+		if p, found := world.state.PartyOf(h.Id); found {
+			role = p.Role(h.Id)
+		}
+		combatants = append(combatants, combat.FromHero(h, state.Rank(role), data.Classifiers, data.Items, data.Derived))
+	}
+	for i, id := range enemies {
+		if e, found := data.Enemies.Resolve(id); found {
+			combatants = append(combatants, combat.FromEnemy(id, i+1, e, data.Derived))
+		}
+	}
+
+	result := combat.New(world.rng, combatants...).Run()
+	for _, h := range party {
+		h.Wounds += result.Damage[h.Id]
+	}
+
+	return result
+}
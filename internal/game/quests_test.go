@@ -0,0 +1,321 @@
+//------------------------------------------------------------------------------
+//    Copyright 2019 Jeff Sharpe (zeropointx.io)
+//
+//    Licensed under the Apache License, Version 2.0 (the "License");
+//    you may not use this file except in compliance with the License.
+//    You may obtain a copy of the License at
+//
+//        http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS,
+//    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//    See the License for the specific language governing permissions and
+//    limitations under the License.
+//------------------------------------------------------------------------------
+
+package game
+
+import (
+	"github.com/zpxio/heromanager/internal/game/combat"
+	"github.com/zpxio/heromanager/internal/game/data/attributes"
+	"github.com/zpxio/heromanager/internal/game/data/damage"
+	"github.com/zpxio/heromanager/internal/game/state"
+	"github.com/zpxio/heromanager/internal/game/state/hero"
+	"math/rand"
+)
+
+const testQuests = `
+Cellar:
+  name: Cellar
+  duration: 2
+  party: {min: 1, max: 2}
+  requires: {Brawn: 20}
+  checks:
+    - attribute: Brawn
+      difficulty: 0
+    - skill: Lore
+      difficulty: 0
+  hazards:
+    - type: Toxin
+      damage: 4
+  rewards: {xp: 150}
+
+Impossible:
+  name: Impossible
+  duration: 1
+  party: {min: 1, max: 1}
+  checks:
+    - attribute: Insight
+      difficulty: 1000
+  hazards:
+    - type: Fire
+      damage: 10
+  rewards: {xp: 500}
+
+Den:
+  name: Den
+  duration: 1
+  party: {min: 1, max: 2}
+  encounter: Vermin
+  rewards: {xp: 10}
+
+Lair:
+  name: Lair
+  duration: 1
+  party: {min: 1, max: 1}
+  difficulty: 9
+  checks:
+    - attribute: Brawn
+      difficulty: 0
+  rewards: {xp: 10}
+
+Vault:
+  name: Vault
+  duration: 1
+  party: {min: 1, max: 1}
+  tier: 2
+  rewards: {xp: 10, gold: 40, loot: Hoard}
+`
+
+const testEnemies = `
+Rat:
+  name: Rat
+  attributes: {Vigor: 1}
+  loot: Scraps
+  attacks:
+    - {name: Bite, type: Toxin, damage: 1}
+Troll:
+  name: Troll
+  attributes: {Vigor: 100, Finesse: 50}
+  attacks:
+    - {name: Club, type: Physical, damage: 40}
+`
+
+const testEncounters = `
+Vermin:
+  name: Vermin
+  difficulty: {min: 1, max: 3}
+  size: {min: 2, max: 2}
+  enemies:
+    - enemy: Rat
+Bridge:
+  name: Bridge
+  difficulty: {min: 8, max: 10}
+  size: {min: 1, max: 1}
+  enemies:
+    - enemy: Troll
+`
+
+const testLoot = `
+Scraps:
+  name: Scraps
+  guaranteed:
+    - item: Tail
+Hoard:
+  name: Hoard
+  guaranteed:
+    - item: Coin
+      quantity: {min: 5, max: 5}
+    - item: Crown
+      when:
+        tier: {min: 3, max: 5}
+`
+
+func (s *WorldTestSuite) questWorld() *World {
+	s.writeData("skills.yml", "Lore:\n  name: Lore\n  attributes: [Insight]\n")
+	s.writeData("quests.yml", testQuests)
+	s.writeData("enemies.yml", testEnemies)
+	s.writeData("encounters.yml", testEncounters)
+	s.writeData("items.yml", "Tail:\n  name: Rat Tail\nCoin:\n  name: Coin\nCrown:\n  name: Crown\n")
+	s.writeData("loot.yml", testLoot)
+	s.writeData("progression.yml", "curve: basic\ncurves:\n  basic:\n    max: 3\n    thresholds: [100, 200]\ngrowth:\n  points: 5\n")
+
+	w := s.loadWorld()
+	w.rng = rand.New(rand.NewSource(1))
+	for _, id := range []string{"H1", "H2", "H3"} {
+		h := hero.Hero{Id: id, Race: "Dwarf", Level: 1, Attributes: attributes.NewAttributeValues()}
+		h.Attributes.Set(attributes.Brawn, 50.0)
+		w.state.Heroes = append(w.state.Heroes, h)
+	}
+
+	return w
+}
+
+func (s *WorldTestSuite) TestAssignQuest() {
+	w := s.questWorld()
+
+	e, err := w.AssignQuest("Cellar", []string{"H1", "H2"})
+	s.Require().Nil(err)
+	s.Equal("E1", e.Id)
+	s.Equal(w.Tick()+2, e.Due)
+	s.Len(w.Expeditions(), 1)
+	s.True(w.state.Busy("H1"))
+	s.False(w.state.Busy("H3"))
+}
+
+func (s *WorldTestSuite) TestAssignQuest_Rejected() {
+	w := s.questWorld()
+	weak, _ := w.FindHero("H3")
+	weak.Attributes.Set(attributes.Brawn, 10.0)
+	_, err := w.AssignQuest("Cellar", []string{"H1"})
+	s.Require().Nil(err)
+
+	_, err = w.AssignQuest("Dungeon", []string{"H2"})
+	s.NotNil(err)
+
+	_, err = w.AssignQuest("Cellar", []string{"H1", "H2", "H3"})
+	s.Require().NotNil(err)
+	rejected, ok := err.(*AssignmentError)
+	s.Require().True(ok)
+	s.Equal([]string{
+		"needs 1-2 heroes, found 3",
+		"hero is on another expedition: H1",
+		"hero H3 needs Brawn 20, has 12",
+	}, rejected.Reasons)
+
+	_, err = w.AssignQuest("Cellar", []string{"H2", "H2"})
+	s.NotNil(err)
+	_, err = w.AssignQuest("Cellar", []string{"H9"})
+	s.NotNil(err)
+	s.Len(w.Expeditions(), 1)
+}
+
+func (s *WorldTestSuite) TestOnTick_ResolvesQuest() {
+	w := s.questWorld()
+	_, err := w.AssignQuest("Cellar", []string{"H1", "H2"})
+	s.Require().Nil(err)
+
+	w.OnTick(w.Tick() + 1)
+	s.Len(w.Expeditions(), 1)
+	s.Empty(w.Results())
+
+	w.OnTick(w.Tick() + 2)
+	s.Empty(w.Expeditions())
+	s.Require().Len(w.Results(), 1)
+
+	r := w.Results()[0]
+	s.True(r.Success)
+	s.Equal("Cellar", r.Quest)
+	s.Require().Len(r.Checks, 2)
+	s.Equal(attributes.Brawn, r.Checks[0].Result.Skill)
+	s.Equal("Lore", r.Checks[1].Result.Skill)
+	s.Equal(map[string]float64{damage.Toxin: 4 * HazardSuccessFactor}, r.Damage["H1"])
+	s.Equal(2, r.Rewards["H2"].To)
+
+	h, _ := w.FindHero("H2")
+	s.Equal(150, h.Experience)
+	s.Equal(2, h.Level)
+	s.Equal(4*HazardSuccessFactor, h.Wounds)
+	s.False(w.state.Busy("H2"))
+}
+
+func (s *WorldTestSuite) TestOnTick_FailsQuest() {
+	w := s.questWorld()
+	_, err := w.AssignQuest("Impossible", []string{"H3"})
+	s.Require().Nil(err)
+
+	w.OnTick(w.Tick() + 1)
+	s.Require().Len(w.Results(), 1)
+
+	r := w.Results()[0]
+	s.False(r.Success)
+	s.Empty(r.Rewards)
+
+	h, _ := w.FindHero("H3")
+	s.Equal(0, h.Experience)
+	s.Equal(10.0, h.Wounds)
+}
+
+func (s *WorldTestSuite) TestReload_OrphanedExpedition() {
+	w := s.questWorld()
+	_, err := w.AssignQuest("Impossible", []string{"H3"})
+	s.Require().Nil(err)
+	s.writeData("quests.yml", "{}")
+
+	_, err = w.Reload()
+	s.Require().NotNil(err)
+	orphans, ok := err.(*OrphanError)
+	s.Require().True(ok)
+	s.Equal([]string{"expedition E1 uses missing quest: Impossible"}, orphans.Orphans)
+}
+
+func (s *WorldTestSuite) TestOnTick_HazardResistance() {
+	w := s.questWorld()
+	dwarf, _ := w.Manifest().Resolve("races", "Dwarf")
+	dwarf.Resistances[damage.Fire] = 0.5
+	_, err := w.AssignQuest("Impossible", []string{"H3"})
+	s.Require().Nil(err)
+
+	w.OnTick(w.Tick() + 1)
+
+	s.Require().Len(w.Results(), 1)
+	s.Equal(map[string]float64{damage.Fire: 5.0}, w.Results()[0].Damage["H3"])
+	h, _ := w.FindHero("H3")
+	s.Equal(5.0, h.Wounds)
+}
+
+func (s *WorldTestSuite) TestOnTick_Encounter() {
+	w := s.questWorld()
+	_, err := w.CreateParty("Exterminators", "", []state.Member{{Hero: "H1", Role: state.RoleFront}, {Hero: "H2", Role: state.RoleRear}})
+	s.Require().Nil(err)
+	_, err = w.AssignParty("Den", "P1")
+	s.Require().Nil(err)
+
+	w.OnTick(w.Tick() + 1)
+
+	s.Require().Len(w.Results(), 1)
+	r := w.Results()[0]
+	s.True(r.Success)
+	s.Equal("Vermin", r.Encounter)
+	s.Require().NotNil(r.Combat)
+	s.Equal(combat.SideHeroes, r.Combat.Winner)
+	s.Equal(10.0, r.Combat.Damage["Rat#1"]+r.Combat.Damage["Rat#2"])
+
+	for _, e := range r.Combat.Log {
+		if e.Actor == "Rat#1" || e.Actor == "Rat#2" {
+			s.Equal("H1", e.Target)
+		}
+	}
+	front, _ := w.FindHero("H1")
+	s.Equal(r.Combat.Damage["H1"], front.Wounds)
+	s.Equal(10, front.Experience)
+
+	s.Equal(map[string]int{"Tail": 2}, r.Loot)
+	s.Equal(map[string]int{"Tail": 2}, w.Stash())
+}
+
+func (s *WorldTestSuite) TestOnTick_EncounterLost() {
+	w := s.questWorld()
+	_, err := w.AssignQuest("Lair", []string{"H3"})
+	s.Require().Nil(err)
+
+	w.OnTick(w.Tick() + 1)
+
+	s.Require().Len(w.Results(), 1)
+	r := w.Results()[0]
+	s.False(r.Success)
+	s.Equal("Bridge", r.Encounter)
+	s.Equal(combat.SideEnemies, r.Combat.Winner)
+	s.Empty(r.Checks)
+	s.Empty(r.Rewards)
+
+	h, _ := w.FindHero("H3")
+	s.Equal(0, h.Experience)
+	s.Equal(r.Combat.Damage["H3"], h.Wounds)
+	s.True(h.Wounds > 0)
+}
+
+func (s *WorldTestSuite) TestOnTick_RecoversWounds() {
+	s.writeData("progression.yml", "curve: basic\ncurves:\n  basic:\n    max: 1\nrecovery:\n  rate: 3\n")
+	w := s.loadWorld()
+	w.state.Heroes = append(w.state.Heroes, hero.Hero{Id: "H1", Wounds: 5.0}, hero.Hero{Id: "H2", Wounds: 5.0})
+	w.state.Expeditions = append(w.state.Expeditions, state.Expedition{Id: "E1", Quest: "Cellar", Heroes: []string{"H2"}, Due: 100})
+
+	w.OnTick(1)
+	s.Equal(2.0, w.state.Heroes[0].Wounds)
+	s.Equal(5.0, w.state.Heroes[1].Wounds)
+
+	w.OnTick(2)
+	s.Equal(0.0, w.state.Heroes[0].Wounds)
+}
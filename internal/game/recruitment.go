@@ -0,0 +1,187 @@
+//------------------------------------------------------------------------------
+//    Copyright 2019 Jeff Sharpe (zeropointx.io)
+//
+//    Licensed under the Apache License, Version 2.0 (the "License");
+//    you may not use this file except in compliance with the License.
+//    You may obtain a copy of the License at
+//
+//        http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS,
+//    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//    See the License for the specific language governing permissions and
+//    limitations under the License.
+//------------------------------------------------------------------------------
+
+package game
+
+import (
+	"fmt"
+	"github.com/zpxio/heromanager/internal/game/data/content"
+	"github.com/zpxio/heromanager/internal/game/data/recruitment"
+	"github.com/zpxio/heromanager/internal/game/data/rolling"
+	"github.com/zpxio/heromanager/internal/game/state"
+	"github.com/zpxio/heromanager/internal/game/state/hero"
+	"log"
+)
+
+func (world *World) Recruitment() *recruitment.Config {
+	return world.content().Recruitment
+}
+
+// Candidates lists the heroes available for hire, oldest first.
+func (world *World) Candidates() []state.Candidate {
+	world.stateLock.Lock()
+	defer world.stateLock.Unlock()
+
+	return append([]state.Candidate{}, world.state.Candidates...)
+}
+
+// NextRefresh returns the tick the recruitment pool next refreshes, or zero
+// without recruitment.
+func (world *World) NextRefresh() uint64 {
+	world.stateLock.Lock()
+	defer world.stateLock.Unlock()
+
+	config := world.Recruitment()
+	if config.Empty() {
+		return 0
+	}
+	if world.state.Refreshed == 0 {
+		return world.Tick()
+	}
+
+	return world.state.Refreshed + config.Interval
+}
+
+// Tavern is a TickListener sending expired candidates away and refreshing
+// the recruitment pool when it is due.
+type Tavern struct {
+	world *World
+}
+
+func (t *Tavern) OnTick(id uint64) {
+	t.world.stateLock.Lock()
+	defer t.world.stateLock.Unlock()
+
+	t.world.refreshCandidates(id)
+}
+
+// refreshCandidates removes candidates expiring by a tick, then generates
+// new candidates until the pool is full when a refresh is due. It must be
+// called with the state lock held.
+func (world *World) refreshCandidates(tick uint64) {
+	data := world.content()
+	config := data.Recruitment
+
+	remaining := make([]state.Candidate, 0, len(world.state.Candidates))
+	for _, c := range world.state.Candidates {
+		if c.Expires > tick {
+			remaining = append(remaining, c)
+		}
+	}
+	world.state.Candidates = remaining
+
+	if config.Empty() || !config.Due(world.state.Refreshed, tick) {
+		return
+	}
+	world.state.Refreshed = tick
+
+	selector, roller, err := recruiter(config, data)
+	if err != nil {
+		log.Printf("ERROR: Cannot generate candidates: %s", err)
+		return
+	}
+
+	for len(world.state.Candidates) < config.Size {
+		h := hero.Generate(data.Classifiers, selector, roller, world.rng)
+		h.Id = world.nextHeroId()
+		h.GrantSkills(data.Skills, data.Classifiers)
+
+		world.state.Candidates = append(world.state.Candidates, state.Candidate{
+			Hero:    *h,
+			Fee:     config.Fee.For(h.Wage(data.Economy.Wage, data.Classifiers)),
+			Arrived: tick,
+			Expires: tick + config.Expiry,
+		})
+	}
+	log.Printf("Recruitment pool refreshed: %d candidates", len(world.state.Candidates))
+}
+
+// recruiter builds the selector and rolling strategy candidates are
+// generated with, failing when no classifier combination is valid.
+func recruiter(config *recruitment.Config, data *content.Set) (*hero.Selector, rolling.Strategy, error) {
+	selector := hero.NewSelector(data.Classifiers)
+	for target, ids := range config.Options {
+		for _, id := range ids {
+			selector.AddOption(target, id)
+		}
+	}
+	for target, factors := range config.Weights {
+		for id, factor := range factors {
+			selector.ScaleWeight(target, id, factor)
+		}
+	}
+	selector.UseUniform(config.Uniform)
+
+	if len(selector.ValidCombinations()) == 0 {
+		return nil, nil, selector.Explain()
+	}
+
+	var roller rolling.Strategy
+	var err error
+	if config.Strategy != "" {
+		roller, err = data.Rolling.Strategy(config.Strategy)
+	} else {
+		roller, err = data.Rolling.DefaultStrategy()
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return selector, roller, nil
+}
+
+// nextHeroId allocates an ID no hero or candidate uses. It must be called
+// with the state lock held.
+func (world *World) nextHeroId() string {
+	for {
+		world.state.NextHero++
+		id := fmt.Sprintf("H%d", world.state.NextHero)
+
+		_, isHero := world.FindHero(id)
+		_, isCandidate := world.state.FindCandidate(id)
+		if !isHero && !isCandidate {
+			return id
+		}
+	}
+}
+
+// Hire pays a candidate's fee out of the treasury and adds the candidate to
+// the guild's heroes.
+func (world *World) Hire(id string) (*hero.Hero, error) {
+	world.stateLock.Lock()
+	defer world.stateLock.Unlock()
+
+	i, found := world.state.FindCandidate(id)
+	if !found {
+		return nil, fmt.Errorf("unknown candidate: %s", id)
+	}
+
+	c := world.state.Candidates[i]
+	if c.Fee > world.state.Treasury {
+		return nil, fmt.Errorf("cannot afford candidate %s: costs %d, treasury holds %d", id, c.Fee, world.state.Treasury)
+	}
+
+	if c.Fee > 0 {
+		world.state.Record(state.Transaction{Tick: world.Tick(), Kind: state.TransactionHire, Amount: -c.Fee, Hero: id, Memo: "hired " + id})
+	}
+	world.state.Candidates = append(world.state.Candidates[:i], world.state.Candidates[i+1:]...)
+	world.state.Heroes = append(world.state.Heroes, c.Hero)
+	log.Printf("Hired hero %s for %d", id, c.Fee)
+
+	h, _ := world.FindHero(id)
+
+	return h, nil
+}
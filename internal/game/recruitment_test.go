@@ -0,0 +1,146 @@
+//------------------------------------------------------------------------------
+//    Copyright 2019 Jeff Sharpe (zeropointx.io)
+//
+//    Licensed under the Apache License, Version 2.0 (the "License");
+//    you may not use this file except in compliance with the License.
+//    You may obtain a copy of the License at
+//
+//        http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS,
+//    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//    See the License for the specific language governing permissions and
+//    limitations under the License.
+//------------------------------------------------------------------------------
+
+package game
+
+import (
+	"github.com/zpxio/heromanager/internal/game/state"
+	"github.com/zpxio/heromanager/internal/game/state/hero"
+)
+
+const testRecruitment = `
+interval: 10
+size: 3
+expiry: 15
+strategy: dice
+options:
+  races: [Dwarf]
+weights:
+  professions: {Scout: 0}
+fee: {base: 20, wages: 2}
+`
+
+func (s *WorldTestSuite) recruitWorld() *World {
+	s.writeData("professions.yml", "Smith:\n  name: Smith\n  wage: 5\nScout:\n  name: Scout\n")
+	s.writeData("economy.yml", "currency: crowns\nstarting: 100\ninterval: 5\nwage: 2\n")
+	s.writeData("recruitment.yml", testRecruitment)
+
+	return s.loadWorld()
+}
+
+func (s *WorldTestSuite) TestRefreshCandidates() {
+	w := s.recruitWorld()
+	w.state.Heroes = append(w.state.Heroes, hero.Hero{Id: "H2", Race: "Elf"})
+
+	(&Tavern{world: w}).OnTick(1)
+
+	candidates := w.Candidates()
+	s.Require().Len(candidates, 3)
+	for i, id := range []string{"H1", "H3", "H4"} {
+		c := candidates[i]
+		s.Equal(id, c.Hero.Id)
+		s.Equal("Dwarf", c.Hero.Race)
+		s.Equal("Smith", c.Hero.Profession)
+		s.NotNil(c.Hero.Skills.Policy())
+		s.Equal(uint64(1), c.Arrived)
+		s.Equal(uint64(16), c.Expires)
+		s.Equal(20+2*c.Hero.Wage(2, w.Manifest()), c.Fee)
+	}
+	s.Equal(uint64(11), w.NextRefresh())
+}
+
+func (s *WorldTestSuite) TestRefreshCandidates_Expiry() {
+	w := s.recruitWorld()
+	tavern := &Tavern{world: w}
+	(&Payroll{world: w}).OnTick(1)
+	tavern.OnTick(1)
+
+	_, err := w.Hire("H2")
+	s.Require().Nil(err)
+	tavern.OnTick(5)
+	s.Len(w.Candidates(), 2)
+
+	tavern.OnTick(11)
+	s.Len(w.Candidates(), 3)
+	s.Equal("H4", w.Candidates()[2].Hero.Id)
+
+	tavern.OnTick(16)
+	s.Require().Len(w.Candidates(), 1)
+	s.Equal("H4", w.Candidates()[0].Hero.Id)
+
+	tavern.OnTick(21)
+	s.Len(w.Candidates(), 3)
+}
+
+func (s *WorldTestSuite) TestRefreshCandidates_NoRecruitment() {
+	w := s.loadWorld()
+
+	(&Tavern{world: w}).OnTick(1)
+
+	s.Empty(w.Candidates())
+	s.Equal(uint64(0), w.NextRefresh())
+}
+
+func (s *WorldTestSuite) TestHire() {
+	w := s.recruitWorld()
+	(&Payroll{world: w}).OnTick(1)
+	(&Tavern{world: w}).OnTick(1)
+	fee := w.Candidates()[1].Fee
+
+	h, err := w.Hire("H2")
+	s.Require().Nil(err)
+	s.Equal("H2", h.Id)
+	s.Equal("Dwarf", h.Race)
+
+	found, ok := w.FindHero("H2")
+	s.True(ok)
+	s.Equal(h, found)
+	s.Len(w.Candidates(), 2)
+	s.Equal(100-fee, w.Treasury())
+	s.Equal([]state.Transaction{{Tick: w.Tick(), Kind: state.TransactionHire, Amount: -fee, Balance: 100 - fee, Hero: "H2", Memo: "hired H2"}},
+		w.Ledger(state.LedgerFilter{Kind: state.TransactionHire}))
+
+	_, err = w.Hire("H2")
+	s.NotNil(err)
+	_, err = w.Hire("H9")
+	s.NotNil(err)
+}
+
+func (s *WorldTestSuite) TestHire_Unaffordable() {
+	w := s.recruitWorld()
+	(&Tavern{world: w}).OnTick(1)
+
+	_, err := w.Hire("H1")
+	s.NotNil(err)
+	s.Len(w.Candidates(), 3)
+	s.Empty(w.state.Heroes)
+}
+
+func (s *WorldTestSuite) TestReload_OrphanedCandidates() {
+	w := s.recruitWorld()
+	(&Payroll{world: w}).OnTick(1)
+	(&Tavern{world: w}).OnTick(1)
+	s.Require().Len(w.Candidates(), 3)
+
+	s.writeData("professions.yml", "Miner:\n  name: Miner\nScout:\n  name: Scout\n")
+	_, err := w.Reload()
+	s.Require().Nil(err)
+
+	s.Empty(w.Candidates())
+	_, err = w.Hire("H1")
+	s.NotNil(err)
+	s.Empty(w.state.Heroes)
+}
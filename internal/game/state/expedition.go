@@ -0,0 +1,73 @@
+//------------------------------------------------------------------------------
+//    Copyright 2019 Jeff Sharpe (zeropointx.io)
+//
+//    Licensed under the Apache License, Version 2.0 (the "License");
+//    you may not use this file except in compliance with the License.
+//    You may obtain a copy of the License at
+//
+//        http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS,
+//    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//    See the License for the specific language governing permissions and
+//    limitations under the License.
+//------------------------------------------------------------------------------
+
+package state
+
+import (
+	"github.com/zpxio/heromanager/internal/game/combat"
+	"github.com/zpxio/heromanager/internal/game/data/skills"
+	"github.com/zpxio/heromanager/internal/game/state/hero"
+)
+
+// Expedition is a party of heroes away on a quest.
+type Expedition struct {
+	Id      string   `json:"id"`
+	Quest   string   `json:"quest"`
+	Heroes  []string `json:"heroes"`
+	Started uint64   `json:"started"`
+	Due     uint64   `json:"due"`
+}
+
+// CheckOutcome is a quest check rolled by one party member.
+type CheckOutcome struct {
+	Hero   string        `json:"hero"`
+	Result skills.Result `json:"result"`
+}
+
+// Result records how an expedition resolved.
+type Result struct {
+	Expedition
+	Resolved uint64         `json:"resolved"`
+	Success  bool           `json:"success"`
+	Checks   []CheckOutcome `json:"checks"`
+
+	// Encounter is the table enemies were rolled from, and Combat the fight
+	// against them, when the party met any.
+	Encounter string         `json:"encounter,omitempty"`
+	Combat    *combat.Result `json:"combat,omitempty"`
+
+	// Damage taken by each hero, keyed by hero and then damage type.
+	Damage  map[string]map[string]float64 `json:"damage,omitempty"`
+	Rewards map[string]hero.Advancement   `json:"rewards,omitempty"`
+
+	// Gold paid into the treasury, and loot dropped into the guild stash,
+	// keyed by item.
+	Gold int            `json:"gold,omitempty"`
+	Loot map[string]int `json:"loot,omitempty"`
+}
+
+// Busy reports whether a hero is away on an expedition.
+func (s *State) Busy(heroId string) bool {
+	for _, e := range s.Expeditions {
+		for _, id := range e.Heroes {
+			if id == heroId {
+				return true
+			}
+		}
+	}
+
+	return false
+}
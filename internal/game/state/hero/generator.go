@@ -16,18 +16,34 @@
 
 package hero
 
-import "github.com/zpxio/heromanager/internal/game/data/classifier"
+import (
+	log "github.com/sirupsen/logrus"
+	"github.com/zpxio/heromanager/internal/game/data/classifier"
+	"github.com/zpxio/heromanager/internal/game/data/rolling"
+	"math/rand"
+)
 
 type Generator struct {
 	classifierManifest classifier.ClassifierManifest
 }
 
-func Generate(classifierManifest *classifier.ClassifierManifest, selector *Selector) *Hero {
+func Generate(classifierManifest *classifier.ClassifierManifest, selector *Selector, roller rolling.Strategy, rng *rand.Rand) *Hero {
 
 	hero := baseHero()
 
-	// Find the set of selectable races
-	//raceOptions := selector.GetSelectableRaces(classifierManifest)
+	// Pick a valid classifier combination
+	combination, err := selector.PickCombination(rng.Float32())
+	if err != nil {
+		log.Warnf("generating hero without classifiers: %s", err)
+	} else {
+		hero.Race = combination.Race
+		hero.Caste = combination.Caste
+		hero.Profession = combination.Profession
+		hero.Extra = combination.Extra
+	}
+
+	// Roll the starting attributes
+	hero.Attributes = roller.Roll(rng, hero.Race)
 
 	return hero
 }
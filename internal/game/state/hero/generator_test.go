@@ -18,7 +18,10 @@ package hero
 
 import (
 	"github.com/stretchr/testify/suite"
+	"github.com/zpxio/heromanager/internal/game/data/attributes"
 	"github.com/zpxio/heromanager/internal/game/data/classifier"
+	"github.com/zpxio/heromanager/internal/game/data/rolling"
+	"math/rand"
 	"testing"
 )
 
@@ -37,7 +40,32 @@ func TestGenerateSuite(t *testing.T) {
 
 func (s *GenerateTestSuite) TestGenerate_Basic() {
 	x := NewSelector(s.manifest)
-	h := Generate(s.manifest, x)
+	h := Generate(s.manifest, x, rolling.Normal{Mean: 50}, rand.New(rand.NewSource(1)))
 
 	s.Require().NotNil(h)
 }
+
+func (s *GenerateTestSuite) TestGenerate_RolledAttributes() {
+	x := NewSelector(s.manifest)
+	h := Generate(s.manifest, x, rolling.PointBuy{Budget: 100, Minimum: 10, Step: 5}, rand.New(rand.NewSource(1)))
+
+	sum := 0.0
+	for _, k := range attributes.Policy().ValidKeys() {
+		s.True(h.Attributes.Get(k) >= 10.0)
+		sum += h.Attributes.Get(k)
+	}
+	s.InDelta(150.0, sum, 0.0001)
+}
+
+func (s *GenerateTestSuite) TestGenerate_Classifiers() {
+	m := classifier.NewManifest()
+	m.RegisterRace("Dwarf", classifier.BlankRace())
+	m.RegisterCaste("Noble", classifier.BlankCaste())
+	m.RegisterProfession("Smith", classifier.BlankProfession())
+
+	h := Generate(m, NewSelector(m), rolling.Normal{Mean: 50}, rand.New(rand.NewSource(1)))
+
+	s.Equal("Dwarf", h.Race)
+	s.Equal("Noble", h.Caste)
+	s.Equal("Smith", h.Profession)
+}
@@ -17,16 +17,179 @@
 package hero
 
 import (
+	"github.com/zpxio/heromanager/internal/game/data/attributes"
+	"github.com/zpxio/heromanager/internal/game/data/classifier"
+	"github.com/zpxio/heromanager/internal/game/data/damage"
+	"github.com/zpxio/heromanager/internal/game/data/item"
 	"github.com/zpxio/heromanager/internal/game/data/table"
 )
 
+const (
+	SourceRace       = "race"
+	SourceCaste      = "caste"
+	SourceProfession = "profession"
+	SourceEffect     = "effect"
+	SourceItem       = "item"
+)
+
 type Hero struct {
-	name       string
-	Attributes table.Values
+	Id         string       `json:"id"`
+	Name       string       `json:"name"`
+	Race       string       `json:"race"`
+	Caste      string       `json:"caste"`
+	Profession string       `json:"profession"`
+	Attributes table.Values `json:"attributes"`
+	Skills     table.Values `json:"skills"`
+
+	// Level starts at one, and rises as Experience crosses the thresholds
+	// of the level curve.
+	Level      int `json:"level"`
+	Experience int `json:"xp"`
+
+	// Wounds is the damage the hero has taken and not yet recovered from.
+	// Heroes recover at the progression recovery rate while resting.
+	Wounds float64 `json:"wounds,omitempty"`
+
+	// Inventory counts the items carried, keyed by item ID, and Equipment
+	// holds the item equipped in each slot.
+	Inventory map[string]int    `json:"inventory,omitempty"`
+	Equipment map[string]string `json:"equipment,omitempty"`
+
+	// Extra holds the classifiers chosen for declared dimensions beyond
+	// race, caste and profession, keyed by dimension target.
+	Extra map[string]string `json:"extra,omitempty"`
 }
 
 func baseHero() *Hero {
-	h := Hero{}
+	h := Hero{Attributes: attributes.NewAttributeValues(), Level: 1}
 
 	return &h
 }
+
+// Copy returns a deep copy of the hero, sharing no tables or maps with the
+// original.
+func (h *Hero) Copy() Hero {
+	c := *h
+	c.Attributes = copyValues(h.Attributes)
+	c.Skills = copyValues(h.Skills)
+	c.Equipment = copyStrings(h.Equipment)
+	c.Extra = copyStrings(h.Extra)
+
+	if h.Inventory != nil {
+		c.Inventory = make(map[string]int, len(h.Inventory))
+		for id, count := range h.Inventory {
+			c.Inventory[id] = count
+		}
+	}
+
+	return c
+}
+
+func copyValues(t table.Values) table.Values {
+	if t.Policy() == nil {
+		return table.Values{}
+	}
+
+	return t.Copy()
+}
+
+func copyStrings(m map[string]string) map[string]string {
+	if m == nil {
+		return nil
+	}
+
+	c := make(map[string]string, len(m))
+	for k, v := range m {
+		c[k] = v
+	}
+
+	return c
+}
+
+func Source(kind string, id string) string {
+	return kind + ":" + id
+}
+
+// Classifier returns the ID of the hero's classifier in a dimension.
+func (h *Hero) Classifier(target string) string {
+	switch target {
+	case classifier.ConflictRaces:
+		return h.Race
+	case classifier.ConflictCastes:
+		return h.Caste
+	case classifier.ConflictProfessions:
+		return h.Profession
+	}
+
+	return h.Extra[target]
+}
+
+// Modifiers collects the attribute modifiers contributed by the hero's
+// classifiers, in the order they are applied.
+func (h *Hero) Modifiers(manifest *classifier.ClassifierManifest) []table.SourcedModifier {
+	dimensions := manifest.Dimensions()
+	mods := make([]table.SourcedModifier, 0, len(dimensions))
+
+	for _, d := range dimensions {
+		id := h.Classifier(d.Target)
+		if c, found := manifest.Resolve(d.Target, id); found {
+			mods = append(mods, table.NewSourcedModifier(Source(d.Kind, id), c.Attributes))
+		}
+	}
+
+	return mods
+}
+
+// Breakdown explains how each effective attribute was derived from the hero's
+// base attributes. Additional modifiers, such as active effects, are applied
+// after the classifier modifiers.
+func (h *Hero) Breakdown(manifest *classifier.ClassifierManifest, extra ...table.SourcedModifier) (table.Values, table.Breakdown) {
+	mods := append(h.Modifiers(manifest), extra...)
+
+	return h.Attributes.Trace(mods...)
+}
+
+func (h *Hero) EffectiveAttributes(manifest *classifier.ClassifierManifest, extra ...table.SourcedModifier) table.Values {
+	values, _ := h.Breakdown(manifest, extra...)
+
+	return values
+}
+
+// Resistances sums the damage resistances granted by the hero's classifiers
+// and equipped items.
+func (h *Hero) Resistances(manifest *classifier.ClassifierManifest, items *item.Definitions) table.Values {
+	resistances := damage.NewResistances()
+	add := func(granted map[string]float64) {
+		for t, value := range granted {
+			resistances.Set(t, resistances.Get(t)+value)
+		}
+	}
+
+	for _, d := range manifest.Dimensions() {
+		if c, found := manifest.Resolve(d.Target, h.Classifier(d.Target)); found {
+			add(c.Resistances)
+		}
+	}
+	for _, equipped := range h.Equipped(items) {
+		add(equipped.Resistances)
+	}
+
+	return resistances
+}
+
+// Wage sums the base wage and the wages of the hero's classifiers. Heroes are
+// never paid less than nothing.
+func (h *Hero) Wage(base int, manifest *classifier.ClassifierManifest) int {
+	wage := base
+	for _, d := range manifest.Dimensions() {
+		if c, found := manifest.Resolve(d.Target, h.Classifier(d.Target)); found {
+			wage += c.Wage
+		}
+	}
+
+	if wage < 0 {
+		return 0
+	}
+
+	return wage
+}
@@ -18,6 +18,10 @@ package hero
 
 import (
 	"github.com/stretchr/testify/suite"
+	"github.com/zpxio/heromanager/internal/game/data/attributes"
+	"github.com/zpxio/heromanager/internal/game/data/classifier"
+	"github.com/zpxio/heromanager/internal/game/data/damage"
+	"github.com/zpxio/heromanager/internal/game/data/table"
 	"testing"
 )
 
@@ -34,3 +38,125 @@ func (s *HeroTestSuite) TestBaseHero() {
 
 	s.NotNil(h)
 }
+
+func (s *HeroTestSuite) TestCopy() {
+	h := baseHero()
+	h.Attributes.Set(attributes.Brawn, 10)
+	h.Inventory = map[string]int{"Torch": 2}
+	h.Equipment = map[string]string{"hand": "Sword"}
+
+	c := h.Copy()
+	c.Attributes.Set(attributes.Brawn, 12)
+	c.Inventory["Torch"] = 5
+	c.Equipment["hand"] = "Axe"
+
+	s.Equal(10.0, h.Attributes.Get(attributes.Brawn))
+	s.Equal(2, h.Inventory["Torch"])
+	s.Equal("Sword", h.Equipment["hand"])
+	s.Nil(c.Extra)
+	s.Nil(c.Skills.Policy())
+}
+
+func breakdownManifest() *classifier.ClassifierManifest {
+	m := classifier.NewManifest()
+
+	r := classifier.BlankRace()
+	r.Attributes.Load(map[string]float64{attributes.Brawn: 0.5})
+	m.RegisterRace("DWRF", r)
+
+	c := classifier.BlankCaste()
+	c.Attributes.Load(map[string]float64{attributes.Brawn: -0.5, attributes.Insight: 0.1})
+	m.RegisterCaste("Peasant", c)
+
+	return m
+}
+
+func (s *HeroTestSuite) TestModifiers() {
+	h := baseHero()
+	h.Race = "DWRF"
+	h.Caste = "Peasant"
+	h.Profession = "Missing"
+
+	mods := h.Modifiers(breakdownManifest())
+
+	s.Require().Len(mods, 2)
+	s.Equal("race:DWRF", mods[0].Source)
+	s.Equal("caste:Peasant", mods[1].Source)
+}
+
+func (s *HeroTestSuite) TestModifiers_Dimension() {
+	m := breakdownManifest()
+	s.Require().Nil(m.Declare(classifier.Dimension{Target: "homelands", Kind: "homeland"}))
+	coast := classifier.Initialize()
+	coast.Attributes.Load(map[string]float64{attributes.Allure: 0.2})
+	m.Register("homelands", "Coast", coast)
+
+	h := baseHero()
+	h.Race = "DWRF"
+	h.Extra = map[string]string{"homelands": "Coast"}
+
+	mods := h.Modifiers(m)
+
+	s.Require().Len(mods, 2)
+	s.Equal("race:DWRF", mods[0].Source)
+	s.Equal("homeland:Coast", mods[1].Source)
+	s.Equal("Coast", h.Classifier("homelands"))
+}
+
+func (s *HeroTestSuite) TestBreakdown() {
+	h := baseHero()
+	h.Race = "DWRF"
+	h.Caste = "Peasant"
+	h.Attributes.Set(attributes.Brawn, 20.0)
+
+	effect := attributes.NewAttributeModifier()
+	effect.Load(map[string]float64{attributes.Brawn: 1.0})
+
+	values, breakdown := h.Breakdown(breakdownManifest(), table.NewSourcedModifier(Source(SourceEffect, "Rage"), effect))
+
+	brawn := breakdown[attributes.Brawn]
+	s.Equal(20.0, brawn.Base)
+	s.Require().Len(brawn.Contributions, 3)
+	s.InDelta(30.0, brawn.Contributions[0].Value, 0.0001)
+	s.InDelta(15.0, brawn.Contributions[1].Value, 0.0001)
+	s.InDelta(30.0, brawn.Contributions[2].Value, 0.0001)
+	s.Equal("effect:Rage", brawn.Contributions[2].Source)
+	s.InDelta(30.0, values.Get(attributes.Brawn), 0.0001)
+	s.Equal(values.Get(attributes.Brawn), brawn.Final)
+}
+
+func (s *HeroTestSuite) TestResistances() {
+	m := breakdownManifest()
+	r, _ := m.Resolve(classifier.ConflictRaces, "DWRF")
+	r.Resistances[damage.Fire] = 0.25
+	c, _ := m.Resolve(classifier.ConflictCastes, "Peasant")
+	c.Resistances[damage.Fire] = 0.5
+	c.Resistances[damage.Toxin] = -0.5
+
+	h := baseHero()
+	h.Race = "DWRF"
+	h.Caste = "Peasant"
+
+	resistances := h.Resistances(m, nil)
+	s.Equal(0.75, resistances.Get(damage.Fire))
+	s.Equal(-0.5, resistances.Get(damage.Toxin))
+	s.Equal(0.0, resistances.Get(damage.Cold))
+}
+
+func (s *HeroTestSuite) TestWage() {
+	m := breakdownManifest()
+	p := classifier.BlankProfession()
+	p.Wage = 15
+	m.RegisterProfession("Smith", p)
+	c := classifier.BlankCaste()
+	c.Wage = -30
+	m.RegisterCaste("Serf", c)
+
+	h := baseHero()
+	h.Caste = "Peasant"
+	h.Profession = "Smith"
+	s.Equal(25, h.Wage(10, m))
+
+	h.Caste = "Serf"
+	s.Equal(0, h.Wage(10, m))
+}
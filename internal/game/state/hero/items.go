@@ -0,0 +1,163 @@
+//------------------------------------------------------------------------------
+//    Copyright 2019 Jeff Sharpe (zeropointx.io)
+//
+//    Licensed under the Apache License, Version 2.0 (the "License");
+//    you may not use this file except in compliance with the License.
+//    You may obtain a copy of the License at
+//
+//        http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS,
+//    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//    See the License for the specific language governing permissions and
+//    limitations under the License.
+//------------------------------------------------------------------------------
+
+package hero
+
+import (
+	"fmt"
+	"github.com/zpxio/heromanager/internal/game/data/classifier"
+	"github.com/zpxio/heromanager/internal/game/data/item"
+	"github.com/zpxio/heromanager/internal/game/data/table"
+)
+
+// Give adds items to the inventory.
+func (h *Hero) Give(id string, count int) {
+	if h.Inventory == nil {
+		h.Inventory = make(map[string]int)
+	}
+	h.Inventory[id] += count
+}
+
+// Take removes items from the inventory, failing when too few are carried.
+func (h *Hero) Take(id string, count int) bool {
+	if h.Inventory[id] < count {
+		return false
+	}
+
+	h.Inventory[id] -= count
+	if h.Inventory[id] == 0 {
+		delete(h.Inventory, id)
+	}
+
+	return true
+}
+
+// Equipped returns the items equipped, in slot order. Items missing from the
+// definitions are skipped.
+func (h *Hero) Equipped(items *item.Definitions) []*item.Item {
+	equipped := make([]*item.Item, 0, len(h.Equipment))
+	if items == nil {
+		return equipped
+	}
+
+	for _, slot := range item.Slots() {
+		if i, found := items.Resolve(h.Equipment[slot]); found {
+			equipped = append(equipped, i)
+		}
+	}
+
+	return equipped
+}
+
+// EquipmentModifiers collects the attribute modifiers of the equipped items,
+// to be stacked after the modifiers of the hero's classifiers.
+func (h *Hero) EquipmentModifiers(items *item.Definitions) []table.SourcedModifier {
+	mods := make([]table.SourcedModifier, 0, len(h.Equipment))
+	if items == nil {
+		return mods
+	}
+
+	for _, slot := range item.Slots() {
+		id := h.Equipment[slot]
+		if i, found := items.Resolve(id); found {
+			mods = append(mods, table.NewSourcedModifier(Source(SourceItem, id), i.Attributes))
+		}
+	}
+
+	return mods
+}
+
+// Load is the total weight of the items carried and equipped.
+func (h *Hero) Load(items *item.Definitions) float64 {
+	load := 0.0
+	for id, count := range h.Inventory {
+		if i, found := items.Resolve(id); found {
+			load += i.Weight * float64(count)
+		}
+	}
+	for _, i := range h.Equipped(items) {
+		load += i.Weight
+	}
+
+	return load
+}
+
+// CanEquip checks that an item has a slot and that the hero's classifiers
+// meet its requirements.
+func (h *Hero) CanEquip(i *item.Item, manifest *classifier.ClassifierManifest) error {
+	if !i.Equippable() {
+		return fmt.Errorf("%s cannot be equipped", i.Name)
+	}
+
+	selected := make(map[string]string)
+	tags := make(map[string]bool)
+	for _, d := range manifest.Dimensions() {
+		id := h.Classifier(d.Target)
+		if c, found := manifest.Resolve(d.Target, id); found {
+			selected[d.Target] = id
+			for _, tag := range c.Tags {
+				tags[tag] = true
+			}
+		}
+	}
+
+	if !i.Requires.Satisfied(selected, tags) {
+		return fmt.Errorf("%s requires %s", i.Name, i.Requires.Describe())
+	}
+
+	return nil
+}
+
+// Equip moves an item from the inventory into its slot, returning the ID of
+// any item it replaced, which goes back to the inventory.
+func (h *Hero) Equip(id string, items *item.Definitions, manifest *classifier.ClassifierManifest) (string, error) {
+	i, found := items.Resolve(id)
+	if !found {
+		return "", fmt.Errorf("unknown item: %s", id)
+	}
+	if h.Inventory[id] < 1 {
+		return "", fmt.Errorf("hero %s does not carry %s", h.Id, id)
+	}
+	err := h.CanEquip(i, manifest)
+	if err != nil {
+		return "", err
+	}
+
+	if h.Equipment == nil {
+		h.Equipment = make(map[string]string)
+	}
+	previous := h.Equipment[i.Slot]
+	h.Take(id, 1)
+	h.Equipment[i.Slot] = id
+	if previous != "" {
+		h.Give(previous, 1)
+	}
+
+	return previous, nil
+}
+
+// Unequip moves the item in a slot back to the inventory, returning its ID.
+func (h *Hero) Unequip(slot string) (string, error) {
+	id, found := h.Equipment[slot]
+	if !found {
+		return "", fmt.Errorf("hero %s has nothing equipped in slot: %s", h.Id, slot)
+	}
+
+	delete(h.Equipment, slot)
+	h.Give(id, 1)
+
+	return id, nil
+}
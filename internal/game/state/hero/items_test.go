@@ -0,0 +1,119 @@
+//------------------------------------------------------------------------------
+//    Copyright 2019 Jeff Sharpe (zeropointx.io)
+//
+//    Licensed under the Apache License, Version 2.0 (the "License");
+//    you may not use this file except in compliance with the License.
+//    You may obtain a copy of the License at
+//
+//        http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS,
+//    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//    See the License for the specific language governing permissions and
+//    limitations under the License.
+//------------------------------------------------------------------------------
+
+package hero
+
+import (
+	"github.com/zpxio/heromanager/internal/game/data/attributes"
+	"github.com/zpxio/heromanager/internal/game/data/classifier"
+	"github.com/zpxio/heromanager/internal/game/data/damage"
+	"github.com/zpxio/heromanager/internal/game/data/item"
+)
+
+func testItems() *item.Definitions {
+	items := item.NewDefinitions()
+
+	helm := item.Initialize()
+	helm.Name = "Helm"
+	helm.Slot = item.SlotHead
+	helm.Weight = 3
+	helm.Attributes.Load(map[string]float64{attributes.Brawn: 0.5})
+	helm.Resistances[damage.Physical] = 0.1
+	items.Register("Helm", helm)
+
+	crown := item.Initialize()
+	crown.Name = "Crown"
+	crown.Slot = item.SlotHead
+	crown.Weight = 1
+	crown.Requires.AllOf.Add(classifier.ConflictCastes, "Noble")
+	items.Register("Crown", crown)
+
+	bread := item.Initialize()
+	bread.Name = "Bread"
+	bread.Weight = 0.5
+	items.Register("Bread", bread)
+
+	return items
+}
+
+func (s *HeroTestSuite) TestGiveTake() {
+	h := baseHero()
+	h.Give("Bread", 2)
+
+	s.True(h.Take("Bread", 1))
+	s.False(h.Take("Bread", 2))
+	s.True(h.Take("Bread", 1))
+	s.Empty(h.Inventory)
+}
+
+func (s *HeroTestSuite) TestEquip() {
+	items := testItems()
+	h := baseHero()
+	h.Race = "DWRF"
+	h.Caste = "Peasant"
+	h.Attributes.Set(attributes.Brawn, 20.0)
+	h.Give("Helm", 1)
+	h.Give("Bread", 2)
+
+	previous, err := h.Equip("Helm", items, breakdownManifest())
+	s.Require().Nil(err)
+	s.Equal("", previous)
+	s.Equal("Helm", h.Equipment[item.SlotHead])
+	s.Equal(map[string]int{"Bread": 2}, h.Inventory)
+	s.Equal(4.0, h.Load(items))
+
+	mods := h.EquipmentModifiers(items)
+	s.Require().Len(mods, 1)
+	s.Equal("item:Helm", mods[0].Source)
+	values := h.EffectiveAttributes(breakdownManifest(), mods...)
+	s.InDelta(22.5, values.Get(attributes.Brawn), 0.0001)
+	resistances := h.Resistances(breakdownManifest(), items)
+	s.Equal(0.1, resistances.Get(damage.Physical))
+
+	_, err = h.Equip("Bread", items, breakdownManifest())
+	s.NotNil(err)
+	_, err = h.Equip("Helm", items, breakdownManifest())
+	s.NotNil(err)
+
+	removed, err := h.Unequip(item.SlotHead)
+	s.Require().Nil(err)
+	s.Equal("Helm", removed)
+	s.Equal(1, h.Inventory["Helm"])
+	_, err = h.Unequip(item.SlotHead)
+	s.NotNil(err)
+}
+
+func (s *HeroTestSuite) TestEquip_Requirements() {
+	items := testItems()
+	m := breakdownManifest()
+	m.RegisterCaste("Noble", classifier.BlankCaste())
+
+	h := baseHero()
+	h.Caste = "Peasant"
+	h.Give("Crown", 1)
+	_, err := h.Equip("Crown", items, m)
+	s.Require().NotNil(err)
+	s.Contains(err.Error(), "Crown requires")
+
+	h.Caste = "Noble"
+	h.Give("Helm", 1)
+	_, err = h.Equip("Helm", items, m)
+	s.Require().Nil(err)
+	previous, err := h.Equip("Crown", items, m)
+	s.Require().Nil(err)
+	s.Equal("Helm", previous)
+	s.Equal(map[string]int{"Helm": 1}, h.Inventory)
+}
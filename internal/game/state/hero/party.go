@@ -0,0 +1,49 @@
+//------------------------------------------------------------------------------
+//    Copyright 2019 Jeff Sharpe (zeropointx.io)
+//
+//    Licensed under the Apache License, Version 2.0 (the "License");
+//    you may not use this file except in compliance with the License.
+//    You may obtain a copy of the License at
+//
+//        http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS,
+//    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//    See the License for the specific language governing permissions and
+//    limitations under the License.
+//------------------------------------------------------------------------------
+
+package hero
+
+import (
+	"fmt"
+	"github.com/zpxio/heromanager/internal/game/data/classifier"
+)
+
+// Rivalries describes each pair of the heroes' classifiers that refuse to
+// share a party, such as "caste Noble and caste Serf".
+func (h *Hero) Rivalries(other *Hero, manifest *classifier.ClassifierManifest) []string {
+	rivalries := make([]string, 0)
+	dimensions := manifest.Dimensions()
+
+	for _, mine := range dimensions {
+		c, found := manifest.Resolve(mine.Target, h.Classifier(mine.Target))
+		if !found {
+			continue
+		}
+
+		for _, theirs := range dimensions {
+			o, found := manifest.Resolve(theirs.Target, other.Classifier(theirs.Target))
+			if !found {
+				continue
+			}
+
+			if c.RivalOf(mine.Target, o, theirs.Target) {
+				rivalries = append(rivalries, fmt.Sprintf("%s %s and %s %s", mine.Kind, c.Id, theirs.Kind, o.Id))
+			}
+		}
+	}
+
+	return rivalries
+}
@@ -0,0 +1,39 @@
+//------------------------------------------------------------------------------
+//    Copyright 2019 Jeff Sharpe (zeropointx.io)
+//
+//    Licensed under the Apache License, Version 2.0 (the "License");
+//    you may not use this file except in compliance with the License.
+//    You may obtain a copy of the License at
+//
+//        http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS,
+//    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//    See the License for the specific language governing permissions and
+//    limitations under the License.
+//------------------------------------------------------------------------------
+
+package hero
+
+import (
+	"github.com/zpxio/heromanager/internal/game/data/classifier"
+)
+
+func (s *HeroTestSuite) TestRivalries() {
+	m := breakdownManifest()
+	noble := classifier.BlankCaste()
+	noble.Rivals.Add(classifier.ConflictCastes, "Peasant")
+	m.RegisterCaste("Noble", noble)
+
+	lord := baseHero()
+	lord.Race = "DWRF"
+	lord.Caste = "Noble"
+	farmer := baseHero()
+	farmer.Race = "DWRF"
+	farmer.Caste = "Peasant"
+
+	s.Equal([]string{"caste Noble and caste Peasant"}, lord.Rivalries(farmer, m))
+	s.Equal([]string{"caste Peasant and caste Noble"}, farmer.Rivalries(lord, m))
+	s.Empty(lord.Rivalries(lord, m))
+}
@@ -0,0 +1,80 @@
+//------------------------------------------------------------------------------
+//    Copyright 2019 Jeff Sharpe (zeropointx.io)
+//
+//    Licensed under the Apache License, Version 2.0 (the "License");
+//    you may not use this file except in compliance with the License.
+//    You may obtain a copy of the License at
+//
+//        http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS,
+//    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//    See the License for the specific language governing permissions and
+//    limitations under the License.
+//------------------------------------------------------------------------------
+
+package hero
+
+import (
+	"fmt"
+	"github.com/zpxio/heromanager/internal/game/data/attributes"
+	"github.com/zpxio/heromanager/internal/game/data/classifier"
+	"github.com/zpxio/heromanager/internal/game/data/progression"
+	"github.com/zpxio/heromanager/internal/game/data/table"
+	"math"
+)
+
+// Advancement reports the effect of experience gained by a hero.
+type Advancement struct {
+	Experience int                `json:"xp"`
+	From       int                `json:"from"`
+	To         int                `json:"to"`
+	Growth     map[string]float64 `json:"growth"`
+}
+
+// Recover heals up to the given amount of the hero's wounds, returning the
+// amount healed. Wounds never fall below zero.
+func (h *Hero) Recover(amount float64) float64 {
+	healed := math.Max(math.Min(amount, h.Wounds), 0.0)
+	h.Wounds -= healed
+
+	return healed
+}
+
+// GainExperience adds experience to the hero, raising its level along the
+// configured curve and growing its attributes for every level gained. Growth
+// is weighted by the modifiers of the hero's classifiers.
+func (h *Hero) GainExperience(amount int, config *progression.Config, manifest *classifier.ClassifierManifest) (*Advancement, error) {
+	if amount < 0 {
+		return nil, fmt.Errorf("experience cannot be negative: %d", amount)
+	}
+
+	curve, err := config.DefaultCurve()
+	if err != nil {
+		return nil, err
+	}
+
+	if h.Level < 1 {
+		h.Level = 1
+	}
+	advancement := Advancement{Experience: amount, From: h.Level}
+
+	h.Experience += amount
+	level := curve.Level(h.Experience)
+	if level < h.Level {
+		level = h.Level
+	}
+
+	mods := h.Modifiers(manifest)
+	modifiers := make([]table.Modifier, len(mods))
+	for i, sm := range mods {
+		modifiers[i] = sm.Modifier
+	}
+	advancement.Growth = config.Growth.Apply(&h.Attributes, attributes.Policy().ValidKeys(), level-h.Level, modifiers...)
+
+	h.Level = level
+	advancement.To = level
+
+	return &advancement, nil
+}
@@ -0,0 +1,103 @@
+//------------------------------------------------------------------------------
+//    Copyright 2019 Jeff Sharpe (zeropointx.io)
+//
+//    Licensed under the Apache License, Version 2.0 (the "License");
+//    you may not use this file except in compliance with the License.
+//    You may obtain a copy of the License at
+//
+//        http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS,
+//    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//    See the License for the specific language governing permissions and
+//    limitations under the License.
+//------------------------------------------------------------------------------
+
+package hero
+
+import (
+	"github.com/stretchr/testify/suite"
+	"github.com/zpxio/heromanager/internal/game/data/attributes"
+	"github.com/zpxio/heromanager/internal/game/data/progression"
+	"testing"
+)
+
+type ProgressionTestSuite struct {
+	suite.Suite
+}
+
+func TestProgressionSuite(t *testing.T) {
+	suite.Run(t, new(ProgressionTestSuite))
+}
+
+func testProgression() *progression.Config {
+	c := progression.NewConfig()
+	c.Curve = "standard"
+	c.Curves["standard"] = progression.Curve{MaxLevel: 4, Thresholds: []int{100, 300, 600}}
+	c.Growth.Points = 10
+
+	return c
+}
+
+func (s *ProgressionTestSuite) TestGainExperience() {
+	h := baseHero()
+	h.Race = "DWRF"
+	h.Attributes.Set(attributes.Brawn, 20.0)
+
+	a, err := h.GainExperience(50, testProgression(), breakdownManifest())
+	s.Require().Nil(err)
+	s.Equal(1, a.From)
+	s.Equal(1, a.To)
+	s.Equal(1, h.Level)
+	s.Equal(20.0, h.Attributes.Get(attributes.Brawn))
+
+	a, err = h.GainExperience(300, testProgression(), breakdownManifest())
+	s.Require().Nil(err)
+	s.Equal(1, a.From)
+	s.Equal(3, a.To)
+	s.Equal(350, h.Experience)
+	s.Equal(3, h.Level)
+
+	// The race's Brawn bonus weights growth towards Brawn
+	s.InDelta(20.0*1.5/5.5, a.Growth[attributes.Brawn], 0.0001)
+	s.InDelta(20.0/5.5, a.Growth[attributes.Vigor], 0.0001)
+	s.InDelta(20.0+20.0*1.5/5.5, h.Attributes.Get(attributes.Brawn), 0.0001)
+}
+
+func (s *ProgressionTestSuite) TestGainExperience_MaxLevel() {
+	h := baseHero()
+
+	a, err := h.GainExperience(10000, testProgression(), breakdownManifest())
+	s.Require().Nil(err)
+	s.Equal(4, a.To)
+	s.Equal(4, h.Level)
+
+	a, err = h.GainExperience(10000, testProgression(), breakdownManifest())
+	s.Require().Nil(err)
+	s.Equal(4, a.To)
+	s.InDelta(0.0, a.Growth[attributes.Brawn], 0.0001)
+}
+
+func (s *ProgressionTestSuite) TestGainExperience_Invalid() {
+	h := baseHero()
+
+	_, err := h.GainExperience(-1, testProgression(), breakdownManifest())
+	s.NotNil(err)
+
+	_, err = h.GainExperience(10, progression.NewConfig(), breakdownManifest())
+	s.NotNil(err)
+	s.Equal(0, h.Experience)
+}
+
+func (s *ProgressionTestSuite) TestRecover() {
+	h := baseHero()
+	h.Wounds = 5.0
+
+	s.Equal(2.0, h.Recover(2.0))
+	s.Equal(3.0, h.Wounds)
+	s.Equal(0.0, h.Recover(-1.0))
+	s.Equal(3.0, h.Recover(10.0))
+	s.Equal(0.0, h.Wounds)
+	s.Equal(0.0, h.Recover(1.0))
+}
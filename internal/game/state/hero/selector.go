@@ -17,224 +17,479 @@
 package hero
 
 import (
+	"fmt"
 	"github.com/zpxio/heromanager/internal/game/data/classifier"
 	"github.com/zpxio/heromanager/internal/game/util"
+	"sort"
+	"strings"
 )
 
 type Selector struct {
-	manifest          *classifier.ClassifierManifest
-	raceOptions       map[string]bool
-	casteOptions      map[string]bool
-	professionOptions map[string]bool
+	manifest *classifier.ClassifierManifest
+	options  map[string]map[string]bool
+	factors  map[string]map[string]float32
+	uniform  bool
+}
+
+// Combination is a mutually compatible race, caste and profession, along with
+// a classifier for every other dimension in the manifest.
+type Combination struct {
+	Race       string            `json:"race"`
+	Caste      string            `json:"caste"`
+	Profession string            `json:"profession"`
+	Extra      map[string]string `json:"extra,omitempty"`
+}
+
+// Unsatisfiable explains why a selector has no valid combinations.
+type Unsatisfiable struct {
+	Reasons []string
+}
+
+func (e *Unsatisfiable) Error() string {
+	return fmt.Sprintf("no valid classifier combination: %s", strings.Join(e.Reasons, "; "))
 }
 
 func NewSelector(manifest *classifier.ClassifierManifest) *Selector {
 	return &Selector{
-		manifest:          manifest,
-		raceOptions:       make(map[string]bool, 0),
-		casteOptions:      make(map[string]bool, 0),
-		professionOptions: make(map[string]bool, 0),
+		manifest: manifest,
+		options:  make(map[string]map[string]bool),
+		factors:  make(map[string]map[string]float32),
 	}
 }
 
+// AddOption restricts a dimension to the given classifier, in addition to any
+// options already added for it.
+func (s *Selector) AddOption(target string, id string) {
+	if s.options[target] == nil {
+		s.options[target] = make(map[string]bool)
+	}
+	s.options[target][id] = true
+}
+
 func (s *Selector) AddRaceOption(raceId string) {
-	s.raceOptions[raceId] = true
+	s.AddOption(classifier.ConflictRaces, raceId)
 }
 
 func (s *Selector) AddCasteOption(casteId string) {
-	s.casteOptions[casteId] = true
+	s.AddOption(classifier.ConflictCastes, casteId)
 }
 
 func (s *Selector) AddProfessionOption(professionId string) {
-	s.professionOptions[professionId] = true
+	s.AddOption(classifier.ConflictProfessions, professionId)
 }
 
-func (s *Selector) GetRaceOptions() []string {
-	if len(s.raceOptions) == 0 {
-		return s.manifest.AllRaces()
-	} else {
-		return keys(s.raceOptions)
+// ScaleWeight multiplies the weight of combinations including a classifier
+// by a factor, on top of the classifier weights. A factor of zero leaves the
+// classifier valid but never picked.
+func (s *Selector) ScaleWeight(target string, id string, factor float32) {
+	if s.factors[target] == nil {
+		s.factors[target] = make(map[string]float32)
 	}
+	s.factors[target][id] = factor
 }
 
-func (s *Selector) GetCasteOptions() []string {
-	if len(s.casteOptions) == 0 {
-		return s.manifest.AllCastes()
-	} else {
-		return keys(s.casteOptions)
+// UseUniform ignores classifier weights when picking, making every valid
+// option equally likely. Scaled weights still apply.
+func (s *Selector) UseUniform(uniform bool) {
+	s.uniform = uniform
+}
+
+// GetOptions returns the options for a dimension, or every classifier in the
+// dimension when no options were added. Options are listed in manifest order,
+// followed by any unknown options by ID.
+func (s *Selector) GetOptions(target string) []string {
+	if len(s.options[target]) == 0 {
+		return s.manifest.All(target)
 	}
+
+	opts := make([]string, 0, len(s.options[target]))
+	listed := make(map[string]bool, len(s.options[target]))
+	for _, id := range s.manifest.All(target) {
+		if s.options[target][id] {
+			opts = append(opts, id)
+			listed[id] = true
+		}
+	}
+	for _, id := range keys(s.options[target]) {
+		if !listed[id] {
+			opts = append(opts, id)
+		}
+	}
+
+	return opts
+}
+
+func (s *Selector) GetRaceOptions() []string {
+	return s.GetOptions(classifier.ConflictRaces)
+}
+
+func (s *Selector) GetCasteOptions() []string {
+	return s.GetOptions(classifier.ConflictCastes)
 }
 
 func (s *Selector) GetProfessionOptions() []string {
-	if len(s.professionOptions) == 0 {
-		return s.manifest.AllProfessions()
-	} else {
-		return keys(s.professionOptions)
+	return s.GetOptions(classifier.ConflictProfessions)
+}
+
+func (s *Selector) resolve(target string) []*classifier.Classifier {
+	resolved := make([]*classifier.Classifier, 0)
+	for _, k := range s.GetOptions(target) {
+		if c, found := s.manifest.Resolve(target, k); found {
+			resolved = append(resolved, c)
+		}
 	}
+
+	return resolved
 }
 
-func (s *Selector) PickRace() *classifier.Race {
-	set := s.GetSelectableRaces()
-	races := make([]interface{}, len(set), len(set))
+// compatible checks that neither classifier excludes the other.
+func compatible(a *classifier.Classifier, aTarget string, b *classifier.Classifier, bTarget string) bool {
+	return a.Conflicts.AllowClassifier(bTarget, b) && b.Conflicts.AllowClassifier(aTarget, a)
+}
 
-	i := 0
-	for k := range set {
-		r, found := s.manifest.ResolveRace(k)
-		if found {
-			races[i] = r
-			i++
-		}
+// Get returns the classifier chosen for a dimension.
+func (c Combination) Get(target string) string {
+	switch target {
+	case classifier.ConflictRaces:
+		return c.Race
+	case classifier.ConflictCastes:
+		return c.Caste
+	case classifier.ConflictProfessions:
+		return c.Profession
 	}
 
-	selected := util.Pick(races)
-	selectedRace := races[selected].(*classifier.Race)
+	return c.Extra[target]
+}
 
-	return selectedRace
+func (c *Combination) set(target string, id string) {
+	switch target {
+	case classifier.ConflictRaces:
+		c.Race = id
+	case classifier.ConflictCastes:
+		c.Caste = id
+	case classifier.ConflictProfessions:
+		c.Profession = id
+	default:
+		if c.Extra == nil {
+			c.Extra = make(map[string]string)
+		}
+		c.Extra[target] = id
+	}
 }
 
-func keys(set map[string]bool) []string {
-	opts := make([]string, len(set), len(set))
+func combinationOf(selection map[string]string) Combination {
+	c := Combination{}
+	for target, id := range selection {
+		c.set(target, id)
+	}
 
-	i := 0
-	for k := range set {
-		opts[i] = k
-		i++
+	return c
+}
+
+// satisfies checks the requirements of every classifier in a selection.
+func satisfies(selection map[string]string, classifiers []*classifier.Classifier) bool {
+	tags := tagsOf(classifiers...)
+	for _, cl := range classifiers {
+		if !cl.Requires.Satisfied(selection, tags) {
+			return false
+		}
 	}
 
-	return opts
+	return true
 }
 
-func (s *Selector) GetSelectableRaces() map[string]bool {
-	selectable := map[string]bool{}
+func tagsOf(classifiers ...*classifier.Classifier) map[string]bool {
+	tags := make(map[string]bool)
+	for _, cl := range classifiers {
+		for _, tag := range cl.Tags {
+			tags[tag] = true
+		}
+	}
 
-	// Build the base set of selectable options
-	for _, k := range s.GetRaceOptions() {
-		selectable[k] = true
+	return tags
+}
+
+// enumerate visits every selection drawing one option from each dimension,
+// skipping a branch as soon as pairwise returns false for a newly added
+// classifier.
+func enumerate(dimensions []classifier.Dimension, options [][]*classifier.Classifier, pairwise func(a *classifier.Classifier, aTarget string, b *classifier.Classifier, bTarget string) bool, visit func(selection map[string]string, chosen []*classifier.Classifier)) {
+	if len(dimensions) == 0 {
+		return
 	}
 
-	// Remove options that are excluded by all caste options
-RaceCasteScan:
-	for r := range selectable {
-		// Look for a valid caste that doesn't exclude the race
-		for _, k := range s.GetCasteOptions() {
-			caste, found := s.manifest.ResolveCaste(k)
-			if found {
-				if caste.Conflicts.AllowRace(r) {
-					continue RaceCasteScan
+	selection := make(map[string]string, len(dimensions))
+	chosen := make([]*classifier.Classifier, len(dimensions))
+
+	var walk func(depth int)
+	walk = func(depth int) {
+		if depth == len(dimensions) {
+			visit(selection, chosen)
+			return
+		}
+
+		target := dimensions[depth].Target
+	next:
+		for _, c := range options[depth] {
+			for i := 0; i < depth; i++ {
+				if !pairwise(chosen[i], dimensions[i].Target, c, target) {
+					continue next
 				}
 			}
+
+			selection[target] = c.Id
+			chosen[depth] = c
+			walk(depth + 1)
 		}
+		delete(selection, target)
+	}
 
-		// None found.
-		delete(selectable, r)
+	walk(0)
+}
+
+func (s *Selector) resolveAll(dimensions []classifier.Dimension) [][]*classifier.Classifier {
+	options := make([][]*classifier.Classifier, len(dimensions))
+	for i, d := range dimensions {
+		options[i] = s.resolve(d.Target)
 	}
 
-	// Remove options that are excluded by all profession options
-RaceProfessionScan:
-	for r := range selectable {
-		// Look for a valid profession that doesn't exclude the race
-		for _, k := range s.GetProfessionOptions() {
-			profession, found := s.manifest.ResolveProfession(k)
-			if found {
-				if profession.Conflicts.AllowRace(r) {
-					continue RaceProfessionScan
-				}
-			}
+	return options
+}
+
+// ValidCombinations enumerates every combination drawn from the selector
+// options that is compatible in every pairing and satisfies every
+// requirement.
+func (s *Selector) ValidCombinations() []Combination {
+	combinations := make([]Combination, 0)
+
+	dimensions := s.manifest.Dimensions()
+	enumerate(dimensions, s.resolveAll(dimensions), compatible, func(selection map[string]string, chosen []*classifier.Classifier) {
+		if satisfies(selection, chosen) {
+			combinations = append(combinations, combinationOf(selection))
 		}
+	})
 
-		// None found.
-		delete(selectable, r)
+	return combinations
+}
+
+// unmetRequirements reports option classifiers whose requirements no
+// combination of the options can satisfy.
+func (s *Selector) unmetRequirements(dimensions []classifier.Dimension, options [][]*classifier.Classifier) []string {
+	reasons := make([]string, 0)
+
+	unconstrained := func(a *classifier.Classifier, aTarget string, b *classifier.Classifier, bTarget string) bool {
+		return true
 	}
 
-	return selectable
+	satisfiable := func(cl *classifier.Classifier) bool {
+		satisfied := false
+		enumerate(dimensions, options, unconstrained, func(selection map[string]string, chosen []*classifier.Classifier) {
+			if !satisfied && cl.Requires.Satisfied(selection, tagsOf(chosen...)) {
+				satisfied = true
+			}
+		})
+
+		return satisfied
+	}
+
+	for i, d := range dimensions {
+		for _, cl := range options[i] {
+			if !cl.Requires.Empty() && !satisfiable(cl) {
+				reasons = append(reasons, fmt.Sprintf("%s %s requires %s", d.Kind, cl.Id, cl.Requires.Describe()))
+			}
+		}
+	}
+
+	return reasons
 }
 
-func (s *Selector) GetSelectableCastes() map[string]bool {
-	selectable := map[string]bool{}
+// Explain describes why no valid combination exists. It returns nil when at
+// least one combination is valid.
+func (s *Selector) Explain() error {
+	if len(s.ValidCombinations()) > 0 {
+		return nil
+	}
+
+	reasons := make([]string, 0)
 
-	// Build the base set of selectable options
-	for _, k := range s.GetCasteOptions() {
-		selectable[k] = true
+	dimensions := s.manifest.Dimensions()
+	for _, d := range dimensions {
+		reasons = append(reasons, s.unknownOptions(d.Target)...)
+	}
+
+	options := s.resolveAll(dimensions)
+	for i, d := range dimensions {
+		if len(options[i]) == 0 {
+			reasons = append(reasons, fmt.Sprintf("no %s options", d.Kind))
+		}
 	}
 
-	// Remove options that are excluded by all race options
-CasteRaceScan:
-	for r := range selectable {
-		// Look for a valid caste that doesn't exclude the race
-		for _, k := range s.GetRaceOptions() {
-			race, found := s.manifest.ResolveRace(k)
-			if found {
-				if race.Conflicts.AllowCaste(r) {
-					continue CasteRaceScan
+	for i, a := range dimensions {
+		for j := i + 1; j < len(dimensions); j++ {
+			b := dimensions[j]
+			for _, ac := range options[i] {
+				for _, bc := range options[j] {
+					if !compatible(ac, a.Target, bc, b.Target) {
+						reasons = append(reasons, fmt.Sprintf("%s %s conflicts with %s %s", a.Kind, ac.Id, b.Kind, bc.Id))
+					}
 				}
 			}
 		}
+	}
+
+	reasons = append(reasons, s.unmetRequirements(dimensions, options)...)
 
-		// None found.
-		delete(selectable, r)
+	return &Unsatisfiable{Reasons: reasons}
+}
+
+func (s *Selector) unknownOptions(target string) []string {
+	reasons := make([]string, 0)
+	for _, id := range keys(s.options[target]) {
+		if _, found := s.manifest.Resolve(target, id); !found {
+			reasons = append(reasons, fmt.Sprintf("unknown %s option: %s", target, id))
+		}
 	}
 
-	// Remove options that are excluded by all profession options
-CasteProfessionScan:
-	for r := range selectable {
-		// Look for a valid profession that doesn't exclude the race
-		for _, k := range s.GetProfessionOptions() {
-			profession, found := s.manifest.ResolveProfession(k)
-			if found {
-				if profession.Conflicts.AllowCaste(r) {
-					continue CasteProfessionScan
-				}
-			}
+	return reasons
+}
+
+// weightedOption adapts a conditional weight to util.Weighted.
+type weightedOption struct {
+	weight float32
+}
+
+func (o weightedOption) Rarity() float32 {
+	return o.weight
+}
+
+// weight is the joint weight of a combination: the weight of the first
+// dimension's classifier multiplied by the conditional weight of each later
+// dimension's classifier given the ones before it, and by any scaled weights.
+func (s *Selector) weight(c Combination) float32 {
+	weight := float32(1.0)
+	given := make(map[string]string)
+	for _, d := range s.manifest.Dimensions() {
+		id := c.Get(d.Target)
+		if !s.uniform {
+			cl, _ := s.manifest.Resolve(d.Target, id)
+			weight *= cl.WeightGiven(given)
+		}
+		if factor, scaled := s.factors[d.Target][id]; scaled {
+			weight *= factor
 		}
+		given[d.Target] = id
+	}
+
+	return weight
+}
 
-		// None found.
-		delete(selectable, r)
+func (s *Selector) pick(combinations []Combination, randSource ...float32) Combination {
+	options := make([]interface{}, len(combinations))
+	for i, c := range combinations {
+		options[i] = weightedOption{s.weight(c)}
 	}
 
-	return selectable
+	return combinations[util.Pick(options, randSource...)]
 }
 
-func (s *Selector) GetSelectableProfessions() map[string]bool {
-	selectable := map[string]bool{}
+// PickCombination picks among all valid combinations, by joint weight unless
+// the selector is uniform.
+func (s *Selector) PickCombination(randSource ...float32) (*Combination, error) {
+	combinations := s.ValidCombinations()
+	if len(combinations) == 0 {
+		return nil, s.Explain()
+	}
+
+	c := s.pick(combinations, randSource...)
+
+	return &c, nil
+}
 
-	// Build the base set of selectable options
-	for _, k := range s.GetProfessionOptions() {
-		selectable[k] = true
+// PickRace picks a race that is part of at least one valid combination,
+// weighted by the total weight of its combinations.
+func (s *Selector) PickRace(randSource ...float32) *classifier.Race {
+	c, err := s.PickCombination(randSource...)
+	if err != nil {
+		return nil
 	}
 
-	// Remove options that are excluded by all race options
-ProfessionRaceScan:
-	for r := range selectable {
-		// Look for a valid caste that doesn't exclude the race
-		for _, k := range s.GetRaceOptions() {
-			race, found := s.manifest.ResolveRace(k)
-			if found {
-				if race.Conflicts.AllowProfession(r) {
-					continue ProfessionRaceScan
-				}
-			}
-		}
+	r, _ := s.manifest.ResolveRace(c.Race)
 
-		// None found.
-		delete(selectable, r)
+	return r
+}
+
+// PickCaste picks a caste that forms a valid combination with the given race.
+func (s *Selector) PickCaste(raceId string, randSource ...float32) *classifier.Caste {
+	combinations := filter(s.ValidCombinations(), func(c Combination) bool {
+		return c.Race == raceId
+	})
+	if len(combinations) == 0 {
+		return nil
 	}
 
-	// Remove options that are excluded by all profession options
-ProfessionCasteScan:
-	for r := range selectable {
-		// Look for a valid profession that doesn't exclude the race
-		for _, k := range s.GetCasteOptions() {
-			profession, found := s.manifest.ResolveCaste(k)
-			if found {
-				if profession.Conflicts.AllowProfession(r) {
-					continue ProfessionCasteScan
-				}
-			}
+	c, _ := s.manifest.ResolveCaste(s.pick(combinations, randSource...).Caste)
+
+	return c
+}
+
+// PickProfession picks a profession that forms a valid combination with the
+// given race and caste.
+func (s *Selector) PickProfession(raceId string, casteId string, randSource ...float32) *classifier.Profession {
+	combinations := filter(s.ValidCombinations(), func(c Combination) bool {
+		return c.Race == raceId && c.Caste == casteId
+	})
+	if len(combinations) == 0 {
+		return nil
+	}
+
+	p, _ := s.manifest.ResolveProfession(s.pick(combinations, randSource...).Profession)
+
+	return p
+}
+
+func filter(combinations []Combination, accept func(Combination) bool) []Combination {
+	filtered := make([]Combination, 0, len(combinations))
+	for _, c := range combinations {
+		if accept(c) {
+			filtered = append(filtered, c)
 		}
+	}
 
-		// None found.
-		delete(selectable, r)
+	return filtered
+}
+
+func keys(set map[string]bool) []string {
+	opts := make([]string, len(set), len(set))
+
+	i := 0
+	for k := range set {
+		opts[i] = k
+		i++
+	}
+	sort.Strings(opts)
+
+	return opts
+}
+
+// GetSelectable returns the classifiers of a dimension that are part of at
+// least one valid combination.
+func (s *Selector) GetSelectable(target string) map[string]bool {
+	selectable := map[string]bool{}
+
+	for _, c := range s.ValidCombinations() {
+		selectable[c.Get(target)] = true
 	}
 
 	return selectable
 }
+
+func (s *Selector) GetSelectableRaces() map[string]bool {
+	return s.GetSelectable(classifier.ConflictRaces)
+}
+
+func (s *Selector) GetSelectableCastes() map[string]bool {
+	return s.GetSelectable(classifier.ConflictCastes)
+}
+
+func (s *Selector) GetSelectableProfessions() map[string]bool {
+	return s.GetSelectable(classifier.ConflictProfessions)
+}
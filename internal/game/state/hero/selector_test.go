@@ -111,18 +111,18 @@ func TestSelectorSuite(t *testing.T) {
 func (s *SelectorTestSuite) TestNewSelector() {
 	x := NewSelector(s.manifest)
 
-	s.Empty(x.raceOptions)
-	s.Empty(x.casteOptions)
-	s.Empty(x.professionOptions)
+	s.Empty(x.options[classifier.ConflictRaces])
+	s.Empty(x.options[classifier.ConflictCastes])
+	s.Empty(x.options[classifier.ConflictProfessions])
 }
 
 func (s *SelectorTestSuite) TestRaceOptions() {
 	x := NewSelector(s.manifest)
 
-	s.Empty(x.raceOptions)
+	s.Empty(x.options[classifier.ConflictRaces])
 
 	x.AddRaceOption("A")
-	s.Len(x.raceOptions, 1)
+	s.Len(x.options[classifier.ConflictRaces], 1)
 	s.Len(x.GetRaceOptions(), 1)
 	s.Contains(x.GetRaceOptions(), "A")
 
@@ -137,10 +137,10 @@ func (s *SelectorTestSuite) TestRaceOptions() {
 func (s *SelectorTestSuite) TestCasteOptions() {
 	x := NewSelector(s.manifest)
 
-	s.Empty(x.casteOptions)
+	s.Empty(x.options[classifier.ConflictCastes])
 
 	x.AddCasteOption("A")
-	s.Len(x.casteOptions, 1)
+	s.Len(x.options[classifier.ConflictCastes], 1)
 	s.Len(x.GetCasteOptions(), 1)
 	s.Contains(x.GetCasteOptions(), "A")
 
@@ -155,10 +155,10 @@ func (s *SelectorTestSuite) TestCasteOptions() {
 func (s *SelectorTestSuite) TestProfessionOptions() {
 	x := NewSelector(s.manifest)
 
-	s.Empty(x.professionOptions)
+	s.Empty(x.options[classifier.ConflictProfessions])
 
 	x.AddProfessionOption("A")
-	s.Len(x.professionOptions, 1)
+	s.Len(x.options[classifier.ConflictProfessions], 1)
 	s.Len(x.GetProfessionOptions(), 1)
 	s.Contains(x.GetProfessionOptions(), "A")
 
@@ -300,3 +300,396 @@ func (s *SelectorTestSuite) TestGetSelectableProfessions_NoneByCaste() {
 
 	s.Len(targets, 0)
 }
+
+func (s *SelectorTestSuite) TestPickRace_None() {
+	x := NewSelector(s.manifest)
+
+	x.AddRaceOption("Dwarf")
+	x.AddCasteOption("Noble")
+
+	s.Nil(x.PickRace())
+}
+
+func (s *SelectorTestSuite) TestPickCaste_Compatible() {
+	x := NewSelector(s.manifest)
+
+	for i := 0; i < 100; i++ {
+		c := x.PickCaste("Dwarf")
+		s.Require().NotNil(c)
+		s.Contains([]string{"Outcast", "Elder"}, c.Id)
+	}
+}
+
+func (s *SelectorTestSuite) TestPickCaste_None() {
+	x := NewSelector(s.manifest)
+
+	x.AddCasteOption("Serf")
+
+	s.Nil(x.PickCaste("Elf"))
+}
+
+func (s *SelectorTestSuite) TestPickProfession_Compatible() {
+	x := NewSelector(s.manifest)
+
+	for i := 0; i < 100; i++ {
+		p := x.PickProfession("Dwarf", "Elder")
+		s.Require().NotNil(p)
+		s.Contains([]string{"Miner", "Mason", "Mystic"}, p.Id)
+	}
+}
+
+func (s *SelectorTestSuite) TestPickProfession_None() {
+	x := NewSelector(s.manifest)
+
+	x.AddProfessionOption("Pirate")
+
+	s.Nil(x.PickProfession("Dwarf", "Outcast"))
+}
+
+func weightedManifest() *classifier.ClassifierManifest {
+	m := classifier.NewManifest()
+
+	common := classifier.BlankRace()
+	common.Weight = 3.0
+	m.RegisterRace("Common", common)
+
+	rare := classifier.BlankRace()
+	rare.Weight = 1.0
+	m.RegisterRace("Rare", rare)
+
+	noble := classifier.BlankCaste()
+	noble.Weight = 1.0
+	noble.Weights[classifier.ConflictRaces] = map[string]float32{"Rare": 9.0}
+	m.RegisterCaste("Noble", noble)
+
+	serf := classifier.BlankCaste()
+	serf.Weight = 3.0
+	m.RegisterCaste("Serf", serf)
+
+	m.RegisterProfession("Farmer", classifier.BlankProfession())
+
+	return m
+}
+
+func (s *SelectorTestSuite) TestPickRace_Weighted() {
+	x := NewSelector(weightedManifest())
+
+	// Joint weights: Common/Noble 3, Common/Serf 9, Rare/Noble 9, Rare/Serf 3
+	s.Equal("Common", x.PickRace(0.45).Id)
+	s.Equal("Rare", x.PickRace(0.55).Id)
+}
+
+func (s *SelectorTestSuite) TestPickCaste_ConditionalWeight() {
+	x := NewSelector(weightedManifest())
+
+	// Without a race bonus: Noble [0, 0.25), Serf [0.25, 1.0)
+	s.Equal("Noble", x.PickCaste("Common", 0.2).Id)
+	s.Equal("Serf", x.PickCaste("Common", 0.3).Id)
+
+	// With the race bonus: Noble [0, 0.75), Serf [0.75, 1.0)
+	s.Equal("Noble", x.PickCaste("Rare", 0.7).Id)
+	s.Equal("Serf", x.PickCaste("Rare", 0.8).Id)
+}
+
+func (s *SelectorTestSuite) TestScaleWeight() {
+	x := NewSelector(weightedManifest())
+
+	// Joint weights: Common/Noble 3, Common/Serf 9, Rare/Noble 27, Rare/Serf 9
+	x.ScaleWeight(classifier.ConflictRaces, "Rare", 3.0)
+	s.Equal("Common", x.PickRace(0.2).Id)
+	s.Equal("Rare", x.PickRace(0.3).Id)
+
+	x.UseUniform(true)
+	x.ScaleWeight(classifier.ConflictCastes, "Serf", 0.0)
+	for _, r := range []float32{0.0, 0.5, 0.99} {
+		c, err := x.PickCombination(r)
+		s.Require().Nil(err)
+		s.Equal("Noble", c.Caste)
+	}
+}
+
+func (s *SelectorTestSuite) TestValidCombinations_All() {
+	x := NewSelector(s.manifest)
+
+	combinations := x.ValidCombinations()
+
+	s.NotEmpty(combinations)
+	for _, c := range combinations {
+		r, _ := s.manifest.ResolveRace(c.Race)
+		ca, _ := s.manifest.ResolveCaste(c.Caste)
+		p, _ := s.manifest.ResolveProfession(c.Profession)
+
+		s.True(r.Conflicts.AllowCaste(c.Caste) && r.Conflicts.AllowProfession(c.Profession))
+		s.True(ca.Conflicts.AllowRace(c.Race) && ca.Conflicts.AllowProfession(c.Profession))
+		s.True(p.Conflicts.AllowRace(c.Race) && p.Conflicts.AllowCaste(c.Caste))
+	}
+}
+
+func (s *SelectorTestSuite) TestValidCombinations_Joint() {
+	x := NewSelector(s.manifest)
+
+	// Elf is compatible with Elder and with Pirate, but Elder and Pirate
+	// exclude each other, so no triple exists.
+	x.AddRaceOption("Elf")
+	x.AddCasteOption("Elder")
+	x.AddProfessionOption("Pirate")
+
+	s.Empty(x.ValidCombinations())
+	s.Empty(x.GetSelectableRaces())
+	s.Empty(x.GetSelectableCastes())
+	s.Empty(x.GetSelectableProfessions())
+	s.Contains(x.Explain().Error(), "caste Elder conflicts with profession Pirate")
+}
+
+func (s *SelectorTestSuite) TestValidCombinations_Exact() {
+	x := NewSelector(s.manifest)
+
+	x.AddRaceOption("Elf")
+	x.AddCasteOption("Noble")
+	x.AddCasteOption("Elder")
+	x.AddProfessionOption("Pirate")
+	x.AddProfessionOption("Mystic")
+
+	s.Equal([]Combination{
+		{Race: "Elf", Caste: "Elder", Profession: "Mystic"},
+		{Race: "Elf", Caste: "Noble", Profession: "Mystic"},
+		{Race: "Elf", Caste: "Noble", Profession: "Pirate"},
+	}, x.ValidCombinations())
+}
+
+func (s *SelectorTestSuite) TestPickCombination() {
+	x := NewSelector(s.manifest)
+
+	x.AddRaceOption("Elf")
+	x.AddCasteOption("Noble")
+	x.AddProfessionOption("Pirate")
+	x.AddProfessionOption("Mystic")
+
+	c, err := x.PickCombination(0.1)
+	s.Require().Nil(err)
+	s.Equal(Combination{Race: "Elf", Caste: "Noble", Profession: "Mystic"}, *c)
+
+	c, err = x.PickCombination(0.9)
+	s.Require().Nil(err)
+	s.Equal(Combination{Race: "Elf", Caste: "Noble", Profession: "Pirate"}, *c)
+}
+
+func (s *SelectorTestSuite) TestGetOptions_Order() {
+	m := setupManifest()
+	elf, _ := m.ResolveRace("Elf")
+	elf.Order = -1
+	m.RegisterRace("Elf", *elf)
+
+	x := NewSelector(m)
+	s.Equal([]string{"Elf", "Dwarf", "Human"}, x.GetRaceOptions())
+
+	x.AddRaceOption("Human")
+	x.AddRaceOption("Unknown")
+	x.AddRaceOption("Elf")
+	s.Equal([]string{"Elf", "Human", "Unknown"}, x.GetRaceOptions())
+}
+
+func (s *SelectorTestSuite) TestPickCombination_Order() {
+	m := setupManifest()
+	x := NewSelector(m)
+	x.AddRaceOption("Elf")
+	x.AddCasteOption("Noble")
+	x.AddProfessionOption("Pirate")
+	x.AddProfessionOption("Mystic")
+
+	mystic, _ := m.ResolveProfession("Mystic")
+	mystic.Order = 1
+	m.RegisterProfession("Mystic", *mystic)
+
+	// The same random value picks by listing order
+	c, err := x.PickCombination(0.1)
+	s.Require().Nil(err)
+	s.Equal(Combination{Race: "Elf", Caste: "Noble", Profession: "Pirate"}, *c)
+}
+
+func (s *SelectorTestSuite) TestPickCombination_Uniform() {
+	x := NewSelector(weightedManifest())
+	x.UseUniform(true)
+
+	// Four combinations with equal weight
+	c, err := x.PickCombination(0.3)
+	s.Require().Nil(err)
+	s.Equal(Combination{Race: "Common", Caste: "Serf", Profession: "Farmer"}, *c)
+
+	c, err = x.PickCombination(0.6)
+	s.Require().Nil(err)
+	s.Equal(Combination{Race: "Rare", Caste: "Noble", Profession: "Farmer"}, *c)
+}
+
+func (s *SelectorTestSuite) TestPickCombination_Unsatisfiable() {
+	x := NewSelector(s.manifest)
+
+	x.AddRaceOption("Dwarf")
+	x.AddRaceOption("Gnome")
+	x.AddCasteOption("Noble")
+
+	c, err := x.PickCombination()
+	s.Nil(c)
+	s.Require().NotNil(err)
+
+	unsatisfiable, ok := err.(*Unsatisfiable)
+	s.Require().True(ok)
+	s.Contains(unsatisfiable.Reasons, "unknown races option: Gnome")
+	s.Contains(unsatisfiable.Reasons, "race Dwarf conflicts with caste Noble")
+}
+
+func (s *SelectorTestSuite) TestExplain_Satisfiable() {
+	x := NewSelector(s.manifest)
+
+	s.Nil(x.Explain())
+}
+
+func (s *SelectorTestSuite) TestExplain_Empty() {
+	x := NewSelector(classifier.NewManifest())
+
+	err := x.Explain()
+	s.Require().NotNil(err)
+	s.Contains(err.Error(), "no race options")
+}
+
+func requirementsManifest() *classifier.ClassifierManifest {
+	m := classifier.NewManifest()
+
+	m.RegisterRace("Dwarf", classifier.BlankRace())
+	m.RegisterRace("Elf", classifier.BlankRace())
+
+	m.RegisterCaste("Noble", classifier.BlankCaste())
+	m.RegisterCaste("Serf", classifier.BlankCaste())
+
+	paladin := classifier.BlankProfession()
+	paladin.Requires.AllOf.Add(classifier.ConflictCastes, "Noble")
+	paladin.Requires.AnyOf.Add(classifier.ConflictRaces, "Dwarf")
+	m.RegisterProfession("Paladin", paladin)
+
+	farmer := classifier.BlankProfession()
+	farmer.Requires.NoneOf.Add(classifier.ConflictCastes, "Noble")
+	m.RegisterProfession("Farmer", farmer)
+
+	return m
+}
+
+func (s *SelectorTestSuite) TestValidCombinations_Requirements() {
+	x := NewSelector(requirementsManifest())
+
+	s.Equal([]Combination{
+		{Race: "Dwarf", Caste: "Noble", Profession: "Paladin"},
+		{Race: "Dwarf", Caste: "Serf", Profession: "Farmer"},
+		{Race: "Elf", Caste: "Serf", Profession: "Farmer"},
+	}, x.ValidCombinations())
+}
+
+func (s *SelectorTestSuite) TestExplain_Requirements() {
+	x := NewSelector(requirementsManifest())
+
+	x.AddRaceOption("Elf")
+	x.AddProfessionOption("Paladin")
+
+	err := x.Explain()
+	s.Require().NotNil(err)
+	s.Contains(err.Error(), "profession Paladin requires all of [castes:Noble], any of [races:Dwarf]")
+}
+
+func (s *SelectorTestSuite) TestValidCombinations_Tags() {
+	m := classifier.NewManifest()
+
+	dwarf := classifier.BlankRace()
+	m.RegisterRace("Dwarf", dwarf)
+
+	ghoul := classifier.BlankRace()
+	ghoul.Tags = []string{"undead"}
+	m.RegisterRace("Ghoul", ghoul)
+
+	m.RegisterCaste("Noble", classifier.BlankCaste())
+
+	priest := classifier.BlankProfession()
+	priest.Tags = []string{"clergy"}
+	priest.Conflicts.Add(classifier.ConflictTags, "undead")
+	m.RegisterProfession("Priest", priest)
+
+	knight := classifier.BlankProfession()
+	knight.Requires.AllOf.Add(classifier.ConflictTags, "undead")
+	m.RegisterProfession("DeathKnight", knight)
+
+	x := NewSelector(m)
+
+	s.Equal([]Combination{
+		{Race: "Dwarf", Caste: "Noble", Profession: "Priest"},
+		{Race: "Ghoul", Caste: "Noble", Profession: "DeathKnight"},
+	}, x.ValidCombinations())
+
+	// A new undead race inherits the rule without listing it
+	wight := classifier.BlankRace()
+	wight.Tags = []string{"undead"}
+	m.RegisterRace("Wight", wight)
+
+	x.AddRaceOption("Wight")
+	x.AddProfessionOption("Priest")
+	s.Empty(x.ValidCombinations())
+}
+
+func faithManifest() *classifier.ClassifierManifest {
+	m := classifier.NewManifest()
+	if err := m.Declare(classifier.Dimension{Target: "faiths", Kind: "faith"}); err != nil {
+		panic(err)
+	}
+
+	m.RegisterRace("Dwarf", classifier.BlankRace())
+	m.RegisterRace("Elf", classifier.BlankRace())
+	m.RegisterCaste("Noble", classifier.BlankCaste())
+	m.RegisterProfession("Priest", classifier.BlankProfession())
+
+	sun := classifier.Initialize()
+	sun.Conflicts.Add(classifier.ConflictRaces, "Dwarf")
+	m.Register("faiths", "Sun", sun)
+
+	stone := classifier.Initialize()
+	stone.Weight = 3.0
+	stone.Weights = map[string]map[string]float32{classifier.ConflictRaces: {"Elf": 0.0}}
+	m.Register("faiths", "Stone", stone)
+
+	return m
+}
+
+func (s *SelectorTestSuite) TestValidCombinations_Dimension() {
+	x := NewSelector(faithManifest())
+
+	s.Equal([]Combination{
+		{Race: "Dwarf", Caste: "Noble", Profession: "Priest", Extra: map[string]string{"faiths": "Stone"}},
+		{Race: "Elf", Caste: "Noble", Profession: "Priest", Extra: map[string]string{"faiths": "Stone"}},
+		{Race: "Elf", Caste: "Noble", Profession: "Priest", Extra: map[string]string{"faiths": "Sun"}},
+	}, x.ValidCombinations())
+	s.Equal(map[string]bool{"Stone": true, "Sun": true}, x.GetSelectable("faiths"))
+
+	x.AddOption("faiths", "Sun")
+	s.Equal(map[string]bool{"Elf": true}, x.GetSelectableRaces())
+}
+
+func (s *SelectorTestSuite) TestPickCombination_Dimension() {
+	x := NewSelector(faithManifest())
+
+	// Dwarf/Stone weighs 3, Elf/Stone 0 and Elf/Sun 1
+	c, err := x.PickCombination(0.5)
+	s.Require().Nil(err)
+	s.Equal("Dwarf", c.Race)
+	s.Equal("Stone", c.Get("faiths"))
+
+	c, err = x.PickCombination(0.9)
+	s.Require().Nil(err)
+	s.Equal("Elf", c.Race)
+	s.Equal("Sun", c.Get("faiths"))
+}
+
+func (s *SelectorTestSuite) TestExplain_Dimension() {
+	x := NewSelector(faithManifest())
+	x.AddRaceOption("Dwarf")
+	x.AddOption("faiths", "Sun")
+
+	err := x.Explain()
+	s.Require().NotNil(err)
+	s.Contains(err.Error(), "race Dwarf conflicts with faith Sun")
+}
@@ -0,0 +1,66 @@
+//------------------------------------------------------------------------------
+//    Copyright 2019 Jeff Sharpe (zeropointx.io)
+//
+//    Licensed under the Apache License, Version 2.0 (the "License");
+//    you may not use this file except in compliance with the License.
+//    You may obtain a copy of the License at
+//
+//        http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS,
+//    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//    See the License for the specific language governing permissions and
+//    limitations under the License.
+//------------------------------------------------------------------------------
+
+package hero
+
+import (
+	"fmt"
+	"github.com/zpxio/heromanager/internal/game/data/classifier"
+	"github.com/zpxio/heromanager/internal/game/data/skills"
+	"github.com/zpxio/heromanager/internal/game/data/table"
+	"math/rand"
+)
+
+// GrantSkills gives the hero a fresh skill table holding the skills granted
+// by each of its classifiers.
+func (h *Hero) GrantSkills(definitions *skills.Definitions, manifest *classifier.ClassifierManifest) {
+	h.Skills = definitions.NewValues()
+
+	for _, d := range manifest.Dimensions() {
+		c, found := manifest.Resolve(d.Target, h.Classifier(d.Target))
+		if !found {
+			continue
+		}
+		for _, id := range definitions.All() {
+			if value, granted := c.Skills[id]; granted {
+				h.Skills.Set(id, h.Skills.Get(id)+value)
+			}
+		}
+	}
+}
+
+// Skill returns the hero's value in a skill, which is zero for skills the
+// hero never learned.
+func (h *Hero) Skill(id string) float64 {
+	if h.Skills.Policy() == nil || !h.Skills.Policy().ValidKey(id) {
+		return skills.DefaultValue
+	}
+
+	return h.Skills.Get(id)
+}
+
+// Check rolls a skill check for the hero against a difficulty, using the
+// hero's effective attributes with any extra modifiers, such as equipment.
+func (h *Hero) Check(id string, difficulty float64, definitions *skills.Definitions, manifest *classifier.ClassifierManifest, rng *rand.Rand, extra ...table.SourcedModifier) (*skills.Result, error) {
+	skill, found := definitions.Resolve(id)
+	if !found {
+		return nil, fmt.Errorf("unknown skill: %s", id)
+	}
+
+	result := skill.Check(id, h.EffectiveAttributes(manifest, extra...), h.Skill(id), difficulty, rng)
+
+	return &result, nil
+}
@@ -0,0 +1,92 @@
+//------------------------------------------------------------------------------
+//    Copyright 2019 Jeff Sharpe (zeropointx.io)
+//
+//    Licensed under the Apache License, Version 2.0 (the "License");
+//    you may not use this file except in compliance with the License.
+//    You may obtain a copy of the License at
+//
+//        http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS,
+//    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//    See the License for the specific language governing permissions and
+//    limitations under the License.
+//------------------------------------------------------------------------------
+
+package hero
+
+import (
+	"github.com/stretchr/testify/suite"
+	"github.com/zpxio/heromanager/internal/game/data/attributes"
+	"github.com/zpxio/heromanager/internal/game/data/classifier"
+	"github.com/zpxio/heromanager/internal/game/data/skills"
+	"math/rand"
+	"testing"
+)
+
+type SkillsTestSuite struct {
+	suite.Suite
+}
+
+func TestSkillsSuite(t *testing.T) {
+	suite.Run(t, new(SkillsTestSuite))
+}
+
+func testSkills() *skills.Definitions {
+	d := skills.NewDefinitions()
+	d.Register("Smithing", skills.Skill{Name: "Smithing", Attributes: []string{attributes.Brawn}})
+	d.Register("Lore", skills.Skill{Name: "Lore", Attributes: []string{attributes.Insight}})
+
+	return d
+}
+
+func skilledManifest() *classifier.ClassifierManifest {
+	m := breakdownManifest()
+
+	smith := classifier.BlankProfession()
+	smith.Skills["Smithing"] = 30.0
+	m.RegisterProfession("Smith", smith)
+
+	dwarf, _ := m.ResolveRace("DWRF")
+	dwarf.Skills["Smithing"] = 10.0
+	m.RegisterRace("DWRF", *dwarf)
+
+	return m
+}
+
+func (s *SkillsTestSuite) TestGrantSkills() {
+	h := baseHero()
+	h.Race = "DWRF"
+	h.Profession = "Smith"
+
+	h.GrantSkills(testSkills(), skilledManifest())
+
+	s.Equal(40.0, h.Skill("Smithing"))
+	s.Equal(0.0, h.Skill("Lore"))
+	s.Equal(0.0, h.Skill("Juggling"))
+}
+
+func (s *SkillsTestSuite) TestSkill_Ungranted() {
+	h := baseHero()
+
+	s.Equal(skills.DefaultValue, h.Skill("Smithing"))
+}
+
+func (s *SkillsTestSuite) TestCheck() {
+	h := baseHero()
+	h.Race = "DWRF"
+	h.Profession = "Smith"
+	h.Attributes.Set(attributes.Brawn, 20.0)
+	h.GrantSkills(testSkills(), skilledManifest())
+
+	r, err := h.Check("Smithing", 200.0, testSkills(), skilledManifest(), rand.New(rand.NewSource(1)))
+	s.Require().Nil(err)
+
+	// The race's Brawn bonus counts towards the rating
+	s.InDelta(40.0+skills.AttributeShare*30.0, r.Rating, 0.0001)
+	s.False(r.Success)
+
+	_, err = h.Check("Juggling", 10.0, testSkills(), skilledManifest(), rand.New(rand.NewSource(1)))
+	s.NotNil(err)
+}
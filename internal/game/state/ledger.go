@@ -0,0 +1,72 @@
+//------------------------------------------------------------------------------
+//    Copyright 2019 Jeff Sharpe (zeropointx.io)
+//
+//    Licensed under the Apache License, Version 2.0 (the "License");
+//    you may not use this file except in compliance with the License.
+//    You may obtain a copy of the License at
+//
+//        http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS,
+//    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//    See the License for the specific language governing permissions and
+//    limitations under the License.
+//------------------------------------------------------------------------------
+
+package state
+
+// Kinds of transaction recorded in the ledger.
+const (
+	TransactionOpening = "opening"
+	TransactionWage    = "wage"
+	TransactionUpkeep  = "upkeep"
+	TransactionQuest   = "quest"
+	TransactionHire    = "hire"
+)
+
+// Transaction moves money in or out of the treasury. Income is positive and
+// expenses negative.
+type Transaction struct {
+	Tick    uint64 `json:"tick"`
+	Kind    string `json:"kind"`
+	Amount  int    `json:"amount"`
+	Balance int    `json:"balance"`
+	Hero    string `json:"hero,omitempty"`
+	Memo    string `json:"memo"`
+}
+
+// Record adjusts the treasury by a transaction, appending it to the ledger
+// along with the balance it leaves.
+func (s *State) Record(t Transaction) Transaction {
+	s.Treasury += t.Amount
+	t.Balance = s.Treasury
+	s.Ledger = append(s.Ledger, t)
+
+	return t
+}
+
+// LedgerFilter selects transactions from the ledger. Empty fields select
+// every transaction, and a limit keeps only the most recent.
+type LedgerFilter struct {
+	Kind  string
+	Hero  string
+	Since uint64
+	Limit int
+}
+
+// Transactions lists the transactions matching a filter, oldest first.
+func (s *State) Transactions(f LedgerFilter) []Transaction {
+	matched := make([]Transaction, 0)
+	for _, t := range s.Ledger {
+		if (f.Kind == "" || t.Kind == f.Kind) && (f.Hero == "" || t.Hero == f.Hero) && t.Tick >= f.Since {
+			matched = append(matched, t)
+		}
+	}
+
+	if f.Limit > 0 && len(matched) > f.Limit {
+		matched = matched[len(matched)-f.Limit:]
+	}
+
+	return matched
+}
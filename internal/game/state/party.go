@@ -0,0 +1,109 @@
+//------------------------------------------------------------------------------
+//    Copyright 2019 Jeff Sharpe (zeropointx.io)
+//
+//    Licensed under the Apache License, Version 2.0 (the "License");
+//    you may not use this file except in compliance with the License.
+//    You may obtain a copy of the License at
+//
+//        http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS,
+//    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//    See the License for the specific language governing permissions and
+//    limitations under the License.
+//------------------------------------------------------------------------------
+
+package state
+
+// Formation roles place party members in the front line, the middle or the
+// rear.
+const (
+	RoleFront  = "front"
+	RoleMiddle = "middle"
+	RoleRear   = "rear"
+)
+
+// Roles lists the formation roles from front to rear.
+func Roles() []string {
+	return []string{RoleFront, RoleMiddle, RoleRear}
+}
+
+func ValidRole(role string) bool {
+	for _, r := range Roles() {
+		if r == role {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Rank places a formation role, from the front rank at zero. Unknown roles
+// rank with the middle.
+func Rank(role string) int {
+	for i, r := range Roles() {
+		if r == role {
+			return i
+		}
+	}
+
+	return Rank(RoleMiddle)
+}
+
+// Member is a hero in a party, with its place in the formation.
+type Member struct {
+	Hero string `json:"hero"`
+	Role string `json:"role"`
+}
+
+// Party is a standing group of heroes led by one of its members.
+type Party struct {
+	Id      string   `json:"id"`
+	Name    string   `json:"name"`
+	Leader  string   `json:"leader"`
+	Members []Member `json:"members"`
+}
+
+// Heroes lists the IDs of the members.
+func (p *Party) Heroes() []string {
+	ids := make([]string, 0, len(p.Members))
+	for _, m := range p.Members {
+		ids = append(ids, m.Hero)
+	}
+
+	return ids
+}
+
+func (p *Party) Has(heroId string) bool {
+	for _, m := range p.Members {
+		if m.Hero == heroId {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Role returns the formation role of a member, or the middle for heroes
+// outside the party.
+func (p *Party) Role(heroId string) string {
+	for _, m := range p.Members {
+		if m.Hero == heroId {
+			return m.Role
+		}
+	}
+
+	return RoleMiddle
+}
+
+// PartyOf finds the party a hero belongs to.
+func (s *State) PartyOf(heroId string) (*Party, bool) {
+	for i := range s.Parties {
+		if s.Parties[i].Has(heroId) {
+			return &s.Parties[i], true
+		}
+	}
+
+	return nil, false
+}
@@ -0,0 +1,40 @@
+//------------------------------------------------------------------------------
+//    Copyright 2019 Jeff Sharpe (zeropointx.io)
+//
+//    Licensed under the Apache License, Version 2.0 (the "License");
+//    you may not use this file except in compliance with the License.
+//    You may obtain a copy of the License at
+//
+//        http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS,
+//    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//    See the License for the specific language governing permissions and
+//    limitations under the License.
+//------------------------------------------------------------------------------
+
+package state
+
+import "github.com/zpxio/heromanager/internal/game/state/hero"
+
+// Candidate is a hero waiting in the recruitment pool to be hired, for a fee,
+// until it expires.
+type Candidate struct {
+	Hero    hero.Hero `json:"hero"`
+	Fee     int       `json:"fee"`
+	Arrived uint64    `json:"arrived"`
+	Expires uint64    `json:"expires"`
+}
+
+// FindCandidate returns the index of a candidate in the recruitment pool by
+// hero ID.
+func (s *State) FindCandidate(heroId string) (int, bool) {
+	for i, c := range s.Candidates {
+		if c.Hero.Id == heroId {
+			return i, true
+		}
+	}
+
+	return -1, false
+}
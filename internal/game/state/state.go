@@ -19,6 +19,32 @@ package state
 import "github.com/zpxio/heromanager/internal/game/state/hero"
 
 type State struct {
-	Tick   uint64
-	Heroes []hero.Hero
+	Tick     uint64
+	Heroes   []hero.Hero
+	NextHero uint64
+
+	// Candidates are the heroes available for hire, and Refreshed the tick
+	// the recruitment pool last refreshed.
+	Candidates []Candidate
+	Refreshed  uint64
+
+	// Parties group heroes, each hero belonging to at most one.
+	Parties   []Party
+	NextParty uint64
+
+	// Stash counts the items held by the guild, keyed by item ID.
+	Stash map[string]int
+
+	// Treasury is the money held by the guild, and Ledger every transaction
+	// that moved it, oldest first. Funded records whether the treasury has
+	// received the economy's starting funds.
+	Treasury int
+	Ledger   []Transaction
+	Funded   bool
+
+	// Expeditions are the quests in progress, and Results the quests
+	// resolved, oldest first.
+	Expeditions    []Expedition
+	Results        []Result
+	NextExpedition uint64
 }
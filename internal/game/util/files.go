@@ -17,39 +17,168 @@
 package util
 
 import (
+	"fmt"
 	"github.com/sirupsen/logrus"
+	"io/fs"
 	"io/ioutil"
 	"log"
+	"os"
 	"path"
 	"path/filepath"
-	"runtime"
+	"sort"
+	"strings"
 )
 
+// GameDirBasePath anchors relative game directories. It is empty unless set,
+// leaving relative directories, such as the -data flag, to resolve against
+// the working directory. Tests anchor it with AnchorModuleRoot.
 var GameDirBasePath string
 
-func init() {
-	_, filename, _, _ := runtime.Caller(0)
+// EmbeddedDir is the game directory of the default data built into the
+// binary. Paths below it, such as "embedded:/packs/winter", address the
+// embedded data the same way.
+const EmbeddedDir = "embedded:"
 
-	GameDirBasePath = FindAncestor(filename, "heromanager")
+var embeddedData fs.FS
 
-	log.Printf("Using Game Directory: %s", GameDirBasePath)
-}
+// DataExtensions are the file extensions recognized as game data.
+var DataExtensions = []string{".yml", ".yaml", ".json"}
 
 func FindAncestor(dir string, targetDir string) string {
 	ancestor := dir
 
 	for path.Base(ancestor) != targetDir {
-		ancestor = path.Dir(ancestor)
-		if ancestor == "." {
-			return ancestor
+		parent := path.Dir(ancestor)
+		if parent == "." || parent == ancestor {
+			return "."
 		}
+		ancestor = parent
 	}
 
 	return ancestor
 }
 
+// FindModuleRoot walks up from dir to the nearest directory holding a go.mod
+// file. It returns an empty string when there is none, as is the case for
+// an installed binary whose source tree is not present.
+func FindModuleRoot(dir string) string {
+	ancestor := dir
+
+	for {
+		if _, err := os.Stat(filepath.Join(ancestor, "go.mod")); err == nil {
+			return ancestor
+		}
+
+		parent := filepath.Dir(ancestor)
+		if parent == ancestor {
+			return ""
+		}
+		ancestor = parent
+	}
+}
+
+// AnchorModuleRoot anchors relative game directories at the root of the
+// module holding the working directory, so tests running in a package
+// directory find the shared testdata.
+func AnchorModuleRoot() error {
+	wd, err := os.Getwd()
+	if err != nil {
+		return err
+	}
+
+	root := FindModuleRoot(wd)
+	if root == "" {
+		return fmt.Errorf("no module root above: %s", wd)
+	}
+	GameDirBasePath = root
+
+	return nil
+}
+
+// ResolvePath locates a data path within a game directory. Absolute data
+// paths are used as given, absolute game directories are used without the
+// base path, and anything else is anchored at GameDirBasePath.
+func ResolvePath(gameDir string, dataPath string) string {
+	if filepath.IsAbs(dataPath) {
+		return dataPath
+	}
+	if filepath.IsAbs(gameDir) {
+		return filepath.Join(gameDir, dataPath)
+	}
+
+	return filepath.Join(GameDirBasePath, gameDir, dataPath)
+}
+
+// SetEmbeddedData provides the default data served from EmbeddedDir.
+func SetEmbeddedData(data fs.FS) {
+	embeddedData = data
+}
+
+// EmbeddedData returns the default data served from EmbeddedDir.
+func EmbeddedData() fs.FS {
+	return embeddedData
+}
+
+func IsEmbedded(gameDir string) bool {
+	return strings.HasPrefix(gameDir, EmbeddedDir)
+}
+
+// embeddedPath locates a data path within the embedded data.
+func embeddedPath(gameDir string, dataPath string) (string, error) {
+	if embeddedData == nil {
+		return "", fmt.Errorf("no embedded game data: %w", fs.ErrNotExist)
+	}
+
+	p := path.Join("/", strings.TrimPrefix(filepath.ToSlash(gameDir), EmbeddedDir), filepath.ToSlash(dataPath))
+	if p == "/" {
+		return ".", nil
+	}
+
+	return strings.TrimPrefix(p, "/"), nil
+}
+
+// StatData describes a data path within a game directory.
+func StatData(gameDir string, dataPath string) (fs.FileInfo, error) {
+	if IsEmbedded(gameDir) {
+		p, err := embeddedPath(gameDir, dataPath)
+		if err != nil {
+			return nil, err
+		}
+		return fs.Stat(embeddedData, p)
+	}
+
+	return os.Stat(ResolvePath(gameDir, dataPath))
+}
+
+// ReadDataDir lists a directory within a game directory.
+func ReadDataDir(gameDir string, dataPath string) ([]fs.DirEntry, error) {
+	if IsEmbedded(gameDir) {
+		p, err := embeddedPath(gameDir, dataPath)
+		if err != nil {
+			return nil, err
+		}
+		return fs.ReadDir(embeddedData, p)
+	}
+
+	return os.ReadDir(ResolvePath(gameDir, dataPath))
+}
+
 func GameFileData(gameDir string, dataPath string) ([]byte, error) {
-	relpath := path.Join(GameDirBasePath, gameDir, dataPath)
+	if IsEmbedded(gameDir) {
+		p, err := embeddedPath(gameDir, dataPath)
+		if err != nil {
+			return make([]byte, 0), err
+		}
+
+		data, err := fs.ReadFile(embeddedData, p)
+		if err != nil {
+			logrus.Errorf("embedded file data read: %v ", err)
+			return make([]byte, 0), err
+		}
+		return data, nil
+	}
+
+	relpath := ResolvePath(gameDir, dataPath)
 
 	abspath, err := filepath.Abs(relpath)
 	if err != nil {
@@ -64,3 +193,125 @@ func GameFileData(gameDir string, dataPath string) ([]byte, error) {
 
 	return data, err
 }
+
+// IsDataFile checks whether a file name has a recognized data extension.
+func IsDataFile(name string) bool {
+	ext := strings.ToLower(filepath.Ext(name))
+	for _, dataExt := range DataExtensions {
+		if ext == dataExt {
+			return true
+		}
+	}
+
+	return false
+}
+
+// DataFiles lists the data files at a path within a game directory. A file
+// is returned as given; a directory is searched recursively. The returned
+// paths are relative to the game directory and sorted, so that loading is
+// deterministic.
+func DataFiles(gameDir string, dataPath string) ([]string, error) {
+	info, err := StatData(gameDir, dataPath)
+	if err != nil {
+		return nil, err
+	}
+	if !info.IsDir() {
+		return []string{dataPath}, nil
+	}
+
+	files := make([]string, 0)
+	collect := func(root string) fs.WalkDirFunc {
+		return func(file string, entry fs.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+			if entry.IsDir() || !IsDataFile(entry.Name()) {
+				return nil
+			}
+
+			rel, err := filepath.Rel(root, file)
+			if err != nil {
+				return err
+			}
+			files = append(files, filepath.Join(dataPath, rel))
+
+			return nil
+		}
+	}
+
+	if IsEmbedded(gameDir) {
+		root, _ := embeddedPath(gameDir, dataPath)
+		err = fs.WalkDir(embeddedData, root, collect(root))
+	} else {
+		root := ResolvePath(gameDir, dataPath)
+		err = filepath.WalkDir(root, collect(root))
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Strings(files)
+
+	return files, nil
+}
+
+// KindFiles lists the data files for one kind of content: a single file
+// named after the kind with any data extension, followed by every data file
+// in the directory tree named after the kind. Missing files and directories
+// are skipped, so a kind without data yields an empty list.
+func KindFiles(gameDir string, kind string) ([]string, error) {
+	files := make([]string, 0)
+
+	for _, ext := range DataExtensions {
+		name := kind + ext
+		if info, err := StatData(gameDir, name); err == nil && !info.IsDir() {
+			files = append(files, name)
+		}
+	}
+
+	if info, err := StatData(gameDir, kind); err == nil && info.IsDir() {
+		tree, err := DataFiles(gameDir, kind)
+		if err != nil {
+			return nil, err
+		}
+		files = append(files, tree...)
+	}
+
+	return files, nil
+}
+
+// ExportData writes every file of a data filesystem below a target directory,
+// returning the paths written. Existing files are left alone, and reported as
+// an error, unless overwrite is set.
+func ExportData(data fs.FS, targetDir string, overwrite bool) ([]string, error) {
+	written := make([]string, 0)
+
+	err := fs.WalkDir(data, ".", func(file string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		target := filepath.Join(targetDir, filepath.FromSlash(file))
+		if entry.IsDir() {
+			return os.MkdirAll(target, 0755)
+		}
+
+		if _, err := os.Stat(target); err == nil && !overwrite {
+			return fmt.Errorf("refusing to overwrite existing file: %s", target)
+		}
+
+		content, err := fs.ReadFile(data, file)
+		if err != nil {
+			return err
+		}
+		err = ioutil.WriteFile(target, content, 0644)
+		if err != nil {
+			return err
+		}
+		written = append(written, target)
+
+		return nil
+	})
+
+	return written, err
+}
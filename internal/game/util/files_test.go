@@ -18,8 +18,11 @@ package util
 
 import (
 	"github.com/stretchr/testify/suite"
+	"io/ioutil"
 	"path"
+	"path/filepath"
 	"testing"
+	"testing/fstest"
 )
 
 type FileTestSuite struct {
@@ -41,3 +44,129 @@ func (t *FileTestSuite) TestFindAncestor_NoMatch() {
 	dirs := []string{"data", "dir1", "dir2", "dir3", "sub", "data"}
 	t.Equal(".", FindAncestor(path.Join(dirs...), "baz"))
 }
+
+func (t *FileTestSuite) TestFindAncestor_Absolute() {
+	t.Equal(".", FindAncestor("/data/dir1", "baz"))
+}
+
+func (t *FileTestSuite) TestFindModuleRoot() {
+	t.NotEmpty(GameDirBasePath)
+	t.Equal(GameDirBasePath, FindModuleRoot(filepath.Join(GameDirBasePath, "internal", "game")))
+	t.Equal("", FindModuleRoot(t.T().TempDir()))
+}
+
+func (t *FileTestSuite) TestResolvePath() {
+	t.Equal("/abs/file.yml", ResolvePath("data", "/abs/file.yml"))
+	t.Equal("/srv/data/races.yml", ResolvePath("/srv/data", "races.yml"))
+	t.Equal(filepath.Join(GameDirBasePath, "data", "races.yml"), ResolvePath("data", "races.yml"))
+}
+
+func (t *FileTestSuite) TestResolvePath_WorkingDirectory() {
+	anchor := GameDirBasePath
+	defer func() { GameDirBasePath = anchor }()
+
+	GameDirBasePath = ""
+	t.Equal(filepath.Join("data", "races.yml"), ResolvePath("data", "races.yml"))
+}
+
+func (t *FileTestSuite) TestDataFiles_Directory() {
+	files, err := DataFiles("testdata/game/data/tree", "races")
+
+	t.Require().Nil(err)
+	t.Equal([]string{"races/core.yml", "races/wild/deep.json", "races/wild/forest.yaml"}, files)
+}
+
+func (t *FileTestSuite) TestDataFiles_File() {
+	files, err := DataFiles("testdata/game/data/tree", "races.yml")
+
+	t.Require().Nil(err)
+	t.Equal([]string{"races.yml"}, files)
+}
+
+func (t *FileTestSuite) TestDataFiles_Missing() {
+	_, err := DataFiles("testdata/game/data/tree", "professions")
+
+	t.NotNil(err)
+}
+
+func (t *FileTestSuite) TestKindFiles() {
+	files, err := KindFiles("testdata/game/data/tree", "races")
+	t.Require().Nil(err)
+	t.Equal([]string{"races.yml", "races/core.yml", "races/wild/deep.json", "races/wild/forest.yaml"}, files)
+
+	files, err = KindFiles("testdata/game/data/tree", "castes")
+	t.Require().Nil(err)
+	t.Equal([]string{"castes.json"}, files)
+
+	files, err = KindFiles("testdata/game/data/tree", "professions")
+	t.Require().Nil(err)
+	t.Empty(files)
+}
+
+func (t *FileTestSuite) TestKindFiles_AbsoluteGameDir() {
+	files, err := KindFiles(filepath.Join(GameDirBasePath, "testdata/game/data/tree"), "castes")
+
+	t.Require().Nil(err)
+	t.Equal([]string{"castes.json"}, files)
+}
+
+func embeddedTestData() fstest.MapFS {
+	return fstest.MapFS{
+		"races.yml":             {Data: []byte("Human:\n  name: Human\n")},
+		"races/core.yml":        {Data: []byte("Dwarf:\n  name: Dwarf\n")},
+		"races/notes.txt":       {Data: []byte("ignored")},
+		"packs/winter/pack.yml": {Data: []byte("name: Winter\n")},
+	}
+}
+
+func (t *FileTestSuite) TestEmbedded() {
+	SetEmbeddedData(embeddedTestData())
+	defer SetEmbeddedData(nil)
+
+	t.True(IsEmbedded(EmbeddedDir))
+	t.True(IsEmbedded("embedded:/packs/winter"))
+	t.False(IsEmbedded("data"))
+
+	data, err := GameFileData(EmbeddedDir, "races/core.yml")
+	t.Require().Nil(err)
+	t.Equal("Dwarf:\n  name: Dwarf\n", string(data))
+
+	files, err := KindFiles(EmbeddedDir, "races")
+	t.Require().Nil(err)
+	t.Equal([]string{"races.yml", "races/core.yml"}, files)
+
+	entries, err := ReadDataDir(filepath.Join(EmbeddedDir, "packs"), "winter")
+	t.Require().Nil(err)
+	t.Len(entries, 1)
+
+	info, err := StatData("embedded:/packs", "winter/pack.yml")
+	t.Require().Nil(err)
+	t.False(info.IsDir())
+}
+
+func (t *FileTestSuite) TestEmbedded_Missing() {
+	_, err := GameFileData(EmbeddedDir, "races.yml")
+	t.NotNil(err)
+
+	files, err := KindFiles(EmbeddedDir, "races")
+	t.Require().Nil(err)
+	t.Empty(files)
+}
+
+func (t *FileTestSuite) TestExportData() {
+	dir := t.T().TempDir()
+
+	written, err := ExportData(embeddedTestData(), dir, false)
+	t.Require().Nil(err)
+	t.Len(written, 4)
+
+	data, err := ioutil.ReadFile(filepath.Join(dir, "packs", "winter", "pack.yml"))
+	t.Require().Nil(err)
+	t.Equal("name: Winter\n", string(data))
+
+	_, err = ExportData(embeddedTestData(), dir, false)
+	t.NotNil(err)
+
+	_, err = ExportData(embeddedTestData(), dir, true)
+	t.Nil(err)
+}
@@ -0,0 +1,119 @@
+//------------------------------------------------------------------------------
+//    Copyright 2018 Jeff Sharpe (zeropointx.io)
+//
+//    Licensed under the Apache License, Version 2.0 (the "License");
+//    you may not use this file except in compliance with the License.
+//    You may obtain a copy of the License at
+//
+//        http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS,
+//    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//    See the License for the specific language governing permissions and
+//    limitations under the License.
+//------------------------------------------------------------------------------
+
+package game
+
+import (
+	"github.com/zpxio/heromanager/internal/game/util"
+	"log"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// DataWatcher polls the world data directory and reloads the world whenever
+// a data file is added, removed or modified.
+type DataWatcher struct {
+	world    *World
+	interval time.Duration
+	snapshot map[string]fileStamp
+	stop     chan struct{}
+}
+
+type fileStamp struct {
+	modified time.Time
+	size     int64
+}
+
+// Watch starts reloading the world when its data directory changes, checking
+// once per interval.
+func (world *World) Watch(interval time.Duration) *DataWatcher {
+	w := &DataWatcher{world: world, interval: interval, stop: make(chan struct{})}
+	w.snapshot = w.scan()
+
+	go w.run()
+
+	return w
+}
+
+func (w *DataWatcher) Stop() {
+	close(w.stop)
+}
+
+func (w *DataWatcher) run() {
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-w.stop:
+			return
+		case <-ticker.C:
+			w.check()
+		}
+	}
+}
+
+// check reloads the world if the data directory differs from the last scan.
+func (w *DataWatcher) check() bool {
+	snapshot := w.scan()
+	if sameSnapshot(w.snapshot, snapshot) {
+		return false
+	}
+	w.snapshot = snapshot
+
+	log.Printf("Game data changed, reloading")
+	_, err := w.world.Reload()
+	if err != nil {
+		log.Printf("ERROR: Rejected game data reload: %s", err)
+	}
+
+	return true
+}
+
+func (w *DataWatcher) scan() map[string]fileStamp {
+	snapshot := make(map[string]fileStamp)
+
+	root := util.ResolvePath(w.world.dataDirectory, "")
+	err := filepath.Walk(root, func(file string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if !info.IsDir() && util.IsDataFile(info.Name()) {
+			snapshot[file] = fileStamp{modified: info.ModTime(), size: info.Size()}
+		}
+
+		return nil
+	})
+	if err != nil {
+		log.Printf("ERROR: Could not scan game data: %s", err)
+	}
+
+	return snapshot
+}
+
+func sameSnapshot(a map[string]fileStamp, b map[string]fileStamp) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for file, stamp := range a {
+		if other, found := b[file]; !found || other != stamp {
+			return false
+		}
+	}
+
+	return true
+}
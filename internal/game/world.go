@@ -20,9 +20,19 @@ import (
 	"fmt"
 	"github.com/ghodss/yaml"
 	"github.com/zpxio/heromanager/internal/game/data/classifier"
+	"github.com/zpxio/heromanager/internal/game/data/content"
+	"github.com/zpxio/heromanager/internal/game/data/derived"
+	"github.com/zpxio/heromanager/internal/game/data/item"
+	"github.com/zpxio/heromanager/internal/game/data/progression"
+	"github.com/zpxio/heromanager/internal/game/data/rolling"
+	"github.com/zpxio/heromanager/internal/game/data/skills"
 	"github.com/zpxio/heromanager/internal/game/state"
+	"github.com/zpxio/heromanager/internal/game/state/hero"
 	"log"
+	"math/rand"
 	"os"
+	"sort"
+	"strings"
 	"sync"
 	"time"
 )
@@ -33,22 +43,286 @@ type World struct {
 	running      bool
 	runningLatch sync.WaitGroup
 
-	state state.State
-	saver StateSaver
+	// dataLock guards the game data. Reloaded data waits in pending until
+	// the start of the next tick, so a tick never sees two data sets. When
+	// both locks are needed, stateLock is taken first.
+	dataLock      sync.Mutex
+	dataDirectory string
+	data          *content.Set
+	pending       *content.Set
+	rng           *rand.Rand
+
+	// stateLock guards the state, and the random source used to update it,
+	// between the tick loop and API requests.
+	stateLock sync.Mutex
+	state     state.State
+	saver     StateSaver
 }
 
 func CreateWorld() *World {
-	w := World{tick: Create(1, time.Millisecond*1000)}
+	w := World{tick: Create(1, time.Millisecond*1000), data: content.NewSet()}
+	w.rng = rand.New(rand.NewSource(time.Now().UnixNano()))
 
 	w.tick.Subscribe(&w)
+	w.tick.Subscribe(&Payroll{world: &w})
+	w.tick.Subscribe(&Tavern{world: &w})
 
 	return &w
 }
 
 func (world *World) Load(dataDirectory string) {
 	log.Printf("Loading world resources from: %s", dataDirectory)
-	manifest := classifier.NewManifest()
-	classifier.LoadRaces(dataDirectory, "races.yml", manifest)
+	world.dataDirectory = dataDirectory
+
+	set, err := content.Load(dataDirectory)
+	if err != nil {
+		log.Printf("ERROR: Invalid game data: %s", err)
+		return
+	}
+
+	world.dataLock.Lock()
+	defer world.dataLock.Unlock()
+	world.data = set
+}
+
+// Reload loads the data directory again and swaps the result in at the start
+// of the next tick, or immediately when the world is not running. The reload
+// is rejected, leaving the current data in place, when the new data is
+// invalid or no longer defines a classifier used by a hero.
+func (world *World) Reload() (*content.Diff, error) {
+	log.Printf("Reloading world resources from: %s", world.dataDirectory)
+
+	set, err := content.Load(world.dataDirectory)
+	if err != nil {
+		return nil, err
+	}
+
+	world.stateLock.Lock()
+	defer world.stateLock.Unlock()
+	world.dataLock.Lock()
+	defer world.dataLock.Unlock()
+
+	orphans := world.orphans(set)
+	if len(orphans) > 0 {
+		return nil, &OrphanError{Orphans: orphans}
+	}
+
+	current := world.data
+	if world.pending != nil {
+		current = world.pending
+	}
+	diff := content.Compare(current, set)
+
+	if world.running {
+		world.pending = set
+	} else {
+		world.data = set
+		world.dropCandidates(set)
+	}
+	log.Printf("Reloaded world resources: %s", diff.String())
+
+	return &diff, nil
+}
+
+// swapPending installs reloaded data, unless heroes created since the reload
+// use classifiers it no longer defines.
+func (world *World) swapPending() {
+	world.stateLock.Lock()
+	defer world.stateLock.Unlock()
+	world.dataLock.Lock()
+	defer world.dataLock.Unlock()
+
+	if world.pending == nil {
+		return
+	}
+
+	orphans := world.orphans(world.pending)
+	if len(orphans) > 0 {
+		log.Printf("ERROR: Discarding reloaded data: %s", &OrphanError{Orphans: orphans})
+	} else {
+		world.data = world.pending
+		world.dropCandidates(world.data)
+	}
+	world.pending = nil
+}
+
+// dropCandidates turns away recruitment candidates whose classifiers a data
+// set no longer defines, so they can never be hired. It must be called with
+// the state lock held.
+func (world *World) dropCandidates(set *content.Set) {
+	kept := world.state.Candidates[:0]
+	for _, c := range world.state.Candidates {
+		if missing := missingClassifiers(&c.Hero, set.Classifiers); len(missing) > 0 {
+			log.Printf("Dropping candidate %s using missing %s", c.Hero.Id, strings.Join(missing, ", "))
+			continue
+		}
+		kept = append(kept, c)
+	}
+	world.state.Candidates = kept
+}
+
+// missingClassifiers lists each classifier a hero uses that a manifest does
+// not define, as target and ID.
+func missingClassifiers(h *hero.Hero, manifest *classifier.ClassifierManifest) []string {
+	targets := []string{classifier.ConflictRaces, classifier.ConflictCastes, classifier.ConflictProfessions}
+	for target := range h.Extra {
+		targets = append(targets, target)
+	}
+	sort.Strings(targets[3:])
+
+	missing := make([]string, 0)
+	for _, target := range targets {
+		id := h.Classifier(target)
+		if id == "" {
+			continue
+		}
+		if _, found := manifest.Resolve(target, id); !found {
+			missing = append(missing, target+": "+id)
+		}
+	}
+
+	return missing
+}
+
+// orphans lists every hero classifier, every item held, and every
+// expedition quest missing from a data set. Candidates are not heroes yet,
+// and are dropped rather than blocking the data. It must be called with both
+// locks held.
+func (world *World) orphans(set *content.Set) []string {
+	orphans := make([]string, 0)
+
+	for i := range world.state.Heroes {
+		h := &world.state.Heroes[i]
+		for _, missing := range missingClassifiers(h, set.Classifiers) {
+			orphans = append(orphans, fmt.Sprintf("hero %s uses missing %s", h.Id, missing))
+		}
+	}
+
+	for _, h := range world.state.Heroes {
+		for _, id := range heldItems(h.Inventory) {
+			if _, found := set.Items.Resolve(id); !found {
+				orphans = append(orphans, fmt.Sprintf("hero %s carries missing item: %s", h.Id, id))
+			}
+		}
+		for _, slot := range item.Slots() {
+			if id, equipped := h.Equipment[slot]; equipped {
+				if _, found := set.Items.Resolve(id); !found {
+					orphans = append(orphans, fmt.Sprintf("hero %s equips missing item: %s", h.Id, id))
+				}
+			}
+		}
+	}
+	for _, id := range heldItems(world.state.Stash) {
+		if _, found := set.Items.Resolve(id); !found {
+			orphans = append(orphans, "stash holds missing item: "+id)
+		}
+	}
+
+	for _, e := range world.state.Expeditions {
+		if _, found := set.Quests.Resolve(e.Quest); !found {
+			orphans = append(orphans, fmt.Sprintf("expedition %s uses missing quest: %s", e.Id, e.Quest))
+		}
+	}
+
+	return orphans
+}
+
+// heldItems lists the IDs of items held, sorted.
+func heldItems(held map[string]int) []string {
+	ids := make([]string, 0, len(held))
+	for id := range held {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	return ids
+}
+
+// OrphanError rejects game data that no longer defines classifiers in use.
+type OrphanError struct {
+	Orphans []string
+}
+
+func (e *OrphanError) Error() string {
+	return fmt.Sprintf("game data would orphan heroes: %s", strings.Join(e.Orphans, "; "))
+}
+
+func (world *World) content() *content.Set {
+	world.dataLock.Lock()
+	defer world.dataLock.Unlock()
+
+	return world.data
+}
+
+func (world *World) Manifest() *classifier.ClassifierManifest {
+	return world.content().Classifiers
+}
+
+// Packs lists the content packs applied over the base data, in order.
+func (world *World) Packs() []content.Pack {
+	return world.content().Packs
+}
+
+func (world *World) DerivedStats() *derived.Definitions {
+	return world.content().Derived
+}
+
+func (world *World) Rolling() *rolling.Config {
+	return world.content().Rolling
+}
+
+func (world *World) Progression() *progression.Config {
+	return world.content().Progression
+}
+
+func (world *World) Skills() *skills.Definitions {
+	return world.content().Skills
+}
+
+// AwardExperience gives a hero experience, levelling it up as the current
+// game data dictates.
+func (world *World) AwardExperience(id string, amount int) (*hero.Advancement, error) {
+	world.stateLock.Lock()
+	defer world.stateLock.Unlock()
+
+	h, found := world.FindHero(id)
+	if !found {
+		return nil, fmt.Errorf("unknown hero: %s", id)
+	}
+
+	data := world.content()
+
+	return h.GainExperience(amount, data.Progression, data.Classifiers)
+}
+
+// Random returns the world random source. It is only safe to use from the
+// tick loop or while otherwise holding exclusive access to the world.
+func (world *World) Random() *rand.Rand {
+	return world.rng
+}
+
+// CopyHero returns a copy of a hero that is safe to read without holding the
+// state lock.
+func (world *World) CopyHero(id string) (hero.Hero, bool) {
+	world.stateLock.Lock()
+	defer world.stateLock.Unlock()
+
+	if h, found := world.FindHero(id); found {
+		return h.Copy(), true
+	}
+
+	return hero.Hero{}, false
+}
+
+// FindHero returns the hero itself, so callers must hold the state lock.
+func (world *World) FindHero(id string) (*hero.Hero, bool) {
+	for i := range world.state.Heroes {
+		if world.state.Heroes[i].Id == id {
+			return &world.state.Heroes[i], true
+		}
+	}
+
+	return nil, false
 }
 
 func (world *World) Start() {
@@ -69,7 +343,9 @@ func (world *World) Shutdown() {
 }
 
 func (world *World) SaveState() {
+	world.stateLock.Lock()
 	stateYaml, err := yaml.Marshal(world.state)
+	world.stateLock.Unlock()
 	if err != nil {
 		log.Printf("ERROR: Could not compile world state.")
 		return
@@ -89,7 +365,15 @@ func (world *World) SaveState() {
 }
 
 func (world *World) OnTick(id uint64) {
+	world.swapPending()
+
 	log.Printf("Executing world updates: T+%d", id)
+
+	world.stateLock.Lock()
+	defer world.stateLock.Unlock()
+
+	world.recoverWounds()
+	world.progressExpeditions(id)
 }
 
 func (world *World) Tick() uint64 {
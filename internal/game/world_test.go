@@ -0,0 +1,166 @@
+//------------------------------------------------------------------------------
+//    Copyright 2018 Jeff Sharpe (zeropointx.io)
+//
+//    Licensed under the Apache License, Version 2.0 (the "License");
+//    you may not use this file except in compliance with the License.
+//    You may obtain a copy of the License at
+//
+//        http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS,
+//    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//    See the License for the specific language governing permissions and
+//    limitations under the License.
+//------------------------------------------------------------------------------
+
+package game
+
+import (
+	"github.com/stretchr/testify/suite"
+	"github.com/zpxio/heromanager/internal/game/data/attributes"
+	"github.com/zpxio/heromanager/internal/game/state/hero"
+	"github.com/zpxio/heromanager/internal/game/util"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+type WorldTestSuite struct {
+	suite.Suite
+	dataDir string
+}
+
+func TestWorldSuite(t *testing.T) {
+	suite.Run(t, new(WorldTestSuite))
+}
+
+// SetupTest copies the layered test data somewhere it can be edited.
+func (s *WorldTestSuite) SetupTest() {
+	s.dataDir = s.T().TempDir()
+
+	source := util.ResolvePath("testdata/game/data/layered", "")
+	err := filepath.Walk(source, func(file string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+
+		rel, _ := filepath.Rel(source, file)
+		target := filepath.Join(s.dataDir, rel)
+		if info.IsDir() {
+			return os.MkdirAll(target, 0755)
+		}
+
+		data, err := ioutil.ReadFile(file)
+		if err != nil {
+			return err
+		}
+		return ioutil.WriteFile(target, data, 0644)
+	})
+	s.Require().Nil(err)
+}
+
+func (s *WorldTestSuite) writeData(file string, data string) {
+	s.Require().Nil(ioutil.WriteFile(filepath.Join(s.dataDir, file), []byte(data), 0644))
+}
+
+func (s *WorldTestSuite) loadWorld() *World {
+	w := CreateWorld()
+	w.Load(s.dataDir)
+	s.Require().Len(w.Manifest().AllCastes(), 3)
+
+	return w
+}
+
+func (s *WorldTestSuite) TestReload_Unchanged() {
+	w := s.loadWorld()
+
+	diff, err := w.Reload()
+	s.Require().Nil(err)
+	s.True(diff.Empty())
+}
+
+func (s *WorldTestSuite) TestReload() {
+	w := s.loadWorld()
+	s.writeData("castes/core.yml", "Noble:\n  name: Noble\nSerf:\n  name: Serf\nElder:\n  name: Elder\n")
+
+	diff, err := w.Reload()
+	s.Require().Nil(err)
+	s.Equal([]string{"Elder"}, diff.Classifiers["castes"].Added)
+
+	_, found := w.Manifest().ResolveCaste("Elder")
+	s.True(found)
+}
+
+func (s *WorldTestSuite) TestReload_Invalid() {
+	w := s.loadWorld()
+	s.writeData("races.yml", "Dwarf: [")
+
+	_, err := w.Reload()
+	s.NotNil(err)
+	s.Len(w.Manifest().AllRaces(), 3)
+}
+
+func (s *WorldTestSuite) TestReload_Orphans() {
+	w := s.loadWorld()
+	w.state.Heroes = append(w.state.Heroes, hero.Hero{Id: "H1", Race: "Dwarf", Caste: "Serf"})
+	s.writeData("castes/core.yml", "Noble:\n  name: Noble\n")
+
+	_, err := w.Reload()
+	s.Require().NotNil(err)
+	orphans, ok := err.(*OrphanError)
+	s.Require().True(ok)
+	s.Equal([]string{"hero H1 uses missing castes: Serf"}, orphans.Orphans)
+
+	_, found := w.Manifest().ResolveCaste("Serf")
+	s.True(found)
+}
+
+func (s *WorldTestSuite) TestAwardExperience() {
+	s.writeData("progression.yml", "curve: basic\ncurves:\n  basic:\n    max: 3\n    thresholds: [100, 200]\ngrowth:\n  points: 5\n")
+	w := s.loadWorld()
+	w.state.Heroes = append(w.state.Heroes, hero.Hero{Id: "H1", Race: "Dwarf", Level: 1, Attributes: attributes.NewAttributeValues()})
+
+	a, err := w.AwardExperience("H1", 150)
+	s.Require().Nil(err)
+	s.Equal(2, a.To)
+
+	h, _ := w.FindHero("H1")
+	s.Equal(2, h.Level)
+	s.Equal(150, h.Experience)
+
+	_, err = w.AwardExperience("H2", 150)
+	s.NotNil(err)
+}
+
+func (s *WorldTestSuite) TestReload_BetweenTicks() {
+	w := s.loadWorld()
+	w.running = true
+	s.writeData("castes/core.yml", "Noble:\n  name: Noble\nSerf:\n  name: Serf\nElder:\n  name: Elder\n")
+
+	_, err := w.Reload()
+	s.Require().Nil(err)
+
+	// The new data waits for the next tick
+	_, found := w.Manifest().ResolveCaste("Elder")
+	s.False(found)
+
+	w.OnTick(w.Tick())
+	_, found = w.Manifest().ResolveCaste("Elder")
+	s.True(found)
+}
+
+func (s *WorldTestSuite) TestWatcher_Check() {
+	w := s.loadWorld()
+	watcher := &DataWatcher{world: w}
+	watcher.snapshot = watcher.scan()
+
+	s.False(watcher.check())
+
+	s.writeData("castes/more.yml", "Elder:\n  name: Elder\n")
+	s.True(watcher.check())
+	s.Len(w.Manifest().AllCastes(), 4)
+
+	s.False(watcher.check())
+}